@@ -0,0 +1,547 @@
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// BIOSAttributeChange holds the values of a BIOS attribute with a change staged in the Bios/Settings resource.
+type BIOSAttributeChange struct {
+	// Current is the value the BIOS currently applies.
+	Current any
+	// Pending is the staged value that the BIOS applies on the next reset.
+	Pending any
+}
+
+// redfishBIOS holds the Bios resource of a system along with where changes to it are staged.
+type redfishBIOS struct {
+	bios     *redfish.Bios
+	settings common.Settings
+}
+
+// BIOSAttributes returns all the BIOS attributes of the system with the values currently applied.
+func (bmc *BMC) BIOSAttributes() (map[string]any, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting BIOS attributes from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	systemBIOS, err := redfishGetBIOS(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system's bios: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios: %w", err)
+	}
+
+	return systemBIOS.bios.Attributes, nil
+}
+
+// PendingBIOSAttributes returns the BIOS attributes with a change staged in the Bios/Settings resource that differs
+// from the value currently applied. Staged changes are applied by the BIOS on the next system reset.
+func (bmc *BMC) PendingBIOSAttributes() (map[string]BIOSAttributeChange, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting pending BIOS attributes from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	systemBIOS, err := redfishGetBIOS(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system's bios: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios: %w", err)
+	}
+
+	changes := make(map[string]BIOSAttributeChange)
+
+	settingsURI := systemBIOS.settings.SettingsObject.String()
+	if settingsURI == "" || settingsURI == systemBIOS.bios.ODataID {
+		klog.V(100).Info("The BIOS has no separate settings resource so no changes are pending")
+
+		return changes, nil
+	}
+
+	var settings struct {
+		Attributes map[string]any
+	}
+
+	err = redfishGetJSON(redfishClient, settingsURI, &settings)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish bios settings: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios settings: %w", err)
+	}
+
+	// Some BMCs only list the staged attributes in the settings resource while others list all of them, so only
+	// attributes with a different value are pending.
+	for name, pending := range settings.Attributes {
+		current := systemBIOS.bios.Attributes[name]
+		if !biosValuesEqual(current, pending) {
+			changes[name] = BIOSAttributeChange{Current: current, Pending: pending}
+		}
+	}
+
+	return changes, nil
+}
+
+// StageBIOSAttributes validates the attributes against the BIOS attribute registry, if the BMC provides one, and
+// stages them in the Bios/Settings resource. The changes are applied on the next system reset. Attributes that already
+// have the requested value are not staged.
+func (bmc *BMC) StageBIOSAttributes(attributes map[string]any) error {
+	_, err := bmc.stageBIOSAttributes(attributes)
+
+	return err
+}
+
+// stageBIOSAttributes implements StageBIOSAttributes and returns whether any attribute was staged.
+func (bmc *BMC) stageBIOSAttributes(attributes map[string]any) (bool, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return false, err
+	}
+
+	klog.V(100).Infof("Staging BIOS attributes %v from bmc's redfish endpoint", attributes)
+
+	if len(attributes) == 0 {
+		klog.V(100).Info("The BIOS attributes are empty")
+
+		return false, fmt.Errorf("bios 'attributes' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return false, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	systemBIOS, err := redfishGetBIOS(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system's bios: %v", err)
+
+		return false, fmt.Errorf("failed to get bios: %w", err)
+	}
+
+	registry, err := redfishGetAttributeRegistry(redfishClient, systemBIOS.bios.AttributeRegistry)
+	if err != nil {
+		klog.V(100).Infof("Failed to get bios attribute registry %s: %v", systemBIOS.bios.AttributeRegistry, err)
+
+		return false, fmt.Errorf("failed to get bios attribute registry %s: %w", systemBIOS.bios.AttributeRegistry, err)
+	}
+
+	if registry == nil {
+		klog.V(100).Infof("BIOS attribute registry %s not found, skipping validation", systemBIOS.bios.AttributeRegistry)
+	} else {
+		err = validateBIOSAttributes(registry, attributes)
+		if err != nil {
+			klog.V(100).Infof("Invalid BIOS attributes: %v", err)
+
+			return false, err
+		}
+	}
+
+	changedAttributes := make(map[string]any)
+
+	for name, value := range attributes {
+		if current, ok := systemBIOS.bios.Attributes[name]; ok && biosValuesEqual(current, value) {
+			continue
+		}
+
+		changedAttributes[name] = value
+	}
+
+	if len(changedAttributes) == 0 {
+		klog.V(100).Info("All BIOS attributes already have the requested values, nothing to stage")
+
+		return false, nil
+	}
+
+	var applyTime common.ApplyTime
+
+	// Only request an apply time when the BMC advertises support for it, since some BMCs reject the annotation.
+	if slices.Contains(systemBIOS.settings.SupportedApplyTimes, common.OnResetApplyTime) {
+		applyTime = common.OnResetApplyTime
+	}
+
+	err = systemBIOS.bios.UpdateBiosAttributesApplyAt(changedAttributes, applyTime)
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to stage BIOS attributes: %v", err)
+
+		return false, fmt.Errorf("failed to stage bios attributes: %w", err)
+	}
+
+	return true, nil
+}
+
+// ApplyBIOSAttributes stages the attributes using StageBIOSAttributes. If reboot is true, the system is then reset,
+// or powered on if it is off, and this method waits up to timeout for the BIOS to report the requested values. If
+// reboot is false, the attributes remain staged until the next reset. If every attribute already has the requested
+// value, nothing is staged and the system is not reset.
+func (bmc *BMC) ApplyBIOSAttributes(attributes map[string]any, reboot bool, timeout time.Duration) error {
+	staged, err := bmc.stageBIOSAttributes(attributes)
+	if err != nil {
+		return err
+	}
+
+	if !staged {
+		klog.V(100).Info("No BIOS attributes were staged, skipping system reset")
+
+		return nil
+	}
+
+	if !reboot {
+		return nil
+	}
+
+	klog.V(100).Info("Resetting system to apply staged BIOS attributes")
+
+	powerState, err := bmc.SystemPowerState()
+	if err != nil {
+		klog.V(100).Infof("Failed to get system's power state: %v", err)
+
+		return fmt.Errorf("failed to get system's power state: %w", err)
+	}
+
+	if powerState == string(redfish.OffPowerState) {
+		err = bmc.SystemPowerOn()
+	} else {
+		err = bmc.SystemForceReset()
+	}
+
+	if err != nil {
+		klog.V(100).Infof("Failed to reset system: %v", err)
+
+		return fmt.Errorf("failed to reset system: %w", err)
+	}
+
+	return bmc.WaitForBIOSAttributes(attributes, timeout)
+}
+
+// WaitForBIOSAttributes waits up to timeout until the BIOS reports the provided values for all the attributes.
+// Errors reading the attributes are ignored since the BMC may be unavailable while the system resets.
+func (bmc *BMC) WaitForBIOSAttributes(attributes map[string]any, timeout time.Duration) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting up to %s until BIOS attributes are %v", timeout, attributes)
+
+	var mismatched []string
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 10*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			current, err := bmc.BIOSAttributes()
+			if err != nil {
+				klog.V(100).Infof("Failed to get BIOS attributes: %v", err)
+
+				return false, nil
+			}
+
+			mismatched = nil
+
+			for name, value := range attributes {
+				if !biosValuesEqual(current[name], value) {
+					mismatched = append(mismatched, fmt.Sprintf("%s (current: %v, expected: %v)", name, current[name], value))
+				}
+			}
+
+			return len(mismatched) == 0, nil
+		})
+	if err == nil {
+		return nil
+	}
+
+	if len(mismatched) == 0 {
+		klog.V(100).Infof("Failed to read BIOS attributes within %s: %v", timeout, err)
+
+		return fmt.Errorf("failed to read bios attributes within %s: %w", timeout, err)
+	}
+
+	sort.Strings(mismatched)
+
+	klog.V(100).Infof("BIOS attributes not applied within %s: %v", timeout, mismatched)
+
+	return fmt.Errorf("bios attributes not applied within %s: %s: %w", timeout, strings.Join(mismatched, ", "), err)
+}
+
+// redfishGetBIOS uses the provided gofish APIClient and system index to get the Bios resource of a system and its
+// @Redfish.Settings annotation, which gofish does not expose.
+func redfishGetBIOS(redfishClient *gofish.APIClient, selector systemSelector) (*redfishBIOS, error) {
+	system, err := redfishGetSystem(redfishClient, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	bios, err := system.Bios()
+	if err != nil {
+		return nil, err
+	}
+
+	if bios == nil {
+		return nil, fmt.Errorf("system %s has no bios resource", system.ID)
+	}
+
+	var annotations struct {
+		Settings common.Settings `json:"@Redfish.Settings"`
+	}
+
+	err = redfishGetJSON(redfishClient, bios.ODataID, &annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redfishBIOS{bios: bios, settings: annotations.Settings}, nil
+}
+
+// redfishGetAttributeRegistry finds the attribute registry with the provided name, such as
+// BiosAttributeRegistry.v1_0_0, among the registries of the service. If the service does not provide it, nil is
+// returned without an error.
+func redfishGetAttributeRegistry(
+	redfishClient *gofish.APIClient, registryName string) (*redfish.AttributeRegistry, error) {
+	if registryName == "" {
+		return nil, nil
+	}
+
+	registryFiles, err := redfishClient.GetService().Registries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registries: %w", err)
+	}
+
+	for _, registryFile := range registryFiles {
+		if registryFile.ID != registryName && registryFile.Registry != registryName &&
+			!strings.HasPrefix(registryName, registryFile.ID+".") {
+			continue
+		}
+
+		for _, location := range registryFile.Location {
+			if location.URI == "" {
+				continue
+			}
+
+			return redfish.GetAttributeRegistry(redfishClient, location.URI)
+		}
+	}
+
+	return nil, nil
+}
+
+// redfishGetJSON gets the resource at uri and decodes its body into value.
+func redfishGetJSON(redfishClient *gofish.APIClient, uri string, value any) error {
+	response, err := redfishClient.Get(uri)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	return json.NewDecoder(response.Body).Decode(value)
+}
+
+// validateBIOSAttributes checks the attributes against the registry and returns an error describing every violation.
+func validateBIOSAttributes(registry *redfish.AttributeRegistry, attributes map[string]any) error {
+	registryAttributes := make(map[string]redfish.Attribute)
+
+	for _, attribute := range registry.RegistryEntries.Attributes {
+		registryAttributes[attribute.AttributeName] = attribute
+	}
+
+	var violations []string
+
+	for name, value := range attributes {
+		attribute, found := registryAttributes[name]
+		if !found {
+			violations = append(violations, fmt.Sprintf("%s is not in the attribute registry", name))
+
+			continue
+		}
+
+		if violation := validateBIOSAttribute(attribute, value); violation != "" {
+			violations = append(violations, fmt.Sprintf("%s %s", name, violation))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.Strings(violations)
+
+	return fmt.Errorf("bios attributes violate attribute registry %s: %s", registry.ID, strings.Join(violations, "; "))
+}
+
+// validateBIOSAttribute returns a description of how the value violates the registry entry for the attribute, or an
+// empty string if it does not.
+//
+//nolint:gocyclo
+func validateBIOSAttribute(attribute redfish.Attribute, value any) string {
+	if attribute.ReadOnly || attribute.Immutable {
+		return "is read only"
+	}
+
+	switch attribute.Type {
+	case redfish.EnumerationAttributeType:
+		var allowed []string
+
+		for _, allowedValue := range attribute.Value {
+			if value == allowedValue.ValueName {
+				return ""
+			}
+
+			allowed = append(allowed, allowedValue.ValueName)
+		}
+
+		return fmt.Sprintf("has value %v which is not one of %s", value, strings.Join(allowed, ", "))
+	case redfish.BooleanAttributeType:
+		if _, isBool := value.(bool); !isBool {
+			return fmt.Sprintf("has value %v which is not a boolean", value)
+		}
+	case redfish.IntegerAttributeType:
+		integer, isInteger := toInt64(value)
+		if !isInteger {
+			return fmt.Sprintf("has value %v which is not an integer", value)
+		}
+
+		if integer < attribute.LowerBound {
+			return fmt.Sprintf("has value %d which is less than the lower bound %d", integer, attribute.LowerBound)
+		}
+
+		if attribute.UpperBound.Sign() != 0 && attribute.UpperBound.IsInt64() && integer > attribute.UpperBound.Int64() {
+			return fmt.Sprintf("has value %d which is greater than the upper bound %s", integer, attribute.UpperBound.String())
+		}
+	case redfish.StringAttributeType, redfish.PasswordAttributeType:
+		text, isString := value.(string)
+		if !isString {
+			return fmt.Sprintf("has value %v which is not a string", value)
+		}
+
+		if int64(len(text)) < attribute.MinLength {
+			return fmt.Sprintf("is shorter than the minimum length %d", attribute.MinLength)
+		}
+
+		if attribute.MaxLength > 0 && int64(len(text)) > attribute.MaxLength {
+			return fmt.Sprintf("is longer than the maximum length %d", attribute.MaxLength)
+		}
+
+		if attribute.ValueExpression != "" {
+			// The expression uses the Perl dialect, so only enforce it when Go can compile it.
+			expression, err := regexp.Compile(attribute.ValueExpression)
+			if err == nil && !expression.MatchString(text) {
+				return fmt.Sprintf("does not match the expression %s", attribute.ValueExpression)
+			}
+		}
+	}
+
+	return ""
+}
+
+// describeRedfishError returns an error with the extended info messages and resolutions of a Redfish error response,
+// which explain why the BMC rejected a request. Other errors are returned unchanged.
+func describeRedfishError(err error) error {
+	var redfishError *common.Error
+	if !errors.As(err, &redfishError) || len(redfishError.ExtendedInfos) == 0 {
+		return err
+	}
+
+	var messages []string
+
+	for _, extendedInfo := range redfishError.ExtendedInfos {
+		message := extendedInfo.Message
+		if extendedInfo.Resolution != "" {
+			message = fmt.Sprintf("%s (resolution: %s)", message, extendedInfo.Resolution)
+		}
+
+		messages = append(messages, message)
+	}
+
+	return fmt.Errorf("status %d: %s", redfishError.HTTPReturnedStatusCode, strings.Join(messages, "; "))
+}
+
+// biosValuesEqual compares BIOS attribute values. Integral numbers are compared as int64 since numbers decoded from
+// JSON are float64 while callers usually provide ints. Other values are compared by their string representation.
+func biosValuesEqual(first, second any) bool {
+	firstNumber, firstIsNumber := toInt64(first)
+	secondNumber, secondIsNumber := toInt64(second)
+
+	if firstIsNumber && secondIsNumber {
+		return firstNumber == secondNumber
+	}
+
+	return fmt.Sprint(first) == fmt.Sprint(second)
+}
+
+// toInt64 converts the integer or integral float value to int64.
+func toInt64(value any) (int64, bool) {
+	switch number := value.(type) {
+	case int:
+		return int64(number), true
+	case int32:
+		return int64(number), true
+	case int64:
+		return number, true
+	case float64:
+		if number != math.Trunc(number) {
+			return 0, false
+		}
+
+		return int64(number), true
+	default:
+		return 0, false
+	}
+}
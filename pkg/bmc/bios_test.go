@@ -0,0 +1,333 @@
+package bmc
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/redfish_v1_registries.json
+var redfishRegistriesJSONResponse string
+
+//go:embed testdata/redfish_v1_registry_bios_file.json
+var redfishRegistryBIOSFileJSONResponse string
+
+//go:embed testdata/redfish_v1_registry_bios.json
+var redfishRegistryBIOSJSONResponse string
+
+const (
+	defaultBIOSPath = "/redfish/v1/Systems/System.Embedded.1/Bios"
+
+	// redfishBIOSRejectedJSONResponse is the error the fake BIOS returns when rejectPatch is set.
+	redfishBIOSRejectedJSONResponse = `{"error": {"code": "Base.1.8.GeneralError", "message": "A general error has occurred.",
+		"@Message.ExtendedInfo": [{"MessageId": "SYS409", "Message": "A job is already scheduled for the BIOS.",
+		"Resolution": "Delete the scheduled job and retry the operation.", "Severity": "Warning"}]}}`
+)
+
+// fakeBIOS holds the mutable state of the BIOS on the fake Redfish server.
+type fakeBIOS struct {
+	mutex     sync.Mutex
+	current   map[string]any
+	pending   map[string]any
+	applyTime string
+	// rejectPatch makes the fake BIOS reject changes to the settings resource.
+	rejectPatch bool
+	// resets is the number of system reset requests received.
+	resets int
+}
+
+func TestBMCBIOSAttributes(t *testing.T) {
+	redfishServer, _ := createFakeRedfishBIOSServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	attributes, err := bmc.BIOSAttributes()
+	assert.Nil(t, err)
+	assert.Equal(t, "Enabled", attributes["ProcCStates"])
+	assert.Equal(t, float64(60), attributes["AcPwrRcvryUserDelay"])
+	assert.Len(t, attributes, 7)
+}
+
+func TestBMCPendingBIOSAttributes(t *testing.T) {
+	redfishServer, biosState := createFakeRedfishBIOSServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	// The logical processor value matches the current one, so only C-states are pending.
+	biosState.pending = map[string]any{"ProcCStates": "Disabled", "LogicalProc": "Enabled"}
+
+	pending, err := bmc.PendingBIOSAttributes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]BIOSAttributeChange{
+		"ProcCStates": {Current: "Enabled", Pending: "Disabled"},
+	}, pending)
+}
+
+func TestBMCStageBIOSAttributes(t *testing.T) {
+	testCases := []struct {
+		attributes        map[string]any
+		rejectPatch       bool
+		expectedPending   map[string]any
+		expectedErrorText string
+	}{
+		{
+			attributes:      map[string]any{"ProcCStates": "Disabled", "AcPwrRcvryUserDelay": 120, "AssetTag": "rack-1"},
+			expectedPending: map[string]any{"ProcCStates": "Disabled", "AcPwrRcvryUserDelay": float64(120), "AssetTag": "rack-1"},
+		},
+		{
+			attributes:      map[string]any{"ProcCStates": "Disabled", "AcPwrRcvryUserDelay": 60, "LogicalProc": "Enabled"},
+			expectedPending: map[string]any{"ProcCStates": "Disabled"},
+		},
+		{
+			attributes:      map[string]any{"ProcCStates": "Enabled", "AcPwrRcvryUserDelay": 60},
+			expectedPending: map[string]any{},
+		},
+		{
+			attributes:        map[string]any{},
+			expectedErrorText: "bios 'attributes' cannot be empty",
+		},
+		{
+			attributes: map[string]any{
+				"ProcCStates":         "Off",
+				"AcPwrRcvryUserDelay": 1000,
+				"AssetTag":            "a-very-long-asset-tag",
+				"SystemServiceTag":    "ABC1234",
+				"Unknown":             true,
+			},
+			expectedErrorText: "bios attributes violate attribute registry BiosAttributeRegistry.v1_0_0: " +
+				"AcPwrRcvryUserDelay has value 1000 which is greater than the upper bound 600; " +
+				"AssetTag is longer than the maximum length 10; " +
+				"ProcCStates has value Off which is not one of Enabled, Disabled; " +
+				"SystemServiceTag is read only; " +
+				"Unknown is not in the attribute registry",
+		},
+		{
+			attributes:  map[string]any{"ProcCStates": "Disabled"},
+			rejectPatch: true,
+			expectedErrorText: "failed to stage bios attributes: status 409: A job is already scheduled for the BIOS. " +
+				"(resolution: Delete the scheduled job and retry the operation.)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, biosState := createFakeRedfishBIOSServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		biosState.rejectPatch = testCase.rejectPatch
+
+		err := bmc.StageBIOSAttributes(testCase.attributes)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedPending, biosState.pending)
+
+		if len(testCase.expectedPending) > 0 {
+			assert.Equal(t, "OnReset", biosState.applyTime)
+		}
+	}
+}
+
+func TestBIOSValuesEqual(t *testing.T) {
+	assert.True(t, biosValuesEqual(float64(1000000), 1000000))
+	assert.True(t, biosValuesEqual(float64(60), int32(60)))
+	assert.False(t, biosValuesEqual(float64(60.5), 60))
+	assert.True(t, biosValuesEqual("Enabled", "Enabled"))
+	assert.False(t, biosValuesEqual("Enabled", "Disabled"))
+	assert.False(t, biosValuesEqual("60", 61))
+}
+
+func TestBMCApplyBIOSAttributes(t *testing.T) {
+	testCases := []struct {
+		value           string
+		reboot          bool
+		expectedCurrent string
+		expectedResets  int
+	}{
+		{
+			value:           "Disabled",
+			reboot:          true,
+			expectedCurrent: "Disabled",
+			expectedResets:  1,
+		},
+		{
+			value:           "Disabled",
+			reboot:          false,
+			expectedCurrent: "Enabled",
+			expectedResets:  0,
+		},
+		{
+			value:           "Enabled",
+			reboot:          true,
+			expectedCurrent: "Enabled",
+			expectedResets:  0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, biosState := createFakeRedfishBIOSServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.ApplyBIOSAttributes(map[string]any{"SriovGlobalEnable": testCase.value}, testCase.reboot, time.Second)
+
+		redfishServer.Close()
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedCurrent, biosState.current["SriovGlobalEnable"])
+		assert.Equal(t, testCase.expectedResets, biosState.resets)
+	}
+}
+
+func TestBMCWaitForBIOSAttributes(t *testing.T) {
+	redfishServer, _ := createFakeRedfishBIOSServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	err := bmc.WaitForBIOSAttributes(map[string]any{"BootMode": "Uefi", "AcPwrRcvryUserDelay": 60}, time.Second)
+	assert.Nil(t, err)
+
+	err = bmc.WaitForBIOSAttributes(map[string]any{"BootMode": "Bios"}, time.Second)
+	assert.EqualError(t, err,
+		"bios attributes not applied within 1s: BootMode (current: Uefi, expected: Bios): context deadline exceeded")
+}
+
+// createFakeRedfishBIOSServer creates a fake Redfish server with a BIOS that stages changes in its settings resource
+// and applies them when the system is reset.
+func createFakeRedfishBIOSServer(t *testing.T) (*httptest.Server, *fakeBIOS) {
+	t.Helper()
+
+	biosState := &fakeBIOS{
+		current: map[string]any{
+			"ProcCStates":         "Enabled",
+			"SriovGlobalEnable":   "Enabled",
+			"LogicalProc":         "Enabled",
+			"BootMode":            "Uefi",
+			"AcPwrRcvryUserDelay": 60,
+			"AssetTag":            "",
+			"SystemServiceTag":    "ABC1234",
+		},
+		pending: map[string]any{},
+	}
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+
+	mux.HandleFunc("GET "+defaultBIOSPath, func(w http.ResponseWriter, r *http.Request) {
+		biosState.mutex.Lock()
+		defer biosState.mutex.Unlock()
+
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":         defaultBIOSPath,
+			"@odata.type":       "#Bios.v1_2_1.Bios",
+			"Id":                "Bios",
+			"AttributeRegistry": "BiosAttributeRegistry.v1_0_0",
+			"Attributes":        biosState.current,
+			"@Redfish.Settings": map[string]any{
+				"SettingsObject":      map[string]string{"@odata.id": defaultBIOSPath + "/Settings"},
+				"SupportedApplyTimes": []string{"OnReset"},
+			},
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultBIOSPath+"/Settings", func(w http.ResponseWriter, r *http.Request) {
+		biosState.mutex.Lock()
+		defer biosState.mutex.Unlock()
+
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":  defaultBIOSPath + "/Settings",
+			"Attributes": biosState.pending,
+		})
+	})
+
+	mux.HandleFunc("PATCH "+defaultBIOSPath+"/Settings", func(w http.ResponseWriter, r *http.Request) {
+		biosState.mutex.Lock()
+		defer biosState.mutex.Unlock()
+
+		if biosState.rejectPatch {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(redfishBIOSRejectedJSONResponse))
+
+			return
+		}
+
+		var settings struct {
+			Attributes        map[string]any
+			SettingsApplyTime struct {
+				ApplyTime string
+			} `json:"@Redfish.SettingsApplyTime"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&settings)
+		if err != nil {
+			t.Errorf("Failed to decode bios settings request: %v", err)
+		}
+
+		for name, value := range settings.Attributes {
+			biosState.pending[name] = value
+		}
+
+		biosState.applyTime = settings.SettingsApplyTime.ApplyTime
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST /redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset",
+		func(w http.ResponseWriter, r *http.Request) {
+			biosState.mutex.Lock()
+			defer biosState.mutex.Unlock()
+
+			for name, value := range biosState.pending {
+				biosState.current[name] = value
+			}
+
+			biosState.pending = map[string]any{}
+			biosState.resets++
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	mux.HandleFunc("GET /redfish/v1/Registries", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(redfishRegistriesJSONResponse))
+	})
+
+	mux.HandleFunc("GET /redfish/v1/Registries/BiosAttributeRegistry", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(redfishRegistryBIOSFileJSONResponse))
+	})
+
+	mux.HandleFunc("GET /redfish/v1/Registries/BiosAttributeRegistry/BiosAttributeRegistry",
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishRegistryBIOSJSONResponse))
+		})
+
+	return startFakeRedfishLocalServer(mux), biosState
+}
+
+// writeFakeRedfishJSON writes the value as the JSON body of a fake Redfish response.
+func writeFakeRedfishJSON(t *testing.T, w http.ResponseWriter, value any) {
+	t.Helper()
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		t.Errorf("Failed to encode fake redfish response: %v", err)
+	}
+}
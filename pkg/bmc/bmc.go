@@ -2,9 +2,14 @@ package bmc
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/stmcginnis/gofish"
@@ -18,6 +23,8 @@ const (
 	// defaultSSHPort is the default port that will be used for SSH connections.
 	defaultSSHPort = 22
 	defaultTimeOut = 5 * time.Second
+	// defaultFirmwareUploadTimeOut is the default timeout of firmware image uploads, which can be hundreds of MB.
+	defaultFirmwareUploadTimeOut = 30 * time.Minute
 
 	manufacturerDell = "Dell Inc."
 	manufacturerHPE  = "HPE"
@@ -26,8 +33,9 @@ const (
 var (
 	// DefaultTimeOuts holds the default redfish and ssh timeouts.
 	DefaultTimeOuts = TimeOuts{
-		Redfish: defaultTimeOut,
-		SSH:     defaultTimeOut,
+		Redfish:        defaultTimeOut,
+		SSH:            defaultTimeOut,
+		FirmwareUpload: defaultFirmwareUploadTimeOut,
 	}
 
 	// CLI command to get the serial console (virtual serial port).
@@ -51,6 +59,8 @@ type TimeOuts struct {
 	Redfish time.Duration
 	// SSH timeout for the ssh access.
 	SSH time.Duration
+	// FirmwareUpload timeout for uploading a firmware image to the redfish api.
+	FirmwareUpload time.Duration
 }
 
 // BMC is the holder struct for BMC access through redfish & ssh.
@@ -62,6 +72,7 @@ type BMC struct {
 	timeOuts    TimeOuts
 
 	systemIndex       int
+	systemPath        string
 	powerControlIndex int
 
 	sshClientForSerialConsole *ssh.Client
@@ -69,6 +80,13 @@ type BMC struct {
 	errorMsg string
 }
 
+// systemSelector identifies the system of a BMC in the Redfish API, by its path if it is set and by its index
+// otherwise.
+type systemSelector struct {
+	index int
+	path  string
+}
+
 // New returns a BMC struct with the specified host. The host should be nonempty. WithRedfishUser and WithSSHUser must
 // be called before connecting to Redfish or over SSH, respectively. The SSH port and timeouts are set to DefaultSSHPort
 // and DefaultTimeOuts, with indices defaulting to 0.
@@ -145,8 +163,9 @@ func (bmc *BMC) WithRedfishTimeout(timeout time.Duration) *BMC {
 	return bmc
 }
 
-// WithRedfishSystemIndex provies the index of the system to use in the Redfish API. Note that the order of the systems
-// is nondeterministic.
+// WithRedfishSystemIndex provies the index of the system to use in the Redfish API. The systems are ordered by their
+// path, such as /redfish/v1/Systems/1, with numbers in the path compared by value so /Systems/2 comes before
+// /Systems/10.
 func (bmc *BMC) WithRedfishSystemIndex(index int) *BMC {
 	if valid, _ := bmc.validate(); !valid {
 		return bmc
@@ -165,6 +184,27 @@ func (bmc *BMC) WithRedfishSystemIndex(index int) *BMC {
 	return bmc
 }
 
+// WithRedfishSystemPath provides the path of the system to use in the Redfish API, such as
+// /redfish/v1/Systems/System.Embedded.1. The system is looked up by its path on every call, and the path takes
+// precedence over the system index.
+func (bmc *BMC) WithRedfishSystemPath(systemPath string) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	if systemPath == "" {
+		klog.V(100).Info("The Redfish System path is empty")
+
+		bmc.errorMsg = "redfish 'systemPath' cannot be empty"
+
+		return bmc
+	}
+
+	bmc.systemPath = strings.TrimSuffix(systemPath, "/")
+
+	return bmc
+}
+
 // WithRedfishPowerControlIndex provides the index of the PowerControl object to use from the Power link on the Chassis
 // service in the Redfish API. The order of the PowerControl objects is deterministic.
 func (bmc *BMC) WithRedfishPowerControlIndex(index int) *BMC {
@@ -258,6 +298,26 @@ func (bmc *BMC) WithSSHTimeout(timeout time.Duration) *BMC {
 	return bmc
 }
 
+// WithFirmwareUploadTimeout provides the timeout to use when uploading a firmware image with PushFirmwareUpdate. It
+// should not be zero or negative.
+func (bmc *BMC) WithFirmwareUploadTimeout(timeout time.Duration) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	if timeout <= 0 {
+		klog.V(100).Infof("The firmware upload timeout %s is less than or equal to zero", timeout)
+
+		bmc.errorMsg = "firmware upload 'timeout' cannot be less than or equal to zero"
+
+		return bmc
+	}
+
+	bmc.timeOuts.FirmwareUpload = timeout
+
+	return bmc
+}
+
 // SystemManufacturer gets system's manufacturer from the BMC's RedFish API endpoint.
 func (bmc *BMC) SystemManufacturer() (string, error) {
 	if valid, err := bmc.validateRedfish(); !valid {
@@ -282,7 +342,7 @@ func (bmc *BMC) SystemManufacturer() (string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -316,7 +376,7 @@ func (bmc *BMC) IsSecureBootEnabled() (bool, error) {
 		cancel()
 	}()
 
-	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
+	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system's secure boot: %v", err)
 
@@ -350,7 +410,7 @@ func (bmc *BMC) SecureBootEnable() error {
 		cancel()
 	}()
 
-	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
+	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system's secure boot: %v", err)
 
@@ -399,7 +459,7 @@ func (bmc *BMC) SecureBootDisable() error {
 		cancel()
 	}()
 
-	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
+	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system's secure boot: %v", err)
 
@@ -448,7 +508,7 @@ func (bmc *BMC) SystemResetAction(action redfish.ResetType) error {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -575,7 +635,7 @@ func (bmc *BMC) SystemPowerState() (string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -664,7 +724,7 @@ func (bmc *BMC) SystemBootOptions() (map[string]string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -712,7 +772,7 @@ func (bmc *BMC) SystemBootOrderReferences() ([]string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -751,7 +811,7 @@ func (bmc *BMC) SetSystemBootOrderReferences(bootOrderReferences []string) error
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -800,7 +860,7 @@ func (bmc *BMC) BootFromCD(isoURL, virtualMediaID string) error {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -1065,24 +1125,106 @@ func redfishConnect(
 	return client, cancel, nil
 }
 
-// redfishGetSystem uses the provided gofish APIClient and the system index to get a system from the Redfish API.
-func redfishGetSystem(redfishClient *gofish.APIClient, index int) (*redfish.ComputerSystem, error) {
+// redfishConnectSession uses the provided host and credentials to produce a gofish APIClient for long running
+// operations. Unlike redfishConnect, the session is not bound to a deadline: requestTimeout limits every request
+// instead, so the client can be reused for as long as needed and still log out afterwards.
+func redfishConnectSession(
+	host, user, password string, requestTimeout time.Duration) (*gofish.APIClient, context.CancelFunc, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+
+	gofishConfig := gofish.ClientConfig{
+		Endpoint:   "https://" + host,
+		Username:   user,
+		Password:   password,
+		HTTPClient: &http.Client{Transport: transport, Timeout: requestTimeout},
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	client, err := gofish.ConnectContext(ctx, gofishConfig)
+	if err != nil {
+		cancel()
+
+		return nil, nil, fmt.Errorf("failed to connect to redfish endpoint: %w", err)
+	}
+
+	return client, cancel, nil
+}
+
+// redfishGetSystems uses the provided gofish APIClient to get the systems from the Redfish API, sorted by their path.
+// Gofish gets the members of a collection concurrently, so they are sorted to keep the system indices stable.
+func redfishGetSystems(redfishClient *gofish.APIClient) ([]*redfish.ComputerSystem, error) {
 	systems, err := redfishClient.GetService().Systems()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get systems: %w", err)
 	}
 
-	if len(systems) < index+1 {
-		return nil, fmt.Errorf("invalid system index %d (base-index=0, num systems=%d)", index, len(systems))
+	slices.SortFunc(systems, func(first, second *redfish.ComputerSystem) int {
+		return compareRedfishPaths(first.ODataID, second.ODataID)
+	})
+
+	return systems, nil
+}
+
+// redfishGetSystem uses the provided gofish APIClient to get the system identified by selector from the Redfish API.
+func redfishGetSystem(redfishClient *gofish.APIClient, selector systemSelector) (*redfish.ComputerSystem, error) {
+	if selector.path != "" {
+		system, err := redfish.GetComputerSystem(redfishClient, selector.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system %s: %w", selector.path, describeRedfishError(err))
+		}
+
+		return system, nil
+	}
+
+	systems, err := redfishGetSystems(redfishClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(systems) < selector.index+1 {
+		return nil, fmt.Errorf("invalid system index %d (base-index=0, num systems=%d)", selector.index, len(systems))
+	}
+
+	return systems[selector.index], nil
+}
+
+// compareRedfishPaths compares two Redfish paths, comparing runs of digits by their numeric value so that
+// /redfish/v1/Systems/2 comes before /redfish/v1/Systems/10.
+func compareRedfishPaths(first, second string) int {
+	for first != "" && second != "" {
+		firstDigits := len(first) - len(strings.TrimLeft(first, "0123456789"))
+		secondDigits := len(second) - len(strings.TrimLeft(second, "0123456789"))
+
+		if firstDigits == 0 || secondDigits == 0 {
+			if first[0] != second[0] {
+				return cmp.Compare(first[0], second[0])
+			}
+
+			first, second = first[1:], second[1:]
+
+			continue
+		}
+
+		firstNumber := strings.TrimLeft(first[:firstDigits], "0")
+		secondNumber := strings.TrimLeft(second[:secondDigits], "0")
+
+		if result := cmp.Or(
+			cmp.Compare(len(firstNumber), len(secondNumber)), strings.Compare(firstNumber, secondNumber)); result != 0 {
+			return result
+		}
+
+		first, second = first[firstDigits:], second[secondDigits:]
 	}
 
-	return systems[index], nil
+	return cmp.Compare(len(first), len(second))
 }
 
 // redfishGetSystemSecureBoot uses the provided gofish APIClient and the system index to get the SecureBoot resource for
 // a system.
-func redfishGetSystemSecureBoot(redfishClient *gofish.APIClient, systemIndex int) (*redfish.SecureBoot, error) {
-	system, err := redfishGetSystem(redfishClient, systemIndex)
+func redfishGetSystemSecureBoot(redfishClient *gofish.APIClient, selector systemSelector) (*redfish.SecureBoot, error) {
+	system, err := redfishGetSystem(redfishClient, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get redfish system: %w", err)
 	}
@@ -1153,6 +1295,11 @@ func (bmc *BMC) validateSSH() (bool, error) {
 	return true, nil
 }
 
+// systemSelector returns the selector of the system to use in the Redfish API.
+func (bmc *BMC) systemSelector() systemSelector {
+	return systemSelector{index: bmc.systemIndex, path: bmc.systemPath}
+}
+
 // validate checks that the BMC is in a valid state with no error message.
 func (bmc *BMC) validate() (bool, error) {
 	if bmc == nil {
@@ -1197,7 +1344,7 @@ func (bmc *BMC) getSupportedResetTypes() ([]redfish.ResetType, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
 	if err != nil {
 		klog.V(100).Infof("Failed to get redfish system: %v", err)
 
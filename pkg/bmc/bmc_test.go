@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -205,6 +206,32 @@ func TestBMCWithRedfishTimeout(t *testing.T) {
 	}
 }
 
+func TestBMCWithFirmwareUploadTimeout(t *testing.T) {
+	testCases := []struct {
+		timeout        time.Duration
+		expectedErrMsg string
+	}{
+		{
+			timeout:        time.Hour,
+			expectedErrMsg: "",
+		},
+		{
+			timeout:        0,
+			expectedErrMsg: "firmware upload 'timeout' cannot be less than or equal to zero",
+		},
+	}
+
+	for _, testCase := range testCases {
+		bmc := New(defaultHost).WithFirmwareUploadTimeout(testCase.timeout)
+
+		assert.Equal(t, testCase.expectedErrMsg, bmc.errorMsg)
+
+		if testCase.expectedErrMsg == "" {
+			assert.Equal(t, testCase.timeout, bmc.timeOuts.FirmwareUpload)
+		}
+	}
+}
+
 func TestBMCWithRedfishSystemIndex(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -236,6 +263,82 @@ func TestBMCWithRedfishSystemIndex(t *testing.T) {
 	}
 }
 
+func TestBMCWithRedfishSystemPath(t *testing.T) {
+	testCases := []struct {
+		name           string
+		systemPath     string
+		expectedPath   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "everything alright",
+			systemPath:   "/redfish/v1/Systems/System.Embedded.2/",
+			expectedPath: "/redfish/v1/Systems/System.Embedded.2",
+		},
+		{
+			name:           "empty path",
+			systemPath:     "",
+			expectedErrMsg: "redfish 'systemPath' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bmc := New(defaultHost).WithRedfishSystemPath(testCase.systemPath)
+
+			assert.Equal(t, testCase.expectedErrMsg, bmc.errorMsg)
+			assert.Equal(t, testCase.expectedPath, bmc.systemPath)
+		})
+	}
+}
+
+func TestBMCSystemManufacturerWithSystemPath(t *testing.T) {
+	var systemsListed bool
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+	mux.HandleFunc("GET /redfish/v1/Systems", func(w http.ResponseWriter, r *http.Request) {
+		systemsListed = true
+
+		_, _ = w.Write([]byte(redfishSystemsJSONResponse))
+	})
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.ReplaceAll(
+			strings.Replace(redfishSystemJSONResponse, "Dell Inc.", "HPE", 1), "System.Embedded.1", "System.Embedded.2")))
+	})
+
+	redfishServer := startFakeRedfishLocalServer(mux)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword).
+		WithRedfishSystemPath("/redfish/v1/Systems/System.Embedded.2")
+
+	manufacturer, err := bmc.SystemManufacturer()
+	assert.Nil(t, err)
+	assert.Equal(t, "HPE", manufacturer)
+	assert.False(t, systemsListed)
+}
+
+func TestCompareRedfishPaths(t *testing.T) {
+	paths := []string{
+		"/redfish/v1/Systems/10",
+		"/redfish/v1/Systems/System.Embedded.1",
+		"/redfish/v1/Systems/2",
+		"/redfish/v1/Systems/1",
+		"/redfish/v1/Systems/02a",
+	}
+
+	slices.SortFunc(paths, compareRedfishPaths)
+
+	assert.Equal(t, []string{
+		"/redfish/v1/Systems/1",
+		"/redfish/v1/Systems/2",
+		"/redfish/v1/Systems/02a",
+		"/redfish/v1/Systems/10",
+		"/redfish/v1/Systems/System.Embedded.1",
+	}, paths)
+}
+
 func TestBMCWithRedfishPowerControlIndex(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -828,7 +931,13 @@ func getAuthDataCallbackFn(t *testing.T, user *User) func(r *http.Request) {
 // it will be filled with the auth credentials received in the login request. All the responses, except the login one,
 // are sent using static json data from the testdata folder. The flag secureBootEnable is used to load the json response
 // for the secure boot api depending on wether we want it to be enabled or disabled for our test.
-func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIResponseCallbacks) *httptest.Server { //nolint:funlen
+func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIResponseCallbacks) *httptest.Server {
+	return startFakeRedfishLocalServer(createFakeRedfishMux(secureBootEnabled, callbacks))
+}
+
+// createFakeRedfishMux creates the handler used by createFakeRedfishLocalServer. Tests can register more specific
+// patterns on it to override the static responses before starting the server with startFakeRedfishLocalServer.
+func createFakeRedfishMux(secureBootEnabled bool, callbacks redfishAPIResponseCallbacks) *http.ServeMux { //nolint:funlen
 	sbEnabled := secureBootEnabled
 	mux := http.NewServeMux()
 	mux.HandleFunc("/redfish/v1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -941,7 +1050,12 @@ func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIRe
 			_, _ = w.Write([]byte(redfishPowerJSONResponse))
 		}))
 
-	redfishServer := httptest.NewUnstartedServer(mux)
+	return mux
+}
+
+// startFakeRedfishLocalServer starts a TLS server in localhost (random port) using the provided handler.
+func startFakeRedfishLocalServer(handler http.Handler) *httptest.Server {
+	redfishServer := httptest.NewUnstartedServer(handler)
 	redfishServer.EnableHTTP2 = true
 	redfishServer.StartTLS()
 
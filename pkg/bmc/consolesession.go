@@ -0,0 +1,474 @@
+package bmc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// ConsoleMilestone is a stage of the boot process recognized in the console output.
+type ConsoleMilestone string
+
+const (
+	// GRUBConsoleMilestone is reached when the GRUB menu or prompt is shown.
+	GRUBConsoleMilestone ConsoleMilestone = "GRUB"
+	// KernelBootConsoleMilestone is reached when the kernel starts booting.
+	KernelBootConsoleMilestone ConsoleMilestone = "KernelBoot"
+	// KernelPanicConsoleMilestone is reached when the kernel panics.
+	KernelPanicConsoleMilestone ConsoleMilestone = "KernelPanic"
+	// LoginPromptConsoleMilestone is reached when a login prompt is shown.
+	LoginPromptConsoleMilestone ConsoleMilestone = "LoginPrompt"
+)
+
+var (
+	// consoleMilestonePatterns are matched in order against every line of the console output to detect milestones.
+	consoleMilestonePatterns = []consoleMilestonePattern{
+		{milestone: GRUBConsoleMilestone, pattern: regexp.MustCompile(`GNU GRUB|grub> ?$`)},
+		{milestone: KernelBootConsoleMilestone, pattern: regexp.MustCompile(`Linux version \d`)},
+		{milestone: KernelPanicConsoleMilestone, pattern: regexp.MustCompile(`Kernel panic - not syncing`)},
+		{milestone: LoginPromptConsoleMilestone, pattern: regexp.MustCompile(`login: ?$`)},
+	}
+
+	consoleLoginPromptRegex    = regexp.MustCompile(`login: ?$`)
+	consolePasswordPromptRegex = regexp.MustCompile(`[Pp]assword: ?$`)
+	consoleLoginResultRegex    = regexp.MustCompile(`Login incorrect|[$#] ?$`)
+)
+
+const (
+	// consoleErrorContextLength is the number of trailing bytes of unmatched output included in WaitFor errors.
+	consoleErrorContextLength = 256
+	// consolePendingMaxLength is the number of trailing bytes of unconsumed output kept for WaitFor. Older output is
+	// dropped so a console nobody waits on does not grow without bound.
+	consolePendingMaxLength = 1 << 20
+	// consoleTranscriptMaxLength is the number of trailing bytes of output kept in memory for Transcript. The full
+	// output is only kept in the file written by RecordTranscript.
+	consoleTranscriptMaxLength = 1 << 20
+	// consoleLineMaxLength is the number of trailing bytes of the current line matched against the milestone
+	// patterns, which keeps matching linear in the output length when a line is never terminated.
+	consoleLineMaxLength = 4096
+)
+
+// consoleMilestonePattern is the pattern of console lines that reach a milestone.
+type consoleMilestonePattern struct {
+	milestone ConsoleMilestone
+	pattern   *regexp.Regexp
+}
+
+// ConsoleMilestoneEvent holds a milestone detected in the console output.
+type ConsoleMilestoneEvent struct {
+	// Milestone is the milestone that was detected.
+	Milestone ConsoleMilestone
+	// Time is when the line with the milestone was read.
+	Time time.Time
+	// Line is the console line that matched the milestone.
+	Line string
+}
+
+// ConsoleSession provides expect-style automation of a serial console, such as the one returned by
+// OpenSerialConsole. It reads the console output in the background, keeping the end of the transcript in memory and
+// detecting boot milestones, while WaitFor consumes the output up to the next match of a pattern. RecordTranscript
+// keeps the full transcript in a file.
+type ConsoleSession struct {
+	reader io.Reader
+	writer io.WriteCloser
+	closer func() error
+
+	mutex      sync.Mutex
+	pending    []byte
+	transcript []byte
+	file       *os.File
+	line       []byte
+	lineFound  map[ConsoleMilestone]bool
+	milestones []ConsoleMilestoneEvent
+	readErr    error
+	updated    chan struct{}
+}
+
+// NewConsoleSession returns a ConsoleSession reading the console output from reader and sending input to writer.
+// Reading starts immediately and stops when reader returns an error, such as when the console is closed.
+func NewConsoleSession(reader io.Reader, writer io.WriteCloser) *ConsoleSession {
+	klog.V(100).Info("Creating console session")
+
+	session := &ConsoleSession{
+		reader:    reader,
+		writer:    writer,
+		lineFound: make(map[ConsoleMilestone]bool),
+		updated:   make(chan struct{}),
+	}
+
+	go session.read()
+
+	return session
+}
+
+// OpenConsoleSession opens the serial console, see OpenSerialConsole, and returns a ConsoleSession on top of it.
+// Closing the session closes the serial console.
+func (bmc *BMC) OpenConsoleSession(openConsoleCliCmd string) (*ConsoleSession, error) {
+	reader, writer, err := bmc.OpenSerialConsole(openConsoleCliCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	session := NewConsoleSession(reader, writer)
+	session.closer = bmc.CloseSerialConsole
+
+	return session, nil
+}
+
+// RecordTranscript writes the console output to the file at path, truncating it if it exists. The output read before
+// calling it is written first, but only its last MiB is kept in memory, so it should be called right after the session
+// is created for the file to hold the full transcript.
+func (session *ConsoleSession) RecordTranscript(path string) error {
+	klog.V(100).Infof("Recording console transcript to %s", path)
+
+	if path == "" {
+		klog.V(100).Info("The console transcript path is empty")
+
+		return fmt.Errorf("console transcript 'path' cannot be empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		klog.V(100).Infof("Failed to create console transcript file %s: %v", path, err)
+
+		return fmt.Errorf("failed to create console transcript file: %w", err)
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	_, err = file.Write(session.transcript)
+	if err != nil {
+		_ = file.Close()
+
+		klog.V(100).Infof("Failed to write console transcript file %s: %v", path, err)
+
+		return fmt.Errorf("failed to write console transcript file: %w", err)
+	}
+
+	if session.file != nil {
+		_ = session.file.Close()
+	}
+
+	session.file = file
+
+	return nil
+}
+
+// Transcript returns the last MiB of console output read so far. Use RecordTranscript to keep the full output.
+func (session *ConsoleSession) Transcript() string {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	return string(session.transcript)
+}
+
+// Milestones returns the boot milestones detected so far, in the order they were reached.
+func (session *ConsoleSession) Milestones() []ConsoleMilestoneEvent {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	milestones := make([]ConsoleMilestoneEvent, len(session.milestones))
+	copy(milestones, session.milestones)
+
+	return milestones
+}
+
+// WaitForMilestone waits up to timeout until the milestone is detected, including before calling it, and returns the
+// latest detection of it.
+func (session *ConsoleSession) WaitForMilestone(
+	milestone ConsoleMilestone, timeout time.Duration) (*ConsoleMilestoneEvent, error) {
+	klog.V(100).Infof("Waiting up to %s for console milestone %s", timeout, milestone)
+
+	if !slices.ContainsFunc(consoleMilestonePatterns, func(milestonePattern consoleMilestonePattern) bool {
+		return milestonePattern.milestone == milestone
+	}) {
+		klog.V(100).Infof("The console milestone %s is not supported", milestone)
+
+		return nil, fmt.Errorf("console milestone %s is not supported", milestone)
+	}
+
+	deadline := time.After(timeout)
+
+	for {
+		session.mutex.Lock()
+
+		for index := len(session.milestones) - 1; index >= 0; index-- {
+			if session.milestones[index].Milestone == milestone {
+				event := session.milestones[index]
+				session.mutex.Unlock()
+
+				return &event, nil
+			}
+		}
+
+		updated, readErr := session.updated, session.readErr
+		session.mutex.Unlock()
+
+		if readErr != nil {
+			klog.V(100).Infof("Console closed while waiting for milestone %s: %v", milestone, readErr)
+
+			return nil, fmt.Errorf("console closed while waiting for milestone %s: %w", milestone, readErr)
+		}
+
+		select {
+		case <-updated:
+		case <-deadline:
+			klog.V(100).Infof("Console milestone %s not reached within %s", milestone, timeout)
+
+			return nil, fmt.Errorf("console milestone %s not reached within %s", milestone, timeout)
+		}
+	}
+}
+
+// WaitFor waits up to timeout until the console output matches pattern and returns the match followed by its
+// submatches. The output is consumed up to the end of the match, so the next call only matches later output. Only the
+// last MiB of unconsumed output is matched.
+func (session *ConsoleSession) WaitFor(pattern *regexp.Regexp, timeout time.Duration) ([]string, error) {
+	if pattern == nil {
+		klog.V(100).Info("The console pattern is nil")
+
+		return nil, fmt.Errorf("console 'pattern' cannot be nil")
+	}
+
+	klog.V(100).Infof("Waiting up to %s for console output matching %q", timeout, pattern)
+
+	deadline := time.After(timeout)
+
+	for {
+		session.mutex.Lock()
+
+		if indices := pattern.FindSubmatchIndex(session.pending); indices != nil {
+			submatches := make([]string, len(indices)/2)
+
+			for index := range submatches {
+				if indices[2*index] >= 0 {
+					submatches[index] = string(session.pending[indices[2*index]:indices[2*index+1]])
+				}
+			}
+
+			session.pending = session.pending[indices[1]:]
+			session.mutex.Unlock()
+
+			return submatches, nil
+		}
+
+		updated, readErr, lastOutput := session.updated, session.readErr, session.pendingTail()
+		session.mutex.Unlock()
+
+		if readErr != nil {
+			klog.V(100).Infof("Console closed while waiting for %q: %v", pattern, readErr)
+
+			return nil, fmt.Errorf("console closed while waiting for %q (last output: %q): %w", pattern, lastOutput, readErr)
+		}
+
+		select {
+		case <-updated:
+		case <-deadline:
+			klog.V(100).Infof("Console output did not match %q within %s", pattern, timeout)
+
+			return nil, fmt.Errorf("console output did not match %q within %s (last output: %q)", pattern, timeout, lastOutput)
+		}
+	}
+}
+
+// Send writes text to the console as is.
+func (session *ConsoleSession) Send(text string) error {
+	_, err := io.WriteString(session.writer, text)
+	if err != nil {
+		klog.V(100).Infof("Failed to send text to console: %v", err)
+
+		return fmt.Errorf("failed to send text to console: %w", err)
+	}
+
+	return nil
+}
+
+// SendLine writes text to the console followed by a carriage return, which serial consoles expect as the enter key.
+func (session *ConsoleSession) SendLine(text string) error {
+	return session.Send(text + "\r")
+}
+
+// Login logs in to the console with the provided credentials, waiting up to timeout for each prompt. An empty line is
+// sent first to show the login prompt, since it is not repeated on an idle console. Login succeeds once a shell prompt
+// ending in $ or # is shown.
+func (session *ConsoleSession) Login(username, password string, timeout time.Duration) error {
+	klog.V(100).Infof("Logging in to console as %s", username)
+
+	if username == "" {
+		klog.V(100).Info("The console username is empty")
+
+		return fmt.Errorf("console 'username' cannot be empty")
+	}
+
+	err := session.SendLine("")
+	if err != nil {
+		return err
+	}
+
+	_, err = session.WaitFor(consoleLoginPromptRegex, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to get console login prompt: %w", err)
+	}
+
+	err = session.SendLine(username)
+	if err != nil {
+		return err
+	}
+
+	_, err = session.WaitFor(consolePasswordPromptRegex, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to get console password prompt: %w", err)
+	}
+
+	err = session.SendLine(password)
+	if err != nil {
+		return err
+	}
+
+	matches, err := session.WaitFor(consoleLoginResultRegex, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to get console shell prompt: %w", err)
+	}
+
+	if matches[0] == "Login incorrect" {
+		klog.V(100).Infof("Console login as %s was rejected", username)
+
+		return fmt.Errorf("console login as %s was rejected", username)
+	}
+
+	return nil
+}
+
+// Close stops the session by closing the console writer, and the serial console if the session was opened with
+// OpenConsoleSession. The transcript file, if any, is closed once the remaining output is read.
+func (session *ConsoleSession) Close() error {
+	klog.V(100).Info("Closing console session")
+
+	err := session.writer.Close()
+
+	if session.closer != nil {
+		err = errors.Join(err, session.closer())
+	}
+
+	if err != nil {
+		klog.V(100).Infof("Failed to close console session: %v", err)
+
+		return fmt.Errorf("failed to close console session: %w", err)
+	}
+
+	return nil
+}
+
+// read reads the console output until the reader fails, recording it and waking up the waiters after every read.
+func (session *ConsoleSession) read() {
+	buffer := make([]byte, 4096)
+
+	for {
+		count, err := session.reader.Read(buffer)
+
+		session.mutex.Lock()
+
+		if count > 0 {
+			session.record(buffer[:count])
+		}
+
+		if err != nil {
+			session.readErr = err
+
+			if session.file != nil {
+				_ = session.file.Close()
+				session.file = nil
+			}
+		}
+
+		close(session.updated)
+		session.updated = make(chan struct{})
+
+		session.mutex.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// record stores the output in the pending buffer, the transcript and the transcript file, and detects the milestones
+// of its lines. The in-memory buffers are trimmed to their maximum length. It must be called with the mutex held.
+func (session *ConsoleSession) record(output []byte) {
+	session.pending = trimConsoleBuffer(append(session.pending, output...), consolePendingMaxLength)
+	session.transcript = trimConsoleBuffer(append(session.transcript, output...), consoleTranscriptMaxLength)
+
+	if session.file != nil {
+		_, err := session.file.Write(output)
+		if err != nil {
+			klog.V(100).Infof("Failed to write console transcript file: %v", err)
+		}
+	}
+
+	for _, character := range output {
+		if character == '\n' {
+			session.detectMilestones()
+			session.line = session.line[:0]
+			session.lineFound = make(map[ConsoleMilestone]bool)
+
+			continue
+		}
+
+		session.line = append(session.line, character)
+	}
+
+	session.line = trimConsoleBuffer(session.line, consoleLineMaxLength)
+
+	// Prompts are not followed by a new line, so the incomplete line is checked as well.
+	session.detectMilestones()
+}
+
+// detectMilestones records the milestones matched by the current line that were not recorded for it yet. It must be
+// called with the mutex held.
+func (session *ConsoleSession) detectMilestones() {
+	line := strings.TrimRight(string(session.line), "\r")
+
+	for _, milestonePattern := range consoleMilestonePatterns {
+		milestone := milestonePattern.milestone
+		if session.lineFound[milestone] || !milestonePattern.pattern.MatchString(line) {
+			continue
+		}
+
+		klog.V(100).Infof("Console milestone %s reached: %s", milestone, line)
+
+		session.lineFound[milestone] = true
+		session.milestones = append(session.milestones, ConsoleMilestoneEvent{
+			Milestone: milestone,
+			Time:      time.Now(),
+			Line:      line,
+		})
+	}
+}
+
+// pendingTail returns the end of the output not consumed yet, used as context in errors. It must be called with the
+// mutex held.
+func (session *ConsoleSession) pendingTail() string {
+	if len(session.pending) <= consoleErrorContextLength {
+		return string(session.pending)
+	}
+
+	return string(session.pending[len(session.pending)-consoleErrorContextLength:])
+}
+
+// trimConsoleBuffer drops the start of buffer so it holds at most maxLength bytes.
+func trimConsoleBuffer(buffer []byte, maxLength int) []byte {
+	if excess := len(buffer) - maxLength; excess > 0 {
+		return buffer[excess:]
+	}
+
+	return buffer
+}
@@ -0,0 +1,256 @@
+package bmc
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConsole is the other end of a ConsoleSession: tests write the console output to it and read the input sent by
+// the session from it.
+type fakeConsole struct {
+	output *io.PipeWriter
+	input  *bufio.Reader
+}
+
+func TestConsoleSessionWaitFor(t *testing.T) {
+	session, console := newFakeConsoleSession(t)
+	defer session.Close()
+
+	go func() {
+		_, _ = console.output.Write([]byte("Red Hat Enterprise Linux CoreOS 9.6\r\nKernel 5.14.0-570.el9.x86_64 on an x86_64\r\n"))
+		_, _ = console.output.Write([]byte("\r\nworker-0 login: "))
+	}()
+
+	matches, err := session.WaitFor(regexp.MustCompile(`Kernel (\S+) on an (\S+)`), time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Kernel 5.14.0-570.el9.x86_64 on an x86_64", "5.14.0-570.el9.x86_64", "x86_64"}, matches)
+
+	// The output before the end of the previous match is consumed, so only later output matches.
+	_, err = session.WaitFor(regexp.MustCompile(`Kernel`), 100*time.Millisecond)
+	assert.ErrorContains(t, err, `console output did not match "Kernel" within 100ms (last output: `)
+	assert.ErrorContains(t, err, `worker-0 login: `)
+
+	matches, err = session.WaitFor(regexp.MustCompile(`(\S+) login: $`), time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "worker-0", matches[1])
+
+	_, err = session.WaitFor(nil, time.Second)
+	assert.EqualError(t, err, "console 'pattern' cannot be nil")
+
+	_ = console.output.Close()
+
+	_, err = session.WaitFor(regexp.MustCompile(`never`), time.Second)
+	assert.ErrorContains(t, err, `console closed while waiting for "never"`)
+}
+
+func TestConsoleSessionPendingLimit(t *testing.T) {
+	session, console := newFakeConsoleSession(t)
+	defer session.Close()
+
+	transcriptPath := filepath.Join(t.TempDir(), "console.log")
+
+	err := session.RecordTranscript(transcriptPath)
+	assert.Nil(t, err)
+
+	go func() {
+		_, _ = console.output.Write([]byte("first line\r\n"))
+		_, _ = console.output.Write([]byte(strings.Repeat(strings.Repeat("x", 1023)+"\n", consolePendingMaxLength/1024)))
+		_, _ = console.output.Write([]byte("\r\nworker-0 login: "))
+	}()
+
+	_, err = session.WaitFor(regexp.MustCompile(`login: $`), time.Second)
+	assert.Nil(t, err)
+
+	// The oldest output was dropped from the pending buffer and the in-memory transcript but is still in the file.
+	_, err = session.WaitFor(regexp.MustCompile(`first line`), 100*time.Millisecond)
+	assert.ErrorContains(t, err, `console output did not match "first line" within 100ms`)
+	assert.Len(t, session.Transcript(), consoleTranscriptMaxLength)
+	assert.True(t, strings.HasSuffix(session.Transcript(), "worker-0 login: "))
+
+	_ = console.output.Close()
+
+	_, err = session.WaitFor(regexp.MustCompile(`never`), time.Second)
+	assert.ErrorContains(t, err, `console closed while waiting for "never"`)
+
+	transcript, err := os.ReadFile(transcriptPath)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(transcript), "first line\r\n"))
+	assert.True(t, strings.HasSuffix(string(transcript), "worker-0 login: "))
+}
+
+func TestConsoleSessionLineLimit(t *testing.T) {
+	session, console := newFakeConsoleSession(t)
+	defer session.Close()
+
+	// A line that is never terminated is only matched up to its last bytes, so the prompt at its end is still found.
+	go func() {
+		for range 256 {
+			_, _ = console.output.Write([]byte(strings.Repeat("x", 4096)))
+		}
+
+		_, _ = console.output.Write([]byte("worker-0 login: "))
+	}()
+
+	event, err := session.WaitForMilestone(LoginPromptConsoleMilestone, 5*time.Second)
+	assert.Nil(t, err)
+	assert.Len(t, event.Line, consoleLineMaxLength)
+	assert.True(t, strings.HasSuffix(event.Line, "worker-0 login: "))
+}
+
+func TestConsoleSessionSend(t *testing.T) {
+	session, console := newFakeConsoleSession(t)
+	defer session.Close()
+
+	go func() {
+		assert.Nil(t, session.Send("e"))
+		assert.Nil(t, session.SendLine("ls"))
+	}()
+
+	input := make([]byte, 4)
+	_, err := io.ReadFull(console.input, input)
+	assert.Nil(t, err)
+	assert.Equal(t, "els\r", string(input))
+}
+
+func TestConsoleSessionLogin(t *testing.T) {
+	testCases := []struct {
+		password          string
+		expectedErrorText string
+	}{
+		{
+			password: "secret",
+		},
+		{
+			password:          "wrong",
+			expectedErrorText: "console login as core was rejected",
+		},
+	}
+
+	for _, testCase := range testCases {
+		session, console := newFakeConsoleSession(t)
+
+		go runFakeConsoleLogin(console, "core", "secret")
+
+		err := session.Login("core", testCase.password, time.Second)
+
+		_ = session.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+
+	session, _ := newFakeConsoleSession(t)
+	defer session.Close()
+
+	err := session.Login("", "secret", time.Second)
+	assert.EqualError(t, err, "console 'username' cannot be empty")
+}
+
+func TestConsoleSessionMilestones(t *testing.T) {
+	session, console := newFakeConsoleSession(t)
+	defer session.Close()
+
+	transcriptPath := filepath.Join(t.TempDir(), "console.log")
+
+	_, _ = console.output.Write([]byte("                             GNU GRUB  version 2.06\r\n"))
+
+	_, err := session.WaitForMilestone(GRUBConsoleMilestone, time.Second)
+	assert.Nil(t, err)
+
+	// The output read before recording started is written to the transcript file too.
+	err = session.RecordTranscript(transcriptPath)
+	assert.Nil(t, err)
+
+	// The line is split across writes to check that milestones are detected on complete lines.
+	_, _ = console.output.Write([]byte("[    0.000000] Linux vers"))
+	_, _ = console.output.Write([]byte("ion 5.14.0-570.el9.x86_64 (mockbuild@x86-64-01)\r\n"))
+	_, _ = console.output.Write([]byte("[   12.345678] Kernel panic - not syncing: VFS: Unable to mount root fs\r\n"))
+
+	event, err := session.WaitForMilestone(KernelPanicConsoleMilestone, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "[   12.345678] Kernel panic - not syncing: VFS: Unable to mount root fs", event.Line)
+
+	var milestones []ConsoleMilestone
+
+	for _, milestone := range session.Milestones() {
+		milestones = append(milestones, milestone.Milestone)
+	}
+
+	assert.Equal(t, []ConsoleMilestone{
+		GRUBConsoleMilestone, KernelBootConsoleMilestone, KernelPanicConsoleMilestone}, milestones)
+
+	_, err = session.WaitForMilestone(LoginPromptConsoleMilestone, 100*time.Millisecond)
+	assert.EqualError(t, err, "console milestone LoginPrompt not reached within 100ms")
+
+	_, err = session.WaitForMilestone("Unknown", time.Second)
+	assert.EqualError(t, err, "console milestone Unknown is not supported")
+
+	// The transcript file is closed once the console is closed.
+	_ = console.output.Close()
+
+	_, err = session.WaitForMilestone(LoginPromptConsoleMilestone, time.Second)
+	assert.ErrorContains(t, err, "console closed while waiting for milestone LoginPrompt")
+
+	transcript, err := os.ReadFile(transcriptPath)
+	assert.Nil(t, err)
+	assert.Equal(t, session.Transcript(), string(transcript))
+	assert.True(t, strings.HasPrefix(string(transcript), "                             GNU GRUB"))
+	assert.True(t, strings.HasSuffix(string(transcript), "Unable to mount root fs\r\n"))
+
+	err = session.RecordTranscript("")
+	assert.EqualError(t, err, "console transcript 'path' cannot be empty")
+}
+
+// newFakeConsoleSession returns a ConsoleSession connected to a fakeConsole.
+func newFakeConsoleSession(t *testing.T) (*ConsoleSession, *fakeConsole) {
+	t.Helper()
+
+	outputReader, outputWriter := io.Pipe()
+	inputReader, inputWriter := io.Pipe()
+
+	return NewConsoleSession(outputReader, inputWriter), &fakeConsole{
+		output: outputWriter,
+		input:  bufio.NewReader(inputReader),
+	}
+}
+
+// runFakeConsoleLogin acts as a getty that accepts the provided credentials, echoing the username like a terminal.
+func runFakeConsoleLogin(console *fakeConsole, username, password string) {
+	// The empty line sent to show the login prompt.
+	if _, err := console.input.ReadString('\r'); err != nil {
+		return
+	}
+
+	_, _ = console.output.Write([]byte("\r\nworker-0 login: "))
+
+	receivedUsername, err := console.input.ReadString('\r')
+	if err != nil {
+		return
+	}
+
+	_, _ = console.output.Write([]byte(receivedUsername + "\nPassword: "))
+
+	receivedPassword, err := console.input.ReadString('\r')
+	if err != nil {
+		return
+	}
+
+	if strings.TrimSuffix(receivedUsername, "\r") != username || strings.TrimSuffix(receivedPassword, "\r") != password {
+		_, _ = console.output.Write([]byte("\r\nLogin incorrect\r\n\r\nworker-0 login: "))
+
+		return
+	}
+
+	_, _ = console.output.Write([]byte("\r\nLast login: Sun Oct 18 10:00:00 on ttyS0\r\n[core@worker-0 ~]$ "))
+}
@@ -0,0 +1,253 @@
+package bmc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc/redfishemulator"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBMCEmulatorPowerTransitions(t *testing.T) {
+	emulator := redfishemulator.New(redfishemulator.Quirks{PowerTransitionDelay: 200 * time.Millisecond})
+	defer emulator.Close()
+
+	bmc := New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword)
+
+	err := bmc.SystemPowerOff()
+	assert.Nil(t, err)
+
+	powerState, err := bmc.SystemPowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, string(redfish.PoweringOffPowerState), powerState)
+
+	assert.Eventually(t, func() bool {
+		powerState, err := bmc.SystemPowerState()
+
+		return err == nil && powerState == string(redfish.OffPowerState)
+	}, 5*time.Second, 50*time.Millisecond)
+
+	powerUsage, err := bmc.PowerUsage()
+	assert.Nil(t, err)
+	assert.Zero(t, powerUsage)
+
+	err = bmc.SystemPowerOn()
+	assert.Nil(t, err)
+	assert.Equal(t, redfish.PoweringOnPowerState, emulator.PowerState())
+
+	assert.Eventually(t, func() bool {
+		return emulator.PowerState() == redfish.OnPowerState
+	}, 5*time.Second, 50*time.Millisecond)
+
+	err = bmc.WaitForSystemPowerState(redfish.OnPowerState, time.Second)
+	assert.Nil(t, err)
+
+	powerUsage, err = bmc.PowerUsage()
+	assert.Nil(t, err)
+	assert.Equal(t, float32(redfishemulator.DefaultPowerConsumedWatts), powerUsage)
+
+	assert.Equal(t, []redfish.ResetType{redfish.ForceOffResetType, redfish.OnResetType}, emulator.ResetHistory())
+}
+
+func TestBMCEmulatorPowerCycle(t *testing.T) {
+	testCases := []struct {
+		quirks         redfishemulator.Quirks
+		expectedResets []redfish.ResetType
+	}{
+		{
+			quirks:         redfishemulator.DellQuirks(),
+			expectedResets: []redfish.ResetType{redfish.PowerCycleResetType},
+		},
+		{
+			quirks:         redfishemulator.HPEQuirks(),
+			expectedResets: []redfish.ResetType{redfish.ForceOffResetType, redfish.OnResetType},
+		},
+	}
+
+	for _, testCase := range testCases {
+		emulator := redfishemulator.New(testCase.quirks)
+		bmc := New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword)
+
+		err := bmc.SystemPowerCycle()
+
+		emulator.Close()
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedResets, emulator.ResetHistory())
+		assert.Equal(t, redfish.OnPowerState, emulator.PowerState())
+	}
+}
+
+func TestBMCEmulatorBootFromCD(t *testing.T) {
+	testCases := []struct {
+		quirks            redfishemulator.Quirks
+		expectedErrorText string
+	}{
+		{
+			quirks: redfishemulator.DellQuirks(),
+		},
+		{
+			quirks:            redfishemulator.HPEQuirks(),
+			expectedErrorText: "no cd virtual media slot found",
+		},
+		{
+			quirks:            redfishemulator.Quirks{VirtualMediaWithoutActions: true},
+			expectedErrorText: "does not support VirtualMedia.InsertMedia calls",
+		},
+	}
+
+	for _, testCase := range testCases {
+		emulator := redfishemulator.New(testCase.quirks)
+		bmc := New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword)
+
+		err := bmc.BootFromCD("http://images.example.com/rhcos.iso", "CD")
+		if testCase.expectedErrorText != "" {
+			emulator.Close()
+
+			assert.ErrorContains(t, err, testCase.expectedErrorText)
+			assert.False(t, emulator.VirtualMedia("CD").Inserted)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, &redfishemulator.VirtualMediaState{
+			Image:          "http://images.example.com/rhcos.iso",
+			Inserted:       true,
+			WriteProtected: true,
+		}, emulator.VirtualMedia("CD"))
+
+		enabled, target := emulator.BootSourceOverride()
+		assert.Equal(t, redfish.OnceBootSourceOverrideEnabled, enabled)
+		assert.Equal(t, redfish.CdBootSourceOverrideTarget, target)
+
+		// The override only applies to the next boot.
+		err = bmc.SystemForceReset()
+		assert.Nil(t, err)
+
+		enabled, _ = emulator.BootSourceOverride()
+		assert.Equal(t, redfish.DisabledBootSourceOverrideEnabled, enabled)
+
+		err = bmc.BootFromCD("http://images.example.com/rhcos.iso", "USB")
+		assert.EqualError(t, err, "no cd virtual media slot found")
+
+		emulator.Close()
+	}
+}
+
+func TestBMCEmulatorBootOrder(t *testing.T) {
+	testCases := []struct {
+		quirks redfishemulator.Quirks
+	}{
+		{
+			quirks: redfishemulator.Quirks{},
+		},
+		{
+			quirks: redfishemulator.DellQuirks(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		emulator := redfishemulator.New(testCase.quirks)
+		bmc := New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword)
+
+		newBootOrder := []string{"Boot0000", "Boot0001", "Boot0002"}
+
+		err := bmc.SetSystemBootOrderReferences(newBootOrder)
+		assert.Nil(t, err)
+
+		bootOrder, err := bmc.SystemBootOrderReferences()
+		assert.Nil(t, err)
+
+		if testCase.quirks.BootOrderAppliedOnReset {
+			assert.Equal(t, []string{"Boot0001", "Boot0000", "Boot0002"}, bootOrder)
+			assert.Equal(t, newBootOrder, emulator.PendingBootOrder())
+
+			err = bmc.SystemForceReset()
+			assert.Nil(t, err)
+
+			bootOrder, err = bmc.SystemBootOrderReferences()
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, newBootOrder, bootOrder)
+		assert.Nil(t, emulator.PendingBootOrder())
+
+		bootOptions, err := bmc.SystemBootOptions()
+		assert.Nil(t, err)
+		assert.Equal(t, "PXE Device 1: Embedded NIC 1 Port 1 Partition 1", bootOptions["Boot0000"])
+		assert.Len(t, bootOptions, 3)
+
+		err = bmc.SetSystemBootOrderReferences([]string{"Boot0009"})
+		assert.ErrorContains(t, err, "The boot option reference Boot0009 is not valid.")
+
+		emulator.Close()
+	}
+}
+
+func TestBMCEmulatorSecureBoot(t *testing.T) {
+	emulator := redfishemulator.New(redfishemulator.Quirks{})
+	defer emulator.Close()
+
+	bmc := New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword)
+
+	err := bmc.SecureBootEnable()
+	assert.Nil(t, err)
+	assert.True(t, emulator.SecureBootEnabled())
+
+	enabled, err := bmc.IsSecureBootEnabled()
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = bmc.SecureBootEnable()
+	assert.EqualError(t, err, "secure boot is already enabled")
+}
+
+func TestBMCEmulatorFailureInjection(t *testing.T) {
+	emulator := redfishemulator.New(redfishemulator.Quirks{})
+	defer emulator.Close()
+
+	bmc := New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword)
+
+	emulator.InjectFailure(redfishemulator.Failure{
+		Method:     http.MethodGet,
+		Path:       "/redfish/v1/Systems/1",
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "The service is temporarily unavailable.",
+		Count:      1,
+	})
+
+	_, err := bmc.SystemPowerState()
+	assert.ErrorContains(t, err, "503")
+
+	// The failure was consumed by the previous request.
+	powerState, err := bmc.SystemPowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, string(redfish.OnPowerState), powerState)
+
+	emulator.InjectFailure(redfishemulator.Failure{
+		Method:     http.MethodPost,
+		Path:       "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset",
+		StatusCode: http.StatusInternalServerError,
+	})
+
+	for range 2 {
+		err = bmc.SystemPowerOff()
+		assert.ErrorContains(t, err, "Injected failure.")
+	}
+
+	assert.Equal(t, redfish.OnPowerState, emulator.PowerState())
+
+	emulator.ClearFailures()
+
+	err = bmc.SystemPowerOff()
+	assert.Nil(t, err)
+	assert.Equal(t, redfish.OffPowerState, emulator.PowerState())
+
+	bmc = New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, "wrong")
+
+	_, err = bmc.SystemPowerState()
+	assert.ErrorContains(t, err, "401")
+}
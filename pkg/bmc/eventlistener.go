@@ -0,0 +1,214 @@
+package bmc
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// ReceivedEvent holds an event pushed by a BMC to an EventListener.
+type ReceivedEvent struct {
+	// Received is when the listener received the event.
+	Received time.Time
+	// Context is the context of the subscription the event was sent for.
+	Context string
+	// EventID is the identifier the BMC assigned to the event.
+	EventID string
+	// EventType is the deprecated type of the event, such as Alert or StatusChange, still sent by some BMCs.
+	EventType redfish.EventType
+	// Timestamp is when the event occurred, as reported by the BMC.
+	Timestamp string
+	// Severity is the severity of the event, taken from the deprecated Severity if MessageSeverity is not set.
+	Severity common.Health
+	// MessageID identifies the message of the event, such as ResourceEvent.1.0.ResourceStatusChangedCritical.
+	MessageID string
+	// Message is the human-readable message of the event.
+	Message string
+	// MessageArgs are the arguments of the message.
+	MessageArgs []string
+	// OriginOfCondition is the path of the resource the event is about.
+	OriginOfCondition string
+}
+
+// EventListener is an HTTP server that collects the events pushed by BMCs to their event subscriptions, so that tests
+// can make assertions on them.
+type EventListener struct {
+	listener net.Listener
+	server   *http.Server
+	scheme   string
+	mutex    sync.Mutex
+	events   []ReceivedEvent
+}
+
+// NewEventListener starts an EventListener on the provided address, such as ":0" for a random port. If tlsConfig is
+// not nil, the listener serves HTTPS, which some BMCs require for event destinations.
+func NewEventListener(address string, tlsConfig *tls.Config) (*EventListener, error) {
+	klog.V(100).Infof("Starting event listener on %s", address)
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		klog.V(100).Infof("Failed to listen on %s: %v", address, err)
+
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	eventListener := &EventListener{
+		listener: listener,
+		scheme:   "http",
+	}
+
+	eventListener.server = &http.Server{
+		Handler:           http.HandlerFunc(eventListener.handleEvent),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if tlsConfig != nil {
+		eventListener.scheme = "https"
+		eventListener.listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	go func() {
+		err := eventListener.server.Serve(eventListener.listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.V(100).Infof("Event listener on %s stopped: %v", listener.Addr(), err)
+		}
+	}()
+
+	return eventListener, nil
+}
+
+// Destination returns the URL to use as the destination of event subscriptions, with host being the address of this
+// machine as reachable from the BMC.
+func (eventListener *EventListener) Destination(host string) string {
+	_, port, _ := net.SplitHostPort(eventListener.listener.Addr().String())
+
+	return fmt.Sprintf("%s://%s/", eventListener.scheme, net.JoinHostPort(host, port))
+}
+
+// Port returns the port the listener is listening on.
+func (eventListener *EventListener) Port() int {
+	_, port, _ := net.SplitHostPort(eventListener.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	return portNumber
+}
+
+// Events returns the events received so far, in the order they were received.
+func (eventListener *EventListener) Events() []ReceivedEvent {
+	eventListener.mutex.Lock()
+	defer eventListener.mutex.Unlock()
+
+	events := make([]ReceivedEvent, len(eventListener.events))
+	copy(events, eventListener.events)
+
+	return events
+}
+
+// ClearEvents forgets the events received so far.
+func (eventListener *EventListener) ClearEvents() {
+	eventListener.mutex.Lock()
+	defer eventListener.mutex.Unlock()
+
+	eventListener.events = nil
+}
+
+// WaitForEvent waits up to timeout until an event for which match returns true is received, including events received
+// before calling it, and returns the first such event.
+func (eventListener *EventListener) WaitForEvent(
+	match func(event ReceivedEvent) bool, timeout time.Duration) (*ReceivedEvent, error) {
+	klog.V(100).Infof("Waiting up to %s for a matching event", timeout)
+
+	if match == nil {
+		klog.V(100).Info("The event match function is nil")
+
+		return nil, fmt.Errorf("event 'match' cannot be nil")
+	}
+
+	var matchingEvent *ReceivedEvent
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 500*time.Millisecond, timeout, true, func(ctx context.Context) (bool, error) {
+			for _, event := range eventListener.Events() {
+				if match(event) {
+					matchingEvent = &event
+
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		klog.V(100).Infof("No matching event received within %s: %v", timeout, err)
+
+		return nil, fmt.Errorf("no matching event received within %s: %w", timeout, err)
+	}
+
+	return matchingEvent, nil
+}
+
+// Close stops the listener. Events received so far are still available.
+func (eventListener *EventListener) Close() error {
+	klog.V(100).Infof("Stopping event listener on %s", eventListener.listener.Addr())
+
+	return eventListener.server.Close()
+}
+
+// handleEvent stores the events of a Redfish event payload. BMCs only check the status code, so any path is accepted.
+func (eventListener *EventListener) handleEvent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var payload redfish.Event
+
+	err := json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil {
+		klog.V(100).Infof("Failed to decode event from %s: %v", r.RemoteAddr, err)
+
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	received := time.Now()
+
+	eventListener.mutex.Lock()
+	defer eventListener.mutex.Unlock()
+
+	for _, record := range payload.Events {
+		severity := record.MessageSeverity
+		if severity == "" {
+			severity = common.Health(record.Severity)
+		}
+
+		eventListener.events = append(eventListener.events, ReceivedEvent{
+			Received:          received,
+			Context:           payload.Context,
+			EventID:           record.EventID,
+			EventType:         record.EventType,
+			Timestamp:         record.EventTimestamp,
+			Severity:          severity,
+			MessageID:         record.MessageID,
+			Message:           record.Message,
+			MessageArgs:       record.MessageArgs,
+			OriginOfCondition: record.OriginOfCondition,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,83 @@
+package bmc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+const redfishPowerOffEventJSON = `{
+	"@odata.type": "#Event.v1_4_0.Event",
+	"Id": "1",
+	"Name": "Event Array",
+	"Context": "test",
+	"Events": [
+		{
+			"EventId": "2162",
+			"EventType": "Alert",
+			"EventTimestamp": "2026-03-03T10:00:00-0500",
+			"Severity": "Informational",
+			"MessageId": "SYS1003",
+			"Message": "System CPU Resetting.",
+			"MessageArgs": [],
+			"OriginOfCondition": {"@odata.id": "/redfish/v1/Systems/System.Embedded.1"}
+		},
+		{
+			"EventId": "2163",
+			"MessageSeverity": "Critical",
+			"MessageId": "ResourceEvent.1.0.ResourceStatusChangedCritical",
+			"Message": "The health of resource PSU.Slot.1 has changed to Critical.",
+			"MessageArgs": ["PSU.Slot.1", "Critical"]
+		}
+	]
+}`
+
+func TestEventListener(t *testing.T) {
+	eventListener, err := NewEventListener("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+
+	defer eventListener.Close()
+
+	destination := eventListener.Destination("127.0.0.1")
+	assert.True(t, strings.HasPrefix(destination, "http://127.0.0.1:"))
+	assert.NotZero(t, eventListener.Port())
+
+	response, err := http.Post(destination, "application/json", strings.NewReader(redfishPowerOffEventJSON))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	response.Body.Close()
+
+	response, err = http.Post(destination, "application/json", strings.NewReader("not json"))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	response.Body.Close()
+
+	events := eventListener.Events()
+	assert.Len(t, events, 2)
+	assert.Equal(t, "test", events[0].Context)
+	assert.Equal(t, redfish.AlertEventType, events[0].EventType)
+	assert.Equal(t, common.Health("Informational"), events[0].Severity)
+	assert.Equal(t, "/redfish/v1/Systems/System.Embedded.1", events[0].OriginOfCondition)
+	assert.Equal(t, common.CriticalHealth, events[1].Severity)
+	assert.Equal(t, []string{"PSU.Slot.1", "Critical"}, events[1].MessageArgs)
+
+	event, err := eventListener.WaitForEvent(func(event ReceivedEvent) bool {
+		return strings.HasSuffix(event.MessageID, "ResourceStatusChangedCritical")
+	}, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "2163", event.EventID)
+
+	eventListener.ClearEvents()
+	assert.Empty(t, eventListener.Events())
+
+	_, err = eventListener.WaitForEvent(func(event ReceivedEvent) bool { return true }, time.Second)
+	assert.EqualError(t, err, "no matching event received within 1s: context deadline exceeded")
+
+	_, err = eventListener.WaitForEvent(nil, time.Second)
+	assert.EqualError(t, err, "event 'match' cannot be nil")
+}
@@ -0,0 +1,255 @@
+package bmc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// EventLogEntry holds an entry of a BMC log service, such as the System Event Log.
+type EventLogEntry struct {
+	// LogService is the ID of the log service the entry belongs to.
+	LogService string
+	// ID is the identifier of the entry within its log service.
+	ID string
+	// Created is when the entry was created. It is the zero time if the BMC reported an invalid timestamp.
+	Created time.Time
+	// Severity is the severity of the entry, such as OK, Warning or Critical.
+	Severity redfish.EventSeverity
+	// EntryType is the format of the entry, such as SEL or Event.
+	EntryType redfish.LogEntryType
+	// SensorType is the type of sensor that generated SEL entries, if any.
+	SensorType redfish.SensorType
+	// MessageID identifies the message of Event entries or holds the IPMI event data bytes of SEL entries.
+	MessageID string
+	// Message is the human-readable message of the entry.
+	Message string
+}
+
+// LogServices returns the IDs of the log services of the system and of the managers of the system, sorted by ID.
+func (bmc *BMC) LogServices() ([]string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting log services from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	logServices, err := redfishGetLogServices(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish log services: %v", err)
+
+		return nil, fmt.Errorf("failed to get log services: %w", err)
+	}
+
+	var logServiceIDs []string
+
+	for _, logService := range logServices {
+		logServiceIDs = append(logServiceIDs, logService.ID)
+	}
+
+	return logServiceIDs, nil
+}
+
+// LogEntries returns the entries of the log service with the provided ID that were created at or after since, oldest
+// first. If logServiceID is empty, the System Event Log is used. If since is the zero time, all entries are returned;
+// otherwise entries without a valid timestamp are skipped.
+func (bmc *BMC) LogEntries(logServiceID string, since time.Time) ([]EventLogEntry, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting entries of log service %q created since %s", logServiceID, since)
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	logService, err := redfishGetLogService(redfishClient, bmc.systemSelector(), logServiceID)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish log service: %v", err)
+
+		return nil, fmt.Errorf("failed to get log service: %w", err)
+	}
+
+	logEntries, err := logService.Entries()
+	if err != nil {
+		klog.V(100).Infof("Failed to get entries of redfish log service %s: %v", logService.ID, err)
+
+		return nil, fmt.Errorf("failed to get entries of log service %s: %w", logService.ID, err)
+	}
+
+	var entries []EventLogEntry
+
+	for _, logEntry := range logEntries {
+		created, err := time.Parse(time.RFC3339, logEntry.Created)
+		if err != nil {
+			klog.V(100).Infof("Log entry %s has invalid creation time %q: %v", logEntry.ID, logEntry.Created, err)
+
+			if !since.IsZero() {
+				continue
+			}
+
+			created = time.Time{}
+		}
+
+		if created.Before(since) {
+			continue
+		}
+
+		entries = append(entries, EventLogEntry{
+			LogService: logService.ID,
+			ID:         logEntry.ID,
+			Created:    created,
+			Severity:   logEntry.Severity,
+			EntryType:  logEntry.EntryType,
+			SensorType: logEntry.SensorType,
+			MessageID:  logEntry.MessageID,
+			Message:    logEntry.Message,
+		})
+	}
+
+	// BMCs list entries newest first or oldest first, so they are sorted to be consistent.
+	slices.SortStableFunc(entries, func(a, b EventLogEntry) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	return entries, nil
+}
+
+// ClearLog deletes all the entries of the log service with the provided ID. If logServiceID is empty, the System
+// Event Log is cleared.
+func (bmc *BMC) ClearLog(logServiceID string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Clearing log service %q", logServiceID)
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	logService, err := redfishGetLogService(redfishClient, bmc.systemSelector(), logServiceID)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish log service: %v", err)
+
+		return fmt.Errorf("failed to get log service: %w", err)
+	}
+
+	err = logService.ClearLog()
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to clear redfish log service %s: %v", logService.ID, err)
+
+		return fmt.Errorf("failed to clear log service %s: %w", logService.ID, err)
+	}
+
+	return nil
+}
+
+// redfishGetLogServices gets the log services of the system with the provided index and of the managers of the
+// system, sorted by ID. Depending on the vendor, the System Event Log is under either of them.
+func redfishGetLogServices(redfishClient *gofish.APIClient, selector systemSelector) ([]*redfish.LogService, error) {
+	system, err := redfishGetSystem(redfishClient, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	logServices, err := system.LogServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system log services: %w", err)
+	}
+
+	managers, err := system.ManagedBy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system managers: %w", err)
+	}
+
+	for _, manager := range managers {
+		managerLogServices, err := manager.LogServices()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get log services of manager %s: %w", manager.ID, err)
+		}
+
+		logServices = append(logServices, managerLogServices...)
+	}
+
+	slices.SortFunc(logServices, func(a, b *redfish.LogService) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return logServices, nil
+}
+
+// redfishGetLogService gets the log service with the provided ID. If logServiceID is empty, the System Event Log is
+// returned, which is the first log service of SEL entries or the one with the Sel ID.
+func redfishGetLogService(
+	redfishClient *gofish.APIClient, selector systemSelector, logServiceID string) (*redfish.LogService, error) {
+	logServices, err := redfishGetLogServices(redfishClient, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, logService := range logServices {
+		if logServiceID != "" && logService.ID == logServiceID {
+			return logService, nil
+		}
+
+		if logServiceID == "" &&
+			(logService.LogEntryType == redfish.SELLogEntryTypes || strings.EqualFold(logService.ID, "sel")) {
+			return logService, nil
+		}
+	}
+
+	if logServiceID == "" {
+		return nil, fmt.Errorf("system event log not found")
+	}
+
+	return nil, fmt.Errorf("log service %s not found", logServiceID)
+}
@@ -0,0 +1,241 @@
+package bmc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultLogServicesPath = "/redfish/v1/Managers/iDRAC.Embedded.1/LogServices"
+
+// fakeLogServices holds the entries of the log services on the fake Redfish server, keyed by log service ID.
+type fakeLogServices struct {
+	mutex   sync.Mutex
+	entries map[string][]map[string]any
+}
+
+func TestBMCLogServices(t *testing.T) {
+	redfishServer, _ := createFakeRedfishLogServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	logServices, err := bmc.LogServices()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Lclog", "Sel"}, logServices)
+}
+
+func TestBMCLogEntries(t *testing.T) {
+	testCases := []struct {
+		logServiceID      string
+		since             time.Time
+		expectedIDs       []string
+		expectedErrorText string
+	}{
+		{
+			logServiceID: "",
+			expectedIDs:  []string{"1", "2", "3"},
+		},
+		{
+			logServiceID: "Sel",
+			since:        time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+			expectedIDs:  []string{"2", "3"},
+		},
+		{
+			logServiceID: "Lclog",
+			since:        time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			expectedIDs:  []string{"LC1"},
+		},
+		{
+			logServiceID:      "Audit",
+			expectedErrorText: "failed to get log service: log service Audit not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, _ := createFakeRedfishLogServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		entries, err := bmc.LogEntries(testCase.logServiceID, testCase.since)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+
+		var entryIDs []string
+
+		for _, entry := range entries {
+			entryIDs = append(entryIDs, entry.ID)
+		}
+
+		assert.Equal(t, testCase.expectedIDs, entryIDs)
+	}
+}
+
+func TestBMCLogEntriesFields(t *testing.T) {
+	redfishServer, _ := createFakeRedfishLogServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	entries, err := bmc.LogEntries("Sel", time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, []EventLogEntry{{
+		LogService: "Sel",
+		ID:         "3",
+		Created:    time.Date(2026, time.March, 3, 10, 0, 0, 0, time.UTC),
+		Severity:   redfish.CriticalEventSeverity,
+		EntryType:  redfish.SELLogEntryType,
+		SensorType: redfish.SensorType("Power Unit"),
+		MessageID:  "PWR2262",
+		Message:    "The system is powered off because of an unexpected power loss.",
+	}}, entries)
+}
+
+func TestBMCClearLog(t *testing.T) {
+	redfishServer, logServices := createFakeRedfishLogServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	err := bmc.ClearLog("")
+	assert.Nil(t, err)
+	assert.Empty(t, logServices.entries["Sel"])
+	assert.Len(t, logServices.entries["Lclog"], 2)
+
+	entries, err := bmc.LogEntries("", time.Time{})
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+// createFakeRedfishLogServer creates a fake Redfish server whose manager has a System Event Log and a Lifecycle Log,
+// like Dell iDRAC. The SEL lists entries newest first.
+//
+//nolint:funlen
+func createFakeRedfishLogServer(t *testing.T) (*httptest.Server, *fakeLogServices) {
+	t.Helper()
+
+	logServices := &fakeLogServices{entries: map[string][]map[string]any{
+		"Sel": {
+			{
+				"Id": "3", "Created": "2026-03-03T10:00:00Z", "EntryType": "SEL", "Severity": "Critical",
+				"SensorType": "Power Unit", "MessageId": "PWR2262",
+				"Message": "The system is powered off because of an unexpected power loss.",
+			},
+			{
+				"Id": "2", "Created": "2026-03-02T10:00:00Z", "EntryType": "SEL", "Severity": "OK",
+				"Message": "The chassis is closed while the power is off.",
+			},
+			{
+				"Id": "1", "Created": "2026-03-01T10:00:00Z", "EntryType": "SEL", "Severity": "OK",
+				"Message": "Log cleared.",
+			},
+		},
+		"Lclog": {
+			{"Id": "LC0", "Created": "invalid", "EntryType": "Event", "Severity": "OK", "Message": "Unknown time."},
+			{"Id": "LC1", "Created": "2026-03-01T10:00:00Z", "EntryType": "Event", "Severity": "OK", "Message": "Started."},
+		},
+	}}
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":   "/redfish/v1/Managers/iDRAC.Embedded.1",
+			"Id":          "iDRAC.Embedded.1",
+			"LogServices": map[string]string{"@odata.id": defaultLogServicesPath},
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultLogServicesPath, func(w http.ResponseWriter, r *http.Request) {
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id": defaultLogServicesPath,
+			"Members": []map[string]string{
+				{"@odata.id": defaultLogServicesPath + "/Sel"},
+				{"@odata.id": defaultLogServicesPath + "/Lclog"},
+			},
+			"Members@odata.count": 2,
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultLogServicesPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		logServiceID := r.PathValue("id")
+
+		logEntryType := "Event"
+		if logServiceID == "Sel" {
+			logEntryType = "SEL"
+		}
+
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":    defaultLogServicesPath + "/" + logServiceID,
+			"Id":           logServiceID,
+			"LogEntryType": logEntryType,
+			"Entries":      map[string]string{"@odata.id": defaultLogServicesPath + "/" + logServiceID + "/Entries"},
+			"Actions": map[string]any{
+				"#LogService.ClearLog": map[string]string{
+					"target": defaultLogServicesPath + "/" + logServiceID + "/Actions/LogService.ClearLog",
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultLogServicesPath+"/{id}/Entries", func(w http.ResponseWriter, r *http.Request) {
+		logServices.mutex.Lock()
+		defer logServices.mutex.Unlock()
+
+		var members []map[string]string
+
+		for _, entry := range logServices.entries[r.PathValue("id")] {
+			members = append(members, map[string]string{"@odata.id": r.URL.Path + "/" + entry["Id"].(string)})
+		}
+
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":           r.URL.Path,
+			"Members":             members,
+			"Members@odata.count": len(members),
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultLogServicesPath+"/{id}/Entries/{entry}", func(w http.ResponseWriter, r *http.Request) {
+		logServices.mutex.Lock()
+		defer logServices.mutex.Unlock()
+
+		for _, entry := range logServices.entries[r.PathValue("id")] {
+			if entry["Id"] == r.PathValue("entry") {
+				writeFakeRedfishJSON(t, w, entry)
+
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("POST "+defaultLogServicesPath+"/{id}/Actions/LogService.ClearLog",
+		func(w http.ResponseWriter, r *http.Request) {
+			logServices.mutex.Lock()
+			defer logServices.mutex.Unlock()
+
+			logServices.entries[r.PathValue("id")] = nil
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	return startFakeRedfishLocalServer(mux), logServices
+}
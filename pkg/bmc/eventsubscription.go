@@ -0,0 +1,229 @@
+package bmc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// EventSubscription holds a subscription of the Redfish EventService.
+type EventSubscription struct {
+	// URI is the path of the subscription, used to delete it.
+	URI string
+	// ID is the identifier of the subscription.
+	ID string
+	// Destination is the URL the BMC sends events to.
+	Destination string
+	// Context is the client supplied string the BMC sends back with every event.
+	Context string
+	// Protocol is the protocol used to send events, usually Redfish.
+	Protocol redfish.EventDestinationProtocol
+	// RegistryPrefixes are the message registries of the subscribed events. Empty means all registries.
+	RegistryPrefixes []string
+	// ResourceTypes are the resource types whose events are subscribed to. Empty means all resource types.
+	ResourceTypes []string
+	// EventTypes are the deprecated event types of the subscription, still reported by some BMCs.
+	EventTypes []redfish.EventType
+}
+
+// EventSubscriptions returns the subscriptions of the Redfish EventService, sorted by ID.
+func (bmc *BMC) EventSubscriptions() ([]EventSubscription, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting event subscriptions from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	eventService, err := redfishClient.GetService().EventService()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish event service: %v", err)
+
+		return nil, fmt.Errorf("failed to get event service: %w", err)
+	}
+
+	eventDestinations, err := eventService.GetEventSubscriptions()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish event subscriptions: %v", err)
+
+		return nil, fmt.Errorf("failed to get event subscriptions: %w", err)
+	}
+
+	var subscriptions []EventSubscription
+
+	for _, eventDestination := range eventDestinations {
+		subscriptions = append(subscriptions, EventSubscription{
+			URI:              eventDestination.ODataID,
+			ID:               eventDestination.ID,
+			Destination:      eventDestination.Destination,
+			Context:          eventDestination.Context,
+			Protocol:         eventDestination.Protocol,
+			RegistryPrefixes: eventDestination.RegistryPrefixes,
+			ResourceTypes:    eventDestination.ResourceTypes,
+			EventTypes:       eventDestination.EventTypes,
+		})
+	}
+
+	slices.SortFunc(subscriptions, func(a, b EventSubscription) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return subscriptions, nil
+}
+
+// CreateEventSubscription subscribes the destination URL, such as the one of an EventListener, to the events of the
+// BMC. The context is sent back with every event and identifies the subscription, see DeleteEventSubscriptions.
+// RegistryPrefixes and resourceTypes optionally restrict the events sent, for example to the ResourceEvent registry.
+// The URI of the new subscription is returned.
+func (bmc *BMC) CreateEventSubscription(
+	destination, context string, registryPrefixes, resourceTypes []string) (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Creating event subscription to %s with context %s", destination, context)
+
+	if destination == "" {
+		klog.V(100).Info("The event subscription destination is empty")
+
+		return "", fmt.Errorf("event subscription 'destination' cannot be empty")
+	}
+
+	if context == "" {
+		klog.V(100).Info("The event subscription context is empty")
+
+		return "", fmt.Errorf("event subscription 'context' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	eventService, err := redfishClient.GetService().EventService()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish event service: %v", err)
+
+		return "", fmt.Errorf("failed to get event service: %w", err)
+	}
+
+	subscriptionURI, err := eventService.CreateEventSubscriptionInstance(
+		destination,
+		registryPrefixes,
+		resourceTypes,
+		nil,
+		redfish.RedfishEventDestinationProtocol,
+		context,
+		"",
+		nil)
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to create redfish event subscription: %v", err)
+
+		return "", fmt.Errorf("failed to create event subscription: %w", err)
+	}
+
+	return subscriptionURI, nil
+}
+
+// DeleteEventSubscription deletes the event subscription with the provided URI.
+func (bmc *BMC) DeleteEventSubscription(subscriptionURI string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Deleting event subscription %s", subscriptionURI)
+
+	if subscriptionURI == "" {
+		klog.V(100).Info("The event subscription URI is empty")
+
+		return fmt.Errorf("event subscription 'uri' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	err = redfish.DeleteEventDestination(redfishClient, subscriptionURI)
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to delete redfish event subscription %s: %v", subscriptionURI, err)
+
+		return fmt.Errorf("failed to delete event subscription %s: %w", subscriptionURI, err)
+	}
+
+	return nil
+}
+
+// DeleteEventSubscriptions deletes all the event subscriptions with the provided context. It allows cleaning up the
+// subscriptions left behind by previous runs, since BMCs only support a limited number of them.
+func (bmc *BMC) DeleteEventSubscriptions(context string) error {
+	klog.V(100).Infof("Deleting event subscriptions with context %s", context)
+
+	if context == "" {
+		klog.V(100).Info("The event subscription context is empty")
+
+		return fmt.Errorf("event subscription 'context' cannot be empty")
+	}
+
+	subscriptions, err := bmc.EventSubscriptions()
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.Context != context {
+			continue
+		}
+
+		err = bmc.DeleteEventSubscription(subscription.URI)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
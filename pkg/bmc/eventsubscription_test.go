@@ -0,0 +1,219 @@
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	defaultSubscriptionsPath = "/redfish/v1/EventService/Subscriptions"
+	defaultEventDestination  = "http://192.0.2.10:8080/"
+)
+
+// fakeEventService holds the subscriptions on the fake Redfish server, keyed by ID.
+type fakeEventService struct {
+	mutex         sync.Mutex
+	subscriptions map[string]map[string]any
+	nextID        int
+}
+
+func TestBMCEventSubscriptions(t *testing.T) {
+	redfishServer, eventService := createFakeRedfishEventServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	subscriptions, err := bmc.EventSubscriptions()
+	assert.Nil(t, err)
+	assert.Empty(t, subscriptions)
+
+	eventService.subscriptions["2"] = map[string]any{
+		"Id": "2", "Destination": defaultEventDestination, "Context": "other", "Protocol": "Redfish",
+		"EventTypes": []string{"Alert"},
+	}
+	eventService.subscriptions["1"] = map[string]any{
+		"Id": "1", "Destination": defaultEventDestination, "Context": "test", "Protocol": "Redfish",
+		"RegistryPrefixes": []string{"ResourceEvent"},
+	}
+
+	subscriptions, err = bmc.EventSubscriptions()
+	assert.Nil(t, err)
+	assert.Len(t, subscriptions, 2)
+	assert.Equal(t, defaultSubscriptionsPath+"/1", subscriptions[0].URI)
+	assert.Equal(t, "test", subscriptions[0].Context)
+	assert.Equal(t, []string{"ResourceEvent"}, subscriptions[0].RegistryPrefixes)
+	assert.Equal(t, "other", subscriptions[1].Context)
+}
+
+func TestBMCCreateEventSubscription(t *testing.T) {
+	testCases := []struct {
+		destination       string
+		context           string
+		expectedErrorText string
+	}{
+		{
+			destination: defaultEventDestination,
+			context:     "test",
+		},
+		{
+			destination:       "",
+			context:           "test",
+			expectedErrorText: "event subscription 'destination' cannot be empty",
+		},
+		{
+			destination:       defaultEventDestination,
+			context:           "",
+			expectedErrorText: "event subscription 'context' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, eventService := createFakeRedfishEventServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		subscriptionURI, err := bmc.CreateEventSubscription(
+			testCase.destination, testCase.context, []string{"ResourceEvent"}, nil)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, defaultSubscriptionsPath+"/1", subscriptionURI)
+		assert.Equal(t, testCase.destination, eventService.subscriptions["1"]["Destination"])
+		assert.Equal(t, testCase.context, eventService.subscriptions["1"]["Context"])
+		assert.Equal(t, "Redfish", eventService.subscriptions["1"]["Protocol"])
+		assert.Equal(t, []any{"ResourceEvent"}, eventService.subscriptions["1"]["RegistryPrefixes"])
+	}
+}
+
+func TestBMCDeleteEventSubscriptions(t *testing.T) {
+	redfishServer, _ := createFakeRedfishEventServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	for _, context := range []string{"test", "other", "test"} {
+		_, err := bmc.CreateEventSubscription(defaultEventDestination, context, nil, nil)
+		assert.Nil(t, err)
+	}
+
+	err := bmc.DeleteEventSubscriptions("test")
+	assert.Nil(t, err)
+
+	subscriptions, err := bmc.EventSubscriptions()
+	assert.Nil(t, err)
+	assert.Len(t, subscriptions, 1)
+	assert.Equal(t, "other", subscriptions[0].Context)
+
+	err = bmc.DeleteEventSubscription(subscriptions[0].URI)
+	assert.Nil(t, err)
+
+	err = bmc.DeleteEventSubscription(subscriptions[0].URI)
+	assert.ErrorContains(t, err, "failed to delete event subscription "+subscriptions[0].URI)
+
+	err = bmc.DeleteEventSubscriptions("")
+	assert.EqualError(t, err, "event subscription 'context' cannot be empty")
+}
+
+// createFakeRedfishEventServer creates a fake Redfish server with an EventService whose subscriptions can be created,
+// listed and deleted.
+func createFakeRedfishEventServer(t *testing.T) (*httptest.Server, *fakeEventService) {
+	t.Helper()
+
+	eventService := &fakeEventService{subscriptions: map[string]map[string]any{}}
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+
+	mux.HandleFunc("GET /redfish/v1/EventService", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":        "/redfish/v1/EventService",
+			"Id":               "EventService",
+			"ServiceEnabled":   true,
+			"RegistryPrefixes": []string{"ResourceEvent", "iDRAC"},
+			"Subscriptions":    map[string]string{"@odata.id": defaultSubscriptionsPath},
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		eventService.mutex.Lock()
+		defer eventService.mutex.Unlock()
+
+		members := []map[string]string{}
+
+		for id := range eventService.subscriptions {
+			members = append(members, map[string]string{"@odata.id": defaultSubscriptionsPath + "/" + id})
+		}
+
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":           defaultSubscriptionsPath,
+			"Members":             members,
+			"Members@odata.count": len(members),
+		})
+	})
+
+	mux.HandleFunc("POST "+defaultSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		eventService.mutex.Lock()
+		defer eventService.mutex.Unlock()
+
+		var subscription map[string]any
+
+		err := json.NewDecoder(r.Body).Decode(&subscription)
+		if err != nil {
+			t.Errorf("Failed to decode subscription request: %v", err)
+		}
+
+		eventService.nextID++
+		id := fmt.Sprint(eventService.nextID)
+		subscription["Id"] = id
+		eventService.subscriptions[id] = subscription
+
+		w.Header().Set("Location", "https://"+r.Host+defaultSubscriptionsPath+"/"+id)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("GET "+defaultSubscriptionsPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		eventService.mutex.Lock()
+		defer eventService.mutex.Unlock()
+
+		subscription, ok := eventService.subscriptions[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		subscription["@odata.id"] = r.URL.Path
+		writeFakeRedfishJSON(t, w, subscription)
+	})
+
+	mux.HandleFunc("DELETE "+defaultSubscriptionsPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		eventService.mutex.Lock()
+		defer eventService.mutex.Unlock()
+
+		if _, ok := eventService.subscriptions[r.PathValue("id")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		delete(eventService.subscriptions, r.PathValue("id"))
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return startFakeRedfishLocalServer(mux), eventService
+}
@@ -0,0 +1,274 @@
+package bmc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// FirmwareComponent holds a firmware component from the Redfish firmware inventory.
+type FirmwareComponent struct {
+	// URI is the path of the firmware inventory item, which can be used as an update target.
+	URI string
+	// ID is the identifier of the firmware inventory item.
+	ID string
+	// Name is the name of the component, such as the NIC or BIOS it belongs to.
+	Name string
+	// Version is the version of the firmware.
+	Version string
+	// Updateable is true if the firmware can be updated through the UpdateService.
+	Updateable bool
+	// SoftwareID is the implementation specific label of the firmware.
+	SoftwareID string
+	// Manufacturer is the manufacturer of the firmware.
+	Manufacturer string
+	// ReleaseDate is the release date of the firmware, if the BMC reports it.
+	ReleaseDate string
+}
+
+// FirmwareInventory returns the firmware components of the UpdateService firmware inventory, sorted by ID.
+func (bmc *BMC) FirmwareInventory() ([]FirmwareComponent, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting firmware inventory from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	updateService, err := redfishClient.GetService().UpdateService()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish update service: %v", err)
+
+		return nil, fmt.Errorf("failed to get update service: %w", err)
+	}
+
+	inventories, err := updateService.FirmwareInventories()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish firmware inventory: %v", err)
+
+		return nil, fmt.Errorf("failed to get firmware inventory: %w", err)
+	}
+
+	var components []FirmwareComponent
+
+	for _, inventory := range inventories {
+		components = append(components, FirmwareComponent{
+			URI:          inventory.ODataID,
+			ID:           inventory.ID,
+			Name:         inventory.Name,
+			Version:      inventory.Version,
+			Updateable:   inventory.Updateable,
+			SoftwareID:   inventory.SoftwareID,
+			Manufacturer: inventory.Manufacturer,
+			ReleaseDate:  inventory.ReleaseDate,
+		})
+	}
+
+	slices.SortFunc(components, func(a, b FirmwareComponent) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return components, nil
+}
+
+// SimpleUpdateFirmware asks the BMC to download the firmware image at imageURI and apply it using the SimpleUpdate
+// action of the UpdateService. Targets are optional URIs of firmware inventory items to update; if empty, the BMC
+// applies the image to every applicable component. The URI of the task tracking the update is returned, see
+// WaitForTask.
+func (bmc *BMC) SimpleUpdateFirmware(imageURI string, targets []string) (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Updating firmware from %s with targets %v using SimpleUpdate", imageURI, targets)
+
+	if imageURI == "" {
+		klog.V(100).Info("The firmware image URI is empty")
+
+		return "", fmt.Errorf("firmware 'imageURI' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	updateService, err := redfishClient.GetService().UpdateService()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish update service: %v", err)
+
+		return "", fmt.Errorf("failed to get update service: %w", err)
+	}
+
+	// gofish discards the response of SimpleUpdate, which holds the task, so the action is posted directly.
+	var actions struct {
+		Actions struct {
+			SimpleUpdate common.ActionTarget `json:"#UpdateService.SimpleUpdate"`
+		}
+	}
+
+	err = json.Unmarshal(updateService.RawData, &actions)
+	if err != nil || actions.Actions.SimpleUpdate.Target == "" {
+		klog.V(100).Info("The update service does not support SimpleUpdate")
+
+		return "", fmt.Errorf("update service does not support SimpleUpdate")
+	}
+
+	parameters := redfish.SimpleUpdateParameters{
+		ImageURI: imageURI,
+		Targets:  targets,
+	}
+
+	// Some BMCs require the transfer protocol even when the image URI has a scheme.
+	if imageURL, err := url.Parse(imageURI); err == nil {
+		transferProtocol := strings.ToUpper(imageURL.Scheme)
+		if slices.Contains(updateService.TransferProtocol, transferProtocol) {
+			parameters.TransferProtocol = redfish.TransferProtocolType(transferProtocol)
+		}
+	}
+
+	response, err := redfishClient.Post(actions.Actions.SimpleUpdate.Target, parameters)
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to start SimpleUpdate: %v", err)
+
+		return "", fmt.Errorf("failed to start firmware update: %w", err)
+	}
+
+	return redfishGetUpdateTaskURI(response)
+}
+
+// PushFirmwareUpdate uploads the firmware image at imagePath to the multipart HTTP push URI of the UpdateService to
+// apply it immediately. Targets are optional URIs of firmware inventory items to update; if empty, the BMC applies the
+// image to every applicable component. The URI of the task tracking the update is returned, see WaitForTask. Requests
+// are limited by the firmware upload timeout rather than the Redfish timeout, see WithFirmwareUploadTimeout.
+func (bmc *BMC) PushFirmwareUpdate(imagePath string, targets []string) (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Updating firmware from %s with targets %v using multipart HTTP push", imagePath, targets)
+
+	if imagePath == "" {
+		klog.V(100).Info("The firmware image path is empty")
+
+		return "", fmt.Errorf("firmware 'imagePath' cannot be empty")
+	}
+
+	imageFile, err := os.Open(imagePath)
+	if err != nil {
+		klog.V(100).Infof("Failed to open firmware image %s: %v", imagePath, err)
+
+		return "", fmt.Errorf("failed to open firmware image: %w", err)
+	}
+
+	defer imageFile.Close()
+
+	redfishClient, cancel, err := redfishConnectSession(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.FirmwareUpload)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	updateService, err := redfishClient.GetService().UpdateService()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish update service: %v", err)
+
+		return "", fmt.Errorf("failed to get update service: %w", err)
+	}
+
+	if updateService.MultipartHTTPPushURI == "" {
+		klog.V(100).Info("The update service does not support multipart HTTP push")
+
+		return "", fmt.Errorf("update service does not support multipart HTTP push")
+	}
+
+	if targets == nil {
+		targets = []string{}
+	}
+
+	updateParameters, err := json.Marshal(map[string]any{
+		"Targets":                     targets,
+		"@Redfish.OperationApplyTime": common.ImmediateOperationApplyTime,
+	})
+	if err != nil {
+		klog.V(100).Infof("Failed to marshal update parameters: %v", err)
+
+		return "", fmt.Errorf("failed to marshal update parameters: %w", err)
+	}
+
+	response, err := redfishClient.PostMultipart(updateService.MultipartHTTPPushURI, map[string]io.Reader{
+		"UpdateParameters": bytes.NewReader(updateParameters),
+		"UpdateFile":       imageFile,
+	})
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to push firmware image: %v", err)
+
+		return "", fmt.Errorf("failed to push firmware image: %w", err)
+	}
+
+	return redfishGetUpdateTaskURI(response)
+}
+
+// redfishGetUpdateTaskURI returns the URI of the task created by a firmware update request and closes its body.
+func redfishGetUpdateTaskURI(response *http.Response) (string, error) {
+	defer response.Body.Close()
+
+	taskURI, err := getTaskURIFromResponse(response)
+	if err != nil {
+		klog.V(100).Infof("Failed to get firmware update task: %v", err)
+
+		return "", fmt.Errorf("failed to get firmware update task: %w", err)
+	}
+
+	return taskURI, nil
+}
@@ -0,0 +1,417 @@
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	defaultUpdateServicePath = "/redfish/v1/UpdateService"
+	defaultTaskPath          = "/redfish/v1/TaskService/Tasks/JID_001"
+	defaultFirmwareImageURI  = "http://images.example.com/firmware.exe"
+	defaultBIOSFirmwarePath  = "/redfish/v1/UpdateService/FirmwareInventory/Installed-159-1.10.2"
+)
+
+// fakeUpdateService holds the requests received by the fake UpdateService and the states its task goes through.
+type fakeUpdateService struct {
+	mutex sync.Mutex
+	// simpleUpdate is the body of the last SimpleUpdate request.
+	simpleUpdate map[string]any
+	// pushParameters and pushFile are the parts of the last multipart push request.
+	pushParameters map[string]any
+	pushFile       string
+	// taskStates are returned by successive task requests, repeating the last one once exhausted.
+	taskStates []redfish.TaskState
+	taskGets   int
+	// uploadDelay is how long the multipart push request takes.
+	uploadDelay time.Duration
+	// sessions and deletedSessions are the number of Redfish sessions created and deleted.
+	sessions        int
+	deletedSessions int
+}
+
+func TestBMCFirmwareInventory(t *testing.T) {
+	redfishServer, _ := createFakeRedfishUpdateServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	components, err := bmc.FirmwareInventory()
+	assert.Nil(t, err)
+	assert.Equal(t, []FirmwareComponent{
+		{
+			URI:        defaultBIOSFirmwarePath,
+			ID:         "Installed-159-1.10.2",
+			Name:       "BIOS",
+			Version:    "1.10.2",
+			Updateable: true,
+			SoftwareID: "159",
+		},
+		{
+			URI:        "/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.00.60.00",
+			ID:         "Installed-25227-7.00.60.00",
+			Name:       "Integrated Dell Remote Access Controller",
+			Version:    "7.00.60.00",
+			Updateable: false,
+			SoftwareID: "25227",
+		},
+	}, components)
+}
+
+func TestBMCSimpleUpdateFirmware(t *testing.T) {
+	testCases := []struct {
+		imageURI          string
+		targets           []string
+		expectedErrorText string
+	}{
+		{
+			imageURI: defaultFirmwareImageURI,
+			targets:  []string{defaultBIOSFirmwarePath},
+		},
+		{
+			imageURI: "ftp://images.example.com/firmware.exe",
+		},
+		{
+			imageURI:          "",
+			expectedErrorText: "firmware 'imageURI' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, updateService := createFakeRedfishUpdateServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		taskURI, err := bmc.SimpleUpdateFirmware(testCase.imageURI, testCase.targets)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, defaultTaskPath, taskURI)
+		assert.Equal(t, testCase.imageURI, updateService.simpleUpdate["ImageURI"])
+
+		// Only the protocols the service allows are sent.
+		if strings.HasPrefix(testCase.imageURI, "http:") {
+			assert.Equal(t, "HTTP", updateService.simpleUpdate["TransferProtocol"])
+			assert.Equal(t, []any{defaultBIOSFirmwarePath}, updateService.simpleUpdate["Targets"])
+		} else {
+			assert.NotContains(t, updateService.simpleUpdate, "TransferProtocol")
+			assert.NotContains(t, updateService.simpleUpdate, "Targets")
+		}
+	}
+}
+
+func TestBMCPushFirmwareUpdate(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "firmware.exe")
+
+	err := os.WriteFile(imagePath, []byte("firmware image"), 0600)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		imagePath         string
+		expectedErrorText string
+	}{
+		{
+			imagePath: imagePath,
+		},
+		{
+			imagePath:         "",
+			expectedErrorText: "firmware 'imagePath' cannot be empty",
+		},
+		{
+			imagePath:         filepath.Join(t.TempDir(), "missing.exe"),
+			expectedErrorText: "failed to open firmware image",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, updateService := createFakeRedfishUpdateServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		taskURI, err := bmc.PushFirmwareUpdate(testCase.imagePath, nil)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.ErrorContains(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, defaultTaskPath, taskURI)
+		assert.Equal(t, "firmware image", updateService.pushFile)
+		assert.Equal(t, map[string]any{"Targets": []any{}, "@Redfish.OperationApplyTime": "Immediate"},
+			updateService.pushParameters)
+	}
+}
+
+func TestBMCWaitForTask(t *testing.T) {
+	testCases := []struct {
+		taskStates        []redfish.TaskState
+		timeout           time.Duration
+		expectedProgress  int
+		expectedErrorText string
+	}{
+		{
+			taskStates:       []redfish.TaskState{redfish.RunningTaskState, redfish.CompletedTaskState},
+			timeout:          5 * time.Second,
+			expectedProgress: 2,
+		},
+		{
+			taskStates:       []redfish.TaskState{redfish.ExceptionTaskState},
+			timeout:          time.Second,
+			expectedProgress: 1,
+			expectedErrorText: "task /redfish/v1/TaskService/Tasks/JID_001 finished in state Exception: " +
+				"Task state is Exception",
+		},
+		{
+			taskStates:       []redfish.TaskState{redfish.RunningTaskState},
+			timeout:          time.Second,
+			expectedProgress: 1,
+			expectedErrorText: "task /redfish/v1/TaskService/Tasks/JID_001 did not finish within 1s " +
+				"(state: Running, percent complete: 50): context deadline exceeded",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, updateService := createFakeRedfishUpdateServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		updateService.taskStates = testCase.taskStates
+
+		var progressStates []redfish.TaskState
+
+		err := bmc.WaitForTask(defaultTaskPath, testCase.timeout, func(task RedfishTask) {
+			progressStates = append(progressStates, task.State)
+		})
+
+		redfishServer.Close()
+
+		assert.Len(t, progressStates, testCase.expectedProgress)
+		assert.Equal(t, 1, updateService.sessions)
+		assert.Equal(t, 1, updateService.deletedSessions)
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
+
+// createFakeRedfishUpdateServer creates a fake Redfish server with an UpdateService that has a BIOS and a BMC
+// firmware inventory item. Both update methods create the same task.
+//
+//nolint:funlen
+func createFakeRedfishUpdateServer(t *testing.T) (*httptest.Server, *fakeUpdateService) {
+	t.Helper()
+
+	updateService := &fakeUpdateService{taskStates: []redfish.TaskState{redfish.CompletedTaskState}}
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+
+	mux.HandleFunc("POST /redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		updateService.mutex.Lock()
+		defer updateService.mutex.Unlock()
+
+		updateService.sessions++
+
+		w.Header().Set("Location", fmt.Sprintf("/redfish/v1/SessionService/Sessions/%d", updateService.sessions))
+		w.Header().Set("X-Auth-Token", "token")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("DELETE /redfish/v1/SessionService/Sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		updateService.mutex.Lock()
+		defer updateService.mutex.Unlock()
+
+		updateService.deletedSessions++
+	})
+
+	mux.HandleFunc("GET "+defaultUpdateServicePath, func(w http.ResponseWriter, r *http.Request) {
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":            defaultUpdateServicePath,
+			"@odata.type":          "#UpdateService.v1_11_0.UpdateService",
+			"Id":                   "UpdateService",
+			"ServiceEnabled":       true,
+			"FirmwareInventory":    map[string]string{"@odata.id": defaultUpdateServicePath + "/FirmwareInventory"},
+			"MultiPartHttpPushUri": defaultUpdateServicePath + "/MultipartUpload",
+			"Actions": map[string]any{
+				"#UpdateService.SimpleUpdate": map[string]any{
+					"target": defaultUpdateServicePath + "/Actions/UpdateService.SimpleUpdate",
+					"TransferProtocol@Redfish.AllowableValues": []string{"HTTP", "HTTPS", "NFS", "CIFS"},
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultUpdateServicePath+"/FirmwareInventory", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id": defaultUpdateServicePath + "/FirmwareInventory",
+			"Members": []map[string]string{
+				{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.00.60.00"},
+				{"@odata.id": defaultBIOSFirmwarePath},
+			},
+			"Members@odata.count": 2,
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultBIOSFirmwarePath, func(w http.ResponseWriter, r *http.Request) {
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":  defaultBIOSFirmwarePath,
+			"Id":         "Installed-159-1.10.2",
+			"Name":       "BIOS",
+			"SoftwareId": "159",
+			"Updateable": true,
+			"Version":    "1.10.2",
+		})
+	})
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.00.60.00",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeFakeRedfishJSON(t, w, map[string]any{
+				"@odata.id":  "/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.00.60.00",
+				"Id":         "Installed-25227-7.00.60.00",
+				"Name":       "Integrated Dell Remote Access Controller",
+				"SoftwareId": "25227",
+				"Updateable": false,
+				"Version":    "7.00.60.00",
+			})
+		})
+
+	mux.HandleFunc("POST "+defaultUpdateServicePath+"/Actions/UpdateService.SimpleUpdate",
+		func(w http.ResponseWriter, r *http.Request) {
+			updateService.mutex.Lock()
+			defer updateService.mutex.Unlock()
+
+			err := json.NewDecoder(r.Body).Decode(&updateService.simpleUpdate)
+			if err != nil {
+				t.Errorf("Failed to decode SimpleUpdate request: %v", err)
+			}
+
+			w.Header().Set("Location", "https://"+r.Host+defaultTaskPath)
+			w.WriteHeader(http.StatusAccepted)
+		})
+
+	mux.HandleFunc("POST "+defaultUpdateServicePath+"/MultipartUpload", func(w http.ResponseWriter, r *http.Request) {
+		updateService.mutex.Lock()
+		defer updateService.mutex.Unlock()
+
+		time.Sleep(updateService.uploadDelay)
+
+		err := r.ParseMultipartForm(1 << 20)
+		if err != nil {
+			t.Errorf("Failed to parse multipart push request: %v", err)
+		}
+
+		err = json.Unmarshal([]byte(r.FormValue("UpdateParameters")), &updateService.pushParameters)
+		if err != nil {
+			t.Errorf("Failed to decode multipart push parameters: %v", err)
+		}
+
+		file, _, err := r.FormFile("UpdateFile")
+		if err != nil {
+			t.Errorf("Failed to get multipart push file: %v", err)
+		} else {
+			contents, _ := io.ReadAll(file)
+			updateService.pushFile = string(contents)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":   defaultTaskPath,
+			"@odata.type": "#Task.v1_4_3.Task",
+			"Id":          "JID_001",
+			"TaskState":   redfish.NewTaskState,
+		})
+	})
+
+	mux.HandleFunc("GET "+defaultTaskPath, func(w http.ResponseWriter, r *http.Request) {
+		updateService.mutex.Lock()
+		defer updateService.mutex.Unlock()
+
+		state := updateService.taskStates[min(updateService.taskGets, len(updateService.taskStates)-1)]
+		updateService.taskGets++
+
+		percentComplete := 50
+		if state == redfish.CompletedTaskState {
+			percentComplete = 100
+		}
+
+		writeFakeRedfishJSON(t, w, map[string]any{
+			"@odata.id":       defaultTaskPath,
+			"@odata.type":     "#Task.v1_4_3.Task",
+			"Id":              "JID_001",
+			"TaskState":       state,
+			"PercentComplete": percentComplete,
+			"Messages":        []map[string]string{{"Message": "Task state is " + string(state)}},
+		})
+	})
+
+	return startFakeRedfishLocalServer(mux), updateService
+}
+
+func TestBMCWaitForTaskLongerThanRedfishTimeout(t *testing.T) {
+	redfishServer, updateService := createFakeRedfishUpdateServer(t)
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword).WithRedfishTimeout(500 * time.Millisecond)
+
+	// Polls are 2s apart, so the task completes well after the Redfish timeout.
+	updateService.taskStates = []redfish.TaskState{redfish.RunningTaskState, redfish.CompletedTaskState}
+
+	err := bmc.WaitForTask(defaultTaskPath, 5*time.Second, nil)
+
+	redfishServer.Close()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, updateService.taskGets)
+	assert.Equal(t, 1, updateService.sessions)
+	assert.Equal(t, 1, updateService.deletedSessions)
+}
+
+func TestBMCPushFirmwareUpdateLongerThanRedfishTimeout(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "firmware.exe")
+
+	err := os.WriteFile(imagePath, []byte("firmware image"), 0600)
+	assert.Nil(t, err)
+
+	redfishServer, updateService := createFakeRedfishUpdateServer(t)
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword).WithRedfishTimeout(200 * time.Millisecond)
+
+	updateService.uploadDelay = 500 * time.Millisecond
+
+	taskURI, err := bmc.PushFirmwareUpdate(imagePath, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultTaskPath, taskURI)
+
+	bmc.WithFirmwareUploadTimeout(200 * time.Millisecond)
+
+	_, err = bmc.PushFirmwareUpdate(imagePath, nil)
+	assert.ErrorContains(t, err, "failed to push firmware image")
+
+	redfishServer.Close()
+
+	assert.Equal(t, "firmware image", updateService.pushFile)
+}
@@ -0,0 +1,307 @@
+package bmc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// defaultFleetParallelism is the default number of hosts a fleet operates on at the same time.
+const defaultFleetParallelism = 10
+
+// FleetResult holds the result of an operation on a single host of a fleet.
+type FleetResult struct {
+	// Name is the name of the host in the fleet.
+	Name string
+	// Err is the error returned by the operation, or nil if it succeeded.
+	Err error
+	// Duration is how long the operation took on this host.
+	Duration time.Duration
+}
+
+// FleetResults holds the results of an operation on every host of a fleet, in the order the hosts were added.
+type FleetResults []FleetResult
+
+// Failed returns the results of the hosts where the operation failed.
+func (results FleetResults) Failed() FleetResults {
+	var failed FleetResults
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// Err returns the errors of the hosts where the operation failed, each prefixed by the host name, or nil if it
+// succeeded on every host.
+func (results FleetResults) Err() error {
+	var errs []error
+
+	for _, result := range results.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns the results as a table with a row per host.
+func (results FleetResults) String() string {
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "HOST\tRESULT\tDURATION")
+
+	for _, result := range results {
+		status := "OK"
+		if result.Err != nil {
+			status = fmt.Sprintf("FAILED: %v", result.Err)
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Name, status, result.Duration.Round(time.Millisecond))
+	}
+
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// fleetMember is a host of a fleet.
+type fleetMember struct {
+	name string
+	bmc  *BMC
+}
+
+// Fleet runs BMC operations on many hosts concurrently, with at most parallelism hosts at the same time. Operations
+// return a result per host and an error joining the errors of every host where they failed.
+type Fleet struct {
+	members     []fleetMember
+	parallelism int
+
+	errorMsg string
+}
+
+// NewFleet returns an empty fleet. Hosts are added with WithBMC and, unless changed with WithParallelism, up to 10 of
+// them are operated on at the same time.
+func NewFleet() *Fleet {
+	klog.V(100).Info("Creating new BMC fleet")
+
+	return &Fleet{
+		parallelism: defaultFleetParallelism,
+	}
+}
+
+// WithBMC adds a host to the fleet. The name identifies the host in the results and must be unique in the fleet.
+func (fleet *Fleet) WithBMC(name string, bmc *BMC) *Fleet {
+	if valid, _ := fleet.validate(); !valid {
+		return fleet
+	}
+
+	klog.V(100).Infof("Adding host %s to the BMC fleet", name)
+
+	if name == "" {
+		klog.V(100).Info("The name of the fleet host is empty")
+
+		fleet.errorMsg = "fleet host 'name' cannot be empty"
+
+		return fleet
+	}
+
+	if bmc == nil {
+		klog.V(100).Infof("The BMC of fleet host %s is nil", name)
+
+		fleet.errorMsg = fmt.Sprintf("fleet host %s 'bmc' cannot be nil", name)
+
+		return fleet
+	}
+
+	if slices.ContainsFunc(fleet.members, func(member fleetMember) bool { return member.name == name }) {
+		klog.V(100).Infof("The fleet already has a host named %s", name)
+
+		fleet.errorMsg = fmt.Sprintf("fleet already has a host named %s", name)
+
+		return fleet
+	}
+
+	fleet.members = append(fleet.members, fleetMember{name: name, bmc: bmc})
+
+	return fleet
+}
+
+// WithParallelism sets the maximum number of hosts the fleet operates on at the same time. It must be positive.
+func (fleet *Fleet) WithParallelism(parallelism int) *Fleet {
+	if valid, _ := fleet.validate(); !valid {
+		return fleet
+	}
+
+	klog.V(100).Infof("Setting BMC fleet parallelism to %d", parallelism)
+
+	if parallelism <= 0 {
+		klog.V(100).Info("The parallelism of the fleet is not positive")
+
+		fleet.errorMsg = "fleet 'parallelism' must be positive"
+
+		return fleet
+	}
+
+	fleet.parallelism = parallelism
+
+	return fleet
+}
+
+// Hosts returns the names of the hosts of the fleet, in the order they were added.
+func (fleet *Fleet) Hosts() []string {
+	if fleet == nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, member := range fleet.members {
+		names = append(names, member.name)
+	}
+
+	return names
+}
+
+// BMC returns the BMC of the fleet host with the provided name, or nil if there is no such host.
+func (fleet *Fleet) BMC(name string) *BMC {
+	if fleet == nil {
+		return nil
+	}
+
+	for _, member := range fleet.members {
+		if member.name == name {
+			return member.bmc
+		}
+	}
+
+	return nil
+}
+
+// Run runs operation on every host of the fleet, with at most the fleet parallelism running at the same time, and
+// waits for all of them to finish. The returned error joins the errors of every host where operation failed.
+func (fleet *Fleet) Run(operation func(bmc *BMC) error) (FleetResults, error) {
+	if valid, err := fleet.validate(); !valid {
+		return nil, err
+	}
+
+	if operation == nil {
+		klog.V(100).Info("The fleet operation is nil")
+
+		return nil, fmt.Errorf("fleet 'operation' cannot be nil")
+	}
+
+	klog.V(100).Infof("Running operation on %d fleet hosts with parallelism %d", len(fleet.members), fleet.parallelism)
+
+	var (
+		results   = make(FleetResults, len(fleet.members))
+		semaphore = make(chan struct{}, fleet.parallelism)
+		waitGroup sync.WaitGroup
+	)
+
+	for index, member := range fleet.members {
+		waitGroup.Go(func() {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			start := time.Now()
+			err := operation(member.bmc)
+
+			if err != nil {
+				klog.V(100).Infof("Fleet operation failed on host %s: %v", member.name, err)
+			}
+
+			results[index] = FleetResult{Name: member.name, Err: err, Duration: time.Since(start)}
+		})
+	}
+
+	waitGroup.Wait()
+
+	return results, results.Err()
+}
+
+// SystemResetAction performs the specified reset action on every host of the fleet.
+func (fleet *Fleet) SystemResetAction(action redfish.ResetType) (FleetResults, error) {
+	return fleet.Run(func(bmc *BMC) error {
+		return bmc.SystemResetAction(action)
+	})
+}
+
+// SystemPowerOn powers on every host of the fleet.
+func (fleet *Fleet) SystemPowerOn() (FleetResults, error) {
+	return fleet.Run((*BMC).SystemPowerOn)
+}
+
+// SystemPowerOff performs a non-graceful power off of every host of the fleet.
+func (fleet *Fleet) SystemPowerOff() (FleetResults, error) {
+	return fleet.Run((*BMC).SystemPowerOff)
+}
+
+// SystemGracefulShutdown performs a graceful shutdown of every host of the fleet.
+func (fleet *Fleet) SystemGracefulShutdown() (FleetResults, error) {
+	return fleet.Run((*BMC).SystemGracefulShutdown)
+}
+
+// SystemForceReset performs a forced reset of every host of the fleet.
+func (fleet *Fleet) SystemForceReset() (FleetResults, error) {
+	return fleet.Run((*BMC).SystemForceReset)
+}
+
+// SystemPowerCycle power cycles every host of the fleet, falling back to power off and on where power cycle is not
+// supported.
+func (fleet *Fleet) SystemPowerCycle() (FleetResults, error) {
+	return fleet.Run((*BMC).SystemPowerCycle)
+}
+
+// BootFromCD inserts the image available in isoURL in the virtual media with virtualMediaID of every host of the fleet
+// and boots them from it only once.
+func (fleet *Fleet) BootFromCD(isoURL, virtualMediaID string) (FleetResults, error) {
+	return fleet.Run(func(bmc *BMC) error {
+		return bmc.BootFromCD(isoURL, virtualMediaID)
+	})
+}
+
+// SecureBootEnable enables secure boot on every host of the fleet.
+func (fleet *Fleet) SecureBootEnable() (FleetResults, error) {
+	return fleet.Run((*BMC).SecureBootEnable)
+}
+
+// SecureBootDisable disables secure boot on every host of the fleet.
+func (fleet *Fleet) SecureBootDisable() (FleetResults, error) {
+	return fleet.Run((*BMC).SecureBootDisable)
+}
+
+// WaitForSystemPowerState waits up to timeout until every host of the fleet returns the provided power state.
+func (fleet *Fleet) WaitForSystemPowerState(powerState redfish.PowerState, timeout time.Duration) (FleetResults, error) {
+	return fleet.Run(func(bmc *BMC) error {
+		return bmc.WaitForSystemPowerState(powerState, timeout)
+	})
+}
+
+// validate checks that the fleet is in a valid state with no error message.
+func (fleet *Fleet) validate() (bool, error) {
+	if fleet == nil {
+		klog.V(100).Info("The fleet is nil")
+
+		return false, fmt.Errorf("error: received nil fleet")
+	}
+
+	if fleet.errorMsg != "" {
+		klog.V(100).Infof("The fleet has an error message: %s", fleet.errorMsg)
+
+		return false, fmt.Errorf("%s", fleet.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,118 @@
+package fleet
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmh"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/secret"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// bmhCredentialsUsernameKey and bmhCredentialsPasswordKey are the keys of the BMC credentials in the secret
+	// referenced by a BareMetalHost.
+	bmhCredentialsUsernameKey = "username"
+	bmhCredentialsPasswordKey = "password"
+)
+
+// bmhRedfishSchemes are the BareMetalHost BMC address schemes that access the BMC through Redfish.
+var bmhRedfishSchemes = []string{
+	"redfish",
+	"redfish-virtualmedia",
+	"redfish-uefihttp",
+	"idrac-redfish",
+	"idrac-virtualmedia",
+	"ilo5-redfish",
+	"ilo5-virtualmedia",
+}
+
+// NewFromBareMetalHosts returns a BMC fleet with a host for each of the provided BareMetalHosts, named after their
+// namespace and name. The BMC address of each BareMetalHost must use a Redfish scheme, and its credentials are read
+// from the secret it references. If the address has a system path, such as /redfish/v1/Systems/1, that system is used
+// for every operation. The BMCs are not contacted until an operation runs.
+func NewFromBareMetalHosts(apiClient *clients.Settings, bareMetalHosts ...*bmh.BmhBuilder) (*bmc.Fleet, error) {
+	klog.V(100).Infof("Creating new BMC fleet from %d BareMetalHosts", len(bareMetalHosts))
+
+	if apiClient == nil {
+		klog.V(100).Info("The apiClient of the fleet is nil")
+
+		return nil, fmt.Errorf("fleet 'apiClient' cannot be nil")
+	}
+
+	bmcFleet := bmc.NewFleet()
+
+	for _, bareMetalHost := range bareMetalHosts {
+		if bareMetalHost == nil || bareMetalHost.Definition == nil {
+			klog.V(100).Info("The BareMetalHost of the fleet is nil")
+
+			return nil, fmt.Errorf("fleet 'bareMetalHost' cannot be nil")
+		}
+
+		name := bareMetalHost.Definition.Namespace + "/" + bareMetalHost.Definition.Name
+
+		if bmcFleet.BMC(name) != nil {
+			klog.V(100).Infof("The fleet already has a host named %s", name)
+
+			return nil, fmt.Errorf("fleet already has a host named %s", name)
+		}
+
+		host, systemPath, err := bmhRedfishAddress(bareMetalHost.Definition.Spec.BMC.Address)
+		if err != nil {
+			klog.V(100).Infof("Failed to get the Redfish host of BareMetalHost %s: %v", name, err)
+
+			return nil, fmt.Errorf("failed to get the redfish host of baremetalhost %s: %w", name, err)
+		}
+
+		credentials, err := secret.Pull(
+			apiClient, bareMetalHost.Definition.Spec.BMC.CredentialsName, bareMetalHost.Definition.Namespace)
+		if err != nil {
+			klog.V(100).Infof("Failed to get the BMC credentials of BareMetalHost %s: %v", name, err)
+
+			return nil, fmt.Errorf("failed to get the bmc credentials of baremetalhost %s: %w", name, err)
+		}
+
+		username := string(credentials.Object.Data[bmhCredentialsUsernameKey])
+		password := string(credentials.Object.Data[bmhCredentialsPasswordKey])
+
+		hostBMC := bmc.New(host).WithRedfishUser(username, password)
+
+		if systemPath != "" {
+			hostBMC.WithRedfishSystemPath(systemPath)
+		}
+
+		bmcFleet.WithBMC(name, hostBMC)
+	}
+
+	return bmcFleet, nil
+}
+
+// bmhRedfishAddress returns the host and the system path of a BareMetalHost BMC address using a Redfish scheme, such
+// as redfish-virtualmedia+https://10.1.1.1/redfish/v1/Systems/1. The system path is empty if the address does not
+// name a system.
+func bmhRedfishAddress(address string) (string, string, error) {
+	parsedAddress, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid bmc address %q: %w", address, err)
+	}
+
+	scheme, _, _ := strings.Cut(parsedAddress.Scheme, "+")
+	if !slices.Contains(bmhRedfishSchemes, scheme) {
+		return "", "", fmt.Errorf("bmc address %q does not use a redfish scheme", address)
+	}
+
+	if parsedAddress.Host == "" {
+		return "", "", fmt.Errorf("bmc address %q has no host", address)
+	}
+
+	systemPath := strings.TrimSuffix(parsedAddress.Path, "/")
+	if !strings.Contains(systemPath, "/Systems/") {
+		return parsedAddress.Host, "", nil
+	}
+
+	return parsedAddress.Host, systemPath, nil
+}
@@ -0,0 +1,119 @@
+package fleet
+
+import (
+	"testing"
+
+	bmhv1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc/redfishemulator"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmh"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNewFromBareMetalHosts(t *testing.T) {
+	emulator := redfishemulator.New(redfishemulator.Quirks{})
+	defer emulator.Close()
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0-bmc-secret", Namespace: "test-ns"},
+			Data: map[string][]byte{
+				"username": []byte(redfishemulator.DefaultUsername),
+				"password": []byte(redfishemulator.DefaultPassword),
+			},
+		}},
+		SchemeAttachers: []clients.SchemeAttacher{bmhv1alpha1.AddToScheme},
+	})
+
+	testCases := []struct {
+		bmcAddress           string
+		bmcSecretName        string
+		unreachable          bool
+		expectedErrorText    string
+		expectedRunErrorText string
+	}{
+		{
+			bmcAddress:    "redfish-virtualmedia+https://" + emulator.Host() + "/redfish/v1/Systems/1",
+			bmcSecretName: "worker-0-bmc-secret",
+		},
+		{
+			bmcAddress:    "redfish://" + emulator.Host() + "/redfish/v1/",
+			bmcSecretName: "worker-0-bmc-secret",
+		},
+		{
+			bmcAddress:           "idrac-virtualmedia://" + emulator.Host() + "/redfish/v1/Systems/System.Embedded.1",
+			bmcSecretName:        "worker-0-bmc-secret",
+			expectedRunErrorText: "failed to get system /redfish/v1/Systems/System.Embedded.1",
+		},
+		{
+			bmcAddress:    "redfish://10.255.255.1/redfish/v1/Systems/1",
+			bmcSecretName: "worker-0-bmc-secret",
+			unreachable:   true,
+		},
+		{
+			bmcAddress:        "ipmi://10.0.0.1:623",
+			bmcSecretName:     "worker-0-bmc-secret",
+			expectedErrorText: "bmc address \"ipmi://10.0.0.1:623\" does not use a redfish scheme",
+		},
+		{
+			bmcAddress:        "redfish:///redfish/v1/Systems/1",
+			bmcSecretName:     "worker-0-bmc-secret",
+			expectedErrorText: "bmc address \"redfish:///redfish/v1/Systems/1\" has no host",
+		},
+		{
+			bmcAddress:        "redfish://" + emulator.Host() + "/redfish/v1/Systems/1",
+			bmcSecretName:     "missing-secret",
+			expectedErrorText: "secret object missing-secret does not exist in namespace test-ns",
+		},
+	}
+
+	for _, testCase := range testCases {
+		bareMetalHost := bmh.NewBuilder(
+			testSettings, "worker-0", "test-ns", testCase.bmcAddress, testCase.bmcSecretName, "aa:bb:cc:dd:ee:ff", "UEFI")
+
+		bmcFleet, err := NewFromBareMetalHosts(testSettings, bareMetalHost)
+		if testCase.expectedErrorText != "" {
+			assert.ErrorContains(t, err, "baremetalhost test-ns/worker-0: ")
+			assert.ErrorContains(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"test-ns/worker-0"}, bmcFleet.Hosts())
+
+		if testCase.unreachable {
+			continue
+		}
+
+		results, err := bmcFleet.SystemPowerOff()
+		assert.Len(t, results, 1)
+
+		if testCase.expectedRunErrorText != "" {
+			assert.ErrorContains(t, err, testCase.expectedRunErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, redfish.OffPowerState, emulator.PowerState())
+
+		emulator.SetPowerState(redfish.OnPowerState)
+	}
+
+	_, err := NewFromBareMetalHosts(nil)
+	assert.EqualError(t, err, "fleet 'apiClient' cannot be nil")
+
+	_, err = NewFromBareMetalHosts(testSettings, nil)
+	assert.EqualError(t, err, "fleet 'bareMetalHost' cannot be nil")
+
+	bareMetalHost := bmh.NewBuilder(testSettings, "worker-0", "test-ns",
+		"redfish://"+emulator.Host(), "worker-0-bmc-secret", "aa:bb:cc:dd:ee:ff", "UEFI")
+
+	_, err = NewFromBareMetalHosts(testSettings, bareMetalHost, bareMetalHost)
+	assert.EqualError(t, err, "fleet already has a host named test-ns/worker-0")
+}
@@ -0,0 +1,145 @@
+package bmc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc/redfishemulator"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFleetPowerOff(t *testing.T) {
+	var emulators []*redfishemulator.Emulator
+
+	fleet := NewFleet().WithParallelism(2)
+
+	for index := range 3 {
+		emulator := redfishemulator.New(redfishemulator.Quirks{})
+		defer emulator.Close()
+
+		emulators = append(emulators, emulator)
+		fleet.WithBMC(
+			fmt.Sprintf("host-%d", index),
+			New(emulator.Host()).WithRedfishUser(redfishemulator.DefaultUsername, redfishemulator.DefaultPassword))
+	}
+
+	emulators[1].InjectFailure(redfishemulator.Failure{
+		Method:     http.MethodPost,
+		Path:       "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "The BMC is busy.",
+	})
+
+	results, err := fleet.SystemPowerOff()
+	assert.ErrorContains(t, err, "host-1: ")
+	assert.ErrorContains(t, err, "The BMC is busy.")
+	assert.NotContains(t, err.Error(), "host-0")
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, []string{"host-0", "host-1", "host-2"}, []string{results[0].Name, results[1].Name, results[2].Name})
+	assert.Equal(t, FleetResults{results[1]}, results.Failed())
+	assert.Contains(t, results.String(), "HOST    RESULT")
+	assert.Contains(t, results.String(), "host-1  FAILED: ")
+
+	assert.Equal(t, redfish.OffPowerState, emulators[0].PowerState())
+	assert.Equal(t, redfish.OnPowerState, emulators[1].PowerState())
+	assert.Equal(t, redfish.OffPowerState, emulators[2].PowerState())
+
+	emulators[1].ClearFailures()
+
+	results, err = fleet.SystemPowerOff()
+	assert.Nil(t, err)
+	assert.Empty(t, results.Failed())
+
+	results, err = fleet.WaitForSystemPowerState(redfish.OffPowerState, time.Second)
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+}
+
+func TestFleetRunParallelism(t *testing.T) {
+	fleet := NewFleet().WithParallelism(2)
+
+	for index := range 5 {
+		fleet.WithBMC(fmt.Sprintf("host-%d", index), New(fmt.Sprintf("10.0.0.%d", index)))
+	}
+
+	var (
+		mutex         sync.Mutex
+		running       int
+		maxRunning    int
+		operatedHosts []string
+	)
+
+	results, err := fleet.Run(func(bmc *BMC) error {
+		mutex.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		operatedHosts = append(operatedHosts, bmc.host)
+		mutex.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+
+		if bmc.host == "10.0.0.3" {
+			return errors.New("unreachable")
+		}
+
+		return nil
+	})
+
+	assert.EqualError(t, err, "host-3: unreachable")
+	assert.Len(t, results, 5)
+	assert.Len(t, operatedHosts, 5)
+	assert.Equal(t, 2, maxRunning)
+	assert.GreaterOrEqual(t, results[0].Duration, 50*time.Millisecond)
+}
+
+func TestFleetValidation(t *testing.T) {
+	testCases := []struct {
+		fleet             *Fleet
+		expectedErrorText string
+	}{
+		{
+			fleet:             NewFleet().WithBMC("", New("10.0.0.1")),
+			expectedErrorText: "fleet host 'name' cannot be empty",
+		},
+		{
+			fleet:             NewFleet().WithBMC("host-0", nil),
+			expectedErrorText: "fleet host host-0 'bmc' cannot be nil",
+		},
+		{
+			fleet:             NewFleet().WithBMC("host-0", New("10.0.0.1")).WithBMC("host-0", New("10.0.0.2")),
+			expectedErrorText: "fleet already has a host named host-0",
+		},
+		{
+			fleet:             NewFleet().WithParallelism(0),
+			expectedErrorText: "fleet 'parallelism' must be positive",
+		},
+		{
+			fleet:             nil,
+			expectedErrorText: "error: received nil fleet",
+		},
+	}
+
+	for _, testCase := range testCases {
+		results, err := testCase.fleet.SystemPowerOn()
+		assert.Nil(t, results)
+		assert.EqualError(t, err, testCase.expectedErrorText)
+	}
+
+	fleet := NewFleet().WithBMC("host-0", New("10.0.0.1"))
+	assert.Equal(t, []string{"host-0"}, fleet.Hosts())
+	assert.Equal(t, "10.0.0.1", fleet.BMC("host-0").host)
+	assert.Nil(t, fleet.BMC("host-1"))
+
+	_, err := fleet.Run(nil)
+	assert.EqualError(t, err, "fleet 'operation' cannot be nil")
+}
@@ -0,0 +1,350 @@
+package bmc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// NetworkPort holds the inventory of a physical port of a network adapter.
+type NetworkPort struct {
+	// ID is the Redfish ID of the port.
+	ID string
+	// PortID is the label of the port on the adapter, such as 1.
+	PortID string
+	// MACAddresses are the MAC addresses associated with the port, in lower case.
+	MACAddresses []string
+	// LinkStatus is the link status as reported by the BMC, such as LinkUp or Up.
+	LinkStatus string
+	// LinkUp is true if the link of the port is up.
+	LinkUp bool
+	// CurrentSpeedMbps is the current speed of the link in Mbps.
+	CurrentSpeedMbps int
+	// MaxVirtualFunctions is the number of SR-IOV virtual functions the port supports, or 0 if unknown.
+	MaxVirtualFunctions int
+	// VirtualFunctionsEnabled is true if SR-IOV virtual functions are enabled on the port.
+	VirtualFunctionsEnabled bool
+}
+
+// NetworkAdapter holds the inventory of a network adapter of the system.
+type NetworkAdapter struct {
+	// ID is the Redfish ID of the adapter.
+	ID string
+	// Manufacturer is the manufacturer of the adapter.
+	Manufacturer string
+	// Model is the model of the adapter.
+	Model string
+	// SerialNumber is the serial number of the adapter.
+	SerialNumber string
+	// PartNumber is the part number of the adapter.
+	PartNumber string
+	// FirmwareVersion is the firmware package version of the first controller of the adapter.
+	FirmwareVersion string
+	// VendorID is the PCI vendor ID of the adapter, such as 0x8086, if the BMC links it to its PCIe device.
+	VendorID string
+	// DeviceID is the PCI device ID of the adapter, such as 0x159B, if the BMC links it to its PCIe device.
+	DeviceID string
+	// SRIOVCapable is true if the adapter supports SR-IOV virtual functions.
+	SRIOVCapable bool
+	// MaxVirtualFunctions is the maximum number of SR-IOV virtual functions of the adapter, or 0 if unknown.
+	MaxVirtualFunctions int
+	// Health is the health of the adapter.
+	Health common.Health
+	// Ports are the physical ports of the adapter.
+	Ports []NetworkPort
+}
+
+// NetworkAdapters returns the network adapters of the system along with their ports using the Redfish API, sorted by
+// ID. Adapters are found through the system's network interfaces, falling back to the adapters of every chassis if the
+// system does not list them.
+func (bmc *BMC) NetworkAdapters() ([]NetworkAdapter, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting network adapters from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	redfishAdapters, err := redfishGetNetworkAdapters(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish network adapters: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish network adapters: %w", err)
+	}
+
+	var adapters []NetworkAdapter
+
+	for _, redfishAdapter := range redfishAdapters {
+		adapter, err := newNetworkAdapter(redfishAdapter)
+		if err != nil {
+			klog.V(100).Infof("Failed to get inventory of network adapter %s: %v", redfishAdapter.ID, err)
+
+			return nil, fmt.Errorf("failed to get inventory of network adapter %s: %w", redfishAdapter.ID, err)
+		}
+
+		adapters = append(adapters, adapter)
+	}
+
+	return adapters, nil
+}
+
+// redfishGetNetworkAdapters returns the network adapters linked from the network interfaces of the system or, if it
+// has none, the network adapters of every chassis.
+func redfishGetNetworkAdapters(redfishClient *gofish.APIClient, selector systemSelector) ([]*redfish.NetworkAdapter, error) {
+	system, err := redfishGetSystem(redfishClient, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	networkInterfaces, err := system.NetworkInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
+	}
+
+	var adapters []*redfish.NetworkAdapter
+
+	for _, networkInterface := range networkInterfaces {
+		adapter, err := networkInterface.NetworkAdapter()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network adapter of network interface %s: %w", networkInterface.ID, err)
+		}
+
+		if adapter != nil {
+			adapters = append(adapters, adapter)
+		}
+	}
+
+	if len(adapters) == 0 {
+		adapters, err = redfishGetChassisNetworkAdapters(redfishClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(adapters, func(a, b *redfish.NetworkAdapter) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return adapters, nil
+}
+
+// redfishGetChassisNetworkAdapters returns the network adapters of every chassis.
+func redfishGetChassisNetworkAdapters(redfishClient *gofish.APIClient) ([]*redfish.NetworkAdapter, error) {
+	var adapters []*redfish.NetworkAdapter
+
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
+
+	for _, chassis := range chassisCollection {
+		chassisAdapters, err := chassis.NetworkAdapters()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network adapters of chassis %s: %w", chassis.ID, err)
+		}
+
+		adapters = append(adapters, chassisAdapters...)
+	}
+
+	return adapters, nil
+}
+
+// newNetworkAdapter converts the Redfish network adapter, getting its ports and device functions. The PCI vendor and
+// device IDs come from the PCIe function of the first device function that links one.
+func newNetworkAdapter(redfishAdapter *redfish.NetworkAdapter) (NetworkAdapter, error) {
+	adapter := NetworkAdapter{
+		ID:           redfishAdapter.ID,
+		Manufacturer: redfishAdapter.Manufacturer,
+		Model:        redfishAdapter.Model,
+		SerialNumber: redfishAdapter.SerialNumber,
+		PartNumber:   redfishAdapter.PartNumber,
+		Health:       redfishAdapter.Status.Health,
+	}
+
+	for _, controller := range redfishAdapter.Controllers {
+		virtualizationOffload := controller.ControllerCapabilities.VirtualizationOffload
+		adapter.MaxVirtualFunctions = max(adapter.MaxVirtualFunctions, virtualizationOffload.VirtualFunction.DeviceMaxCount)
+		adapter.SRIOVCapable = adapter.SRIOVCapable || virtualizationOffload.SRIOV.SRIOVVEPACapable ||
+			virtualizationOffload.VirtualFunction.DeviceMaxCount > 0
+
+		if adapter.FirmwareVersion == "" {
+			adapter.FirmwareVersion = controller.FirmwarePackageVersion
+		}
+	}
+
+	ports, err := redfishGetNetworkAdapterPorts(redfishAdapter)
+	if err != nil {
+		return NetworkAdapter{}, err
+	}
+
+	deviceFunctions, err := redfishAdapter.NetworkDeviceFunctions()
+	if err != nil {
+		return NetworkAdapter{}, fmt.Errorf("failed to get network device functions: %w", err)
+	}
+
+	for _, deviceFunction := range deviceFunctions {
+		portIndex, err := networkDeviceFunctionPortIndex(deviceFunction, ports)
+		if err != nil {
+			return NetworkAdapter{}, err
+		}
+
+		if deviceFunction.MaxVirtualFunctions > 0 {
+			adapter.SRIOVCapable = true
+		}
+
+		if adapter.VendorID == "" {
+			pcieFunction, err := deviceFunction.PCIeFunction()
+			if err != nil {
+				return NetworkAdapter{}, fmt.Errorf("failed to get pcie function of network device function %s: %w",
+					deviceFunction.ID, err)
+			}
+
+			if pcieFunction != nil {
+				adapter.VendorID = pcieFunction.VendorID
+				adapter.DeviceID = pcieFunction.DeviceID
+			}
+		}
+
+		if portIndex < 0 {
+			continue
+		}
+
+		port := &ports[portIndex]
+		port.MaxVirtualFunctions = max(port.MaxVirtualFunctions, deviceFunction.MaxVirtualFunctions)
+		port.VirtualFunctionsEnabled = port.VirtualFunctionsEnabled || deviceFunction.VirtualFunctionsEnabled
+
+		for _, mac := range []string{deviceFunction.Ethernet.PermanentMACAddress, deviceFunction.Ethernet.MACAddress} {
+			port.MACAddresses = appendMACAddress(port.MACAddresses, mac)
+		}
+	}
+
+	adapter.Ports = ports
+
+	return adapter, nil
+}
+
+// redfishGetNetworkAdapterPorts returns the ports of the adapter sorted by ID, using the deprecated network ports if
+// the adapter has no ports.
+func redfishGetNetworkAdapterPorts(redfishAdapter *redfish.NetworkAdapter) ([]NetworkPort, error) {
+	redfishPorts, err := redfishAdapter.Ports()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ports: %w", err)
+	}
+
+	var ports []NetworkPort
+
+	for _, redfishPort := range redfishPorts {
+		port := NetworkPort{
+			ID:               redfishPort.ID,
+			PortID:           redfishPort.PortID,
+			LinkStatus:       string(redfishPort.LinkStatus),
+			LinkUp:           redfishPort.LinkStatus == redfish.LinkUpPortLinkStatus,
+			CurrentSpeedMbps: int(redfishPort.CurrentSpeedGbps * 1000),
+		}
+
+		for _, mac := range redfishPort.Ethernet.AssociatedMACAddresses {
+			port.MACAddresses = appendMACAddress(port.MACAddresses, mac)
+		}
+
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 0 {
+		ports, err = redfishGetNetworkAdapterNetworkPorts(redfishAdapter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(ports, func(a, b NetworkPort) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return ports, nil
+}
+
+// redfishGetNetworkAdapterNetworkPorts returns the deprecated network ports of the adapter.
+func redfishGetNetworkAdapterNetworkPorts(redfishAdapter *redfish.NetworkAdapter) ([]NetworkPort, error) {
+	var ports []NetworkPort
+
+	redfishNetworkPorts, err := redfishAdapter.NetworkPorts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network ports: %w", err)
+	}
+
+	for _, redfishNetworkPort := range redfishNetworkPorts {
+		port := NetworkPort{
+			ID:               redfishNetworkPort.ID,
+			PortID:           redfishNetworkPort.PhysicalPortNumber,
+			LinkStatus:       string(redfishNetworkPort.LinkStatus),
+			LinkUp:           redfishNetworkPort.LinkStatus == redfish.UpPortLinkStatus,
+			CurrentSpeedMbps: redfishNetworkPort.CurrentLinkSpeedMbps,
+		}
+
+		for _, mac := range redfishNetworkPort.AssociatedNetworkAddresses {
+			port.MACAddresses = appendMACAddress(port.MACAddresses, mac)
+		}
+
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
+
+// networkDeviceFunctionPortIndex returns the index of the port assigned to the device function, or -1 if it has no
+// assigned port among ports.
+func networkDeviceFunctionPortIndex(deviceFunction *redfish.NetworkDeviceFunction, ports []NetworkPort) (int, error) {
+	var portID string
+
+	port, err := deviceFunction.PhysicalNetworkPortAssignment()
+	if err != nil {
+		return -1, fmt.Errorf("failed to get port of network device function %s: %w", deviceFunction.ID, err)
+	}
+
+	if port != nil {
+		portID = port.ID
+	} else {
+		networkPort, err := deviceFunction.PhysicalPortAssignment()
+		if err != nil {
+			return -1, fmt.Errorf("failed to get network port of network device function %s: %w", deviceFunction.ID, err)
+		}
+
+		if networkPort == nil {
+			return -1, nil
+		}
+
+		portID = networkPort.ID
+	}
+
+	return slices.IndexFunc(ports, func(port NetworkPort) bool { return port.ID == portID }), nil
+}
+
+// appendMACAddress appends the MAC address in lower case unless it is empty, all zeros or already in macAddresses.
+func appendMACAddress(macAddresses []string, mac string) []string {
+	mac = strings.ToLower(mac)
+	if mac == "" || mac == "00:00:00:00:00:00" || slices.Contains(macAddresses, mac) {
+		return macAddresses
+	}
+
+	return append(macAddresses, mac)
+}
@@ -0,0 +1,247 @@
+package bmc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeRedfishSystemPath  = "/redfish/v1/Systems/System.Embedded.1"
+	fakeRedfishAdapterPath = "/redfish/v1/Chassis/System.Embedded.1/NetworkAdapters/NIC.Slot.1"
+	fakeRedfishOnboardPath = "/redfish/v1/Chassis/System.Embedded.1/NetworkAdapters/NIC.Integrated.1"
+	fakeRedfishPCIePath    = fakeRedfishSystemPath + "/PCIeDevices/59-0"
+)
+
+func TestBMCNetworkAdapters(t *testing.T) {
+	testCases := []struct {
+		withNetworkInterfaces bool
+		expectedAdapterIDs    []string
+	}{
+		{
+			withNetworkInterfaces: true,
+			expectedAdapterIDs:    []string{"NIC.Slot.1"},
+		},
+		{
+			withNetworkInterfaces: false,
+			expectedAdapterIDs:    []string{"NIC.Integrated.1", "NIC.Slot.1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer := createFakeRedfishNetworkServer(t, testCase.withNetworkInterfaces)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		adapters, err := bmc.NetworkAdapters()
+
+		redfishServer.Close()
+
+		assert.Nil(t, err)
+
+		var adapterIDs []string
+
+		for _, adapter := range adapters {
+			adapterIDs = append(adapterIDs, adapter.ID)
+		}
+
+		assert.Equal(t, testCase.expectedAdapterIDs, adapterIDs)
+
+		if len(adapters) != len(testCase.expectedAdapterIDs) {
+			continue
+		}
+
+		assert.Equal(t, NetworkAdapter{
+			ID:                  "NIC.Slot.1",
+			Manufacturer:        "Intel Corporation",
+			Model:               "Intel(R) Ethernet 25G 2P E810-XXV Adapter",
+			SerialNumber:        "PHAU12345678",
+			FirmwareVersion:     "22.5.7",
+			VendorID:            "0x8086",
+			DeviceID:            "0x159B",
+			SRIOVCapable:        true,
+			MaxVirtualFunctions: 256,
+			Health:              common.OKHealth,
+			Ports: []NetworkPort{
+				{
+					ID:               "NIC.Slot.1-1",
+					PortID:           "NIC.Slot.1-1",
+					MACAddresses:     []string{"b4:96:91:aa:bb:01"},
+					LinkStatus:       "LinkUp",
+					LinkUp:           true,
+					CurrentSpeedMbps: 25000,
+				},
+				{
+					ID:                      "NIC.Slot.1-2",
+					PortID:                  "NIC.Slot.1-2",
+					MACAddresses:            []string{"b4:96:91:aa:bb:02"},
+					LinkStatus:              "LinkDown",
+					MaxVirtualFunctions:     128,
+					VirtualFunctionsEnabled: true,
+				},
+			},
+		}, adapters[len(adapters)-1])
+
+		if !testCase.withNetworkInterfaces {
+			assert.Equal(t, []NetworkPort{{
+				ID:               "1",
+				PortID:           "1",
+				MACAddresses:     []string{"f4:02:70:00:00:01"},
+				LinkStatus:       "Up",
+				LinkUp:           true,
+				CurrentSpeedMbps: 1000,
+			}}, adapters[0].Ports)
+			assert.False(t, adapters[0].SRIOVCapable)
+		}
+	}
+}
+
+// createFakeRedfishNetworkServer creates a fake Redfish server whose system has an SR-IOV capable adapter with two
+// ports and an onboard adapter using the deprecated network ports. The onboard adapter is only listed by the chassis,
+// while the SR-IOV adapter is also linked from the system network interfaces if withNetworkInterfaces is true.
+func createFakeRedfishNetworkServer(t *testing.T, withNetworkInterfaces bool) *httptest.Server {
+	t.Helper()
+
+	resources := map[string]any{
+		fakeRedfishSystemPath + "/NetworkInterfaces": fakeRedfishCollection(fakeRedfishSystemPath + "/NetworkInterfaces/NIC.Slot.1"),
+		fakeRedfishSystemPath + "/NetworkInterfaces/NIC.Slot.1": map[string]any{
+			"@odata.id": fakeRedfishSystemPath + "/NetworkInterfaces/NIC.Slot.1",
+			"Id":        "NIC.Slot.1",
+			"Links":     map[string]any{"NetworkAdapter": map[string]any{"@odata.id": fakeRedfishAdapterPath}},
+		},
+		"/redfish/v1/Chassis/System.Embedded.1/NetworkAdapters": fakeRedfishCollection(
+			fakeRedfishAdapterPath, fakeRedfishOnboardPath),
+		fakeRedfishAdapterPath: map[string]any{
+			"@odata.id":    fakeRedfishAdapterPath,
+			"Id":           "NIC.Slot.1",
+			"Manufacturer": "Intel Corporation",
+			"Model":        "Intel(R) Ethernet 25G 2P E810-XXV Adapter",
+			"SerialNumber": "PHAU12345678",
+			"Status":       map[string]any{"Health": "OK", "State": "Enabled"},
+			"Controllers": []any{map[string]any{
+				"FirmwarePackageVersion": "22.5.7",
+				"ControllerCapabilities": map[string]any{
+					"VirtualizationOffload": map[string]any{
+						"SRIOV":           map[string]any{"SRIOVVEPACapable": true},
+						"VirtualFunction": map[string]any{"DeviceMaxCount": 256},
+					},
+				},
+			}},
+			"Ports":                  map[string]any{"@odata.id": fakeRedfishAdapterPath + "/Ports"},
+			"NetworkDeviceFunctions": map[string]any{"@odata.id": fakeRedfishAdapterPath + "/NetworkDeviceFunctions"},
+		},
+		fakeRedfishAdapterPath + "/Ports": fakeRedfishCollection(
+			fakeRedfishAdapterPath+"/Ports/NIC.Slot.1-1", fakeRedfishAdapterPath+"/Ports/NIC.Slot.1-2"),
+		fakeRedfishAdapterPath + "/Ports/NIC.Slot.1-1": map[string]any{
+			"@odata.id":        fakeRedfishAdapterPath + "/Ports/NIC.Slot.1-1",
+			"Id":               "NIC.Slot.1-1",
+			"PortId":           "NIC.Slot.1-1",
+			"LinkStatus":       "LinkUp",
+			"CurrentSpeedGbps": 25,
+			"Ethernet":         map[string]any{"AssociatedMACAddresses": []string{"B4:96:91:AA:BB:01"}},
+		},
+		fakeRedfishAdapterPath + "/Ports/NIC.Slot.1-2": map[string]any{
+			"@odata.id":  fakeRedfishAdapterPath + "/Ports/NIC.Slot.1-2",
+			"Id":         "NIC.Slot.1-2",
+			"PortId":     "NIC.Slot.1-2",
+			"LinkStatus": "LinkDown",
+		},
+		fakeRedfishAdapterPath + "/NetworkDeviceFunctions": fakeRedfishCollection(
+			fakeRedfishAdapterPath + "/NetworkDeviceFunctions/NIC.Slot.1-2-1"),
+		fakeRedfishAdapterPath + "/NetworkDeviceFunctions/NIC.Slot.1-2-1": map[string]any{
+			"@odata.id":               fakeRedfishAdapterPath + "/NetworkDeviceFunctions/NIC.Slot.1-2-1",
+			"Id":                      "NIC.Slot.1-2-1",
+			"MaxVirtualFunctions":     128,
+			"VirtualFunctionsEnabled": true,
+			"Ethernet": map[string]any{
+				"MACAddress":          "B4:96:91:AA:BB:02",
+				"PermanentMACAddress": "B4:96:91:AA:BB:02",
+			},
+			"Links": map[string]any{
+				"PhysicalNetworkPortAssignment": map[string]any{"@odata.id": fakeRedfishAdapterPath + "/Ports/NIC.Slot.1-2"},
+				"PCIeFunction":                  map[string]any{"@odata.id": fakeRedfishPCIePath + "/PCIeFunctions/59-0-0"},
+			},
+		},
+		fakeRedfishOnboardPath: map[string]any{
+			"@odata.id":    fakeRedfishOnboardPath,
+			"Id":           "NIC.Integrated.1",
+			"Manufacturer": "Broadcom Inc. and subsidiaries",
+			"Status":       map[string]any{"Health": "OK", "State": "Enabled"},
+			"NetworkPorts": map[string]any{"@odata.id": fakeRedfishOnboardPath + "/NetworkPorts"},
+		},
+		fakeRedfishOnboardPath + "/NetworkPorts": fakeRedfishCollection(fakeRedfishOnboardPath + "/NetworkPorts/1"),
+		fakeRedfishOnboardPath + "/NetworkPorts/1": map[string]any{
+			"@odata.id":                  fakeRedfishOnboardPath + "/NetworkPorts/1",
+			"Id":                         "1",
+			"PhysicalPortNumber":         "1",
+			"LinkStatus":                 "Up",
+			"CurrentLinkSpeedMbps":       1000,
+			"AssociatedNetworkAddresses": []string{"F4:02:70:00:00:01"},
+		},
+		fakeRedfishPCIePath: map[string]any{
+			"@odata.id":       fakeRedfishPCIePath,
+			"Id":              "59-0",
+			"Name":            "Ethernet Controller E810-XXV for SFP",
+			"Manufacturer":    "Intel Corporation",
+			"FirmwareVersion": "22.5.7",
+			"DeviceType":      "MultiFunction",
+			"PCIeInterface":   map[string]any{"PCIeType": "Gen4", "LanesInUse": 8},
+			"Status":          map[string]any{"Health": "OK", "State": "Enabled"},
+			"Links": map[string]any{
+				"PCIeFunctions":             []any{map[string]any{"@odata.id": fakeRedfishPCIePath + "/PCIeFunctions/59-0-0"}},
+				"PCIeFunctions@odata.count": 1,
+			},
+		},
+		fakeRedfishPCIePath + "/PCIeFunctions/59-0-0": map[string]any{
+			"@odata.id":         fakeRedfishPCIePath + "/PCIeFunctions/59-0-0",
+			"Id":                "59-0-0",
+			"FunctionId":        0,
+			"FunctionType":      "Physical",
+			"DeviceClass":       "NetworkController",
+			"VendorId":          "0x8086",
+			"DeviceId":          "0x159B",
+			"SubsystemVendorId": "0x8086",
+			"SubsystemId":       "0x0003",
+		},
+	}
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+	mux.HandleFunc("GET "+fakeRedfishSystemPath, func(w http.ResponseWriter, r *http.Request) {
+		var system map[string]any
+
+		err := json.Unmarshal([]byte(redfishSystemJSONResponse), &system)
+		assert.Nil(t, err)
+
+		system["PCIeDevices"] = []any{map[string]any{"@odata.id": fakeRedfishPCIePath}}
+
+		if !withNetworkInterfaces {
+			delete(system, "NetworkInterfaces")
+		}
+
+		writeFakeRedfishJSON(t, w, system)
+	})
+
+	for path, resource := range resources {
+		mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			writeFakeRedfishJSON(t, w, resource)
+		})
+	}
+
+	return startFakeRedfishLocalServer(mux)
+}
+
+// fakeRedfishCollection returns a Redfish collection with the provided members.
+func fakeRedfishCollection(memberPaths ...string) map[string]any {
+	var members []any
+
+	for _, memberPath := range memberPaths {
+		members = append(members, map[string]any{"@odata.id": memberPath})
+	}
+
+	return map[string]any{"Members": members, "Members@odata.count": len(members)}
+}
@@ -0,0 +1,157 @@
+package bmc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// PCIeFunction holds the identifiers of a function of a PCIe device.
+type PCIeFunction struct {
+	// ID is the Redfish ID of the function.
+	ID string
+	// FunctionID is the PCIe function number.
+	FunctionID int
+	// FunctionType is Physical or Virtual.
+	FunctionType redfish.FunctionType
+	// DeviceClass is the class of the function, such as NetworkController.
+	DeviceClass redfish.DeviceClass
+	// VendorID is the PCI vendor ID, such as 0x8086.
+	VendorID string
+	// DeviceID is the PCI device ID, such as 0x159B.
+	DeviceID string
+	// SubsystemVendorID is the PCI subsystem vendor ID.
+	SubsystemVendorID string
+	// SubsystemID is the PCI subsystem ID.
+	SubsystemID string
+}
+
+// PCIeDevice holds the inventory of a PCIe device of the system.
+type PCIeDevice struct {
+	// ID is the Redfish ID of the device.
+	ID string
+	// Name is the name of the device.
+	Name string
+	// Manufacturer is the manufacturer of the device.
+	Manufacturer string
+	// Model is the model of the device.
+	Model string
+	// FirmwareVersion is the version of the firmware of the device.
+	FirmwareVersion string
+	// DeviceType is SingleFunction, MultiFunction or Simulated.
+	DeviceType redfish.DeviceType
+	// PCIeType is the PCIe generation the device is currently running at.
+	PCIeType redfish.PCIeTypes
+	// LanesInUse is the number of PCIe lanes in use by the device.
+	LanesInUse int
+	// Health is the health of the device.
+	Health common.Health
+	// Functions are the PCIe functions of the device.
+	Functions []PCIeFunction
+}
+
+// PCIeDevices returns the PCIe devices of the system along with their functions using the Redfish API, sorted by ID
+// and function number respectively.
+func (bmc *BMC) PCIeDevices() ([]PCIeDevice, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting PCIe devices from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	redfishDevices, err := system.PCIeDevices()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system's PCIe devices: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system's pcie devices: %w", err)
+	}
+
+	slices.SortFunc(redfishDevices, func(a, b *redfish.PCIeDevice) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	var devices []PCIeDevice
+
+	for _, redfishDevice := range redfishDevices {
+		device, err := newPCIeDevice(redfishDevice)
+		if err != nil {
+			klog.V(100).Infof("Failed to get functions of PCIe device %s: %v", redfishDevice.ID, err)
+
+			return nil, fmt.Errorf("failed to get functions of pcie device %s: %w", redfishDevice.ID, err)
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// newPCIeDevice converts the Redfish PCIe device, getting its functions.
+func newPCIeDevice(redfishDevice *redfish.PCIeDevice) (PCIeDevice, error) {
+	device := PCIeDevice{
+		ID:              redfishDevice.ID,
+		Name:            redfishDevice.Name,
+		Manufacturer:    redfishDevice.Manufacturer,
+		Model:           redfishDevice.Model,
+		FirmwareVersion: redfishDevice.FirmwareVersion,
+		DeviceType:      redfishDevice.DeviceType,
+		PCIeType:        redfishDevice.PCIeInterface.PCIeType,
+		LanesInUse:      redfishDevice.PCIeInterface.LanesInUse,
+		Health:          redfishDevice.Status.Health,
+	}
+
+	redfishFunctions, err := redfishDevice.PCIeFunctions()
+	if err != nil {
+		return PCIeDevice{}, err
+	}
+
+	slices.SortFunc(redfishFunctions, func(a, b *redfish.PCIeFunction) int {
+		return a.FunctionID - b.FunctionID
+	})
+
+	for _, redfishFunction := range redfishFunctions {
+		device.Functions = append(device.Functions, newPCIeFunction(redfishFunction))
+	}
+
+	return device, nil
+}
+
+func newPCIeFunction(redfishFunction *redfish.PCIeFunction) PCIeFunction {
+	return PCIeFunction{
+		ID:                redfishFunction.ID,
+		FunctionID:        redfishFunction.FunctionID,
+		FunctionType:      redfishFunction.FunctionType,
+		DeviceClass:       redfishFunction.DeviceClass,
+		VendorID:          redfishFunction.VendorID,
+		DeviceID:          redfishFunction.DeviceID,
+		SubsystemVendorID: redfishFunction.SubsystemVendorID,
+		SubsystemID:       redfishFunction.SubsystemID,
+	}
+}
@@ -0,0 +1,43 @@
+package bmc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBMCPCIeDevices(t *testing.T) {
+	redfishServer := createFakeRedfishNetworkServer(t, true)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	devices, err := bmc.PCIeDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, []PCIeDevice{{
+		ID:              "59-0",
+		Name:            "Ethernet Controller E810-XXV for SFP",
+		Manufacturer:    "Intel Corporation",
+		FirmwareVersion: "22.5.7",
+		DeviceType:      redfish.MultiFunctionDeviceType,
+		PCIeType:        redfish.Gen4PCIeTypes,
+		LanesInUse:      8,
+		Health:          common.OKHealth,
+		Functions: []PCIeFunction{{
+			ID:                "59-0-0",
+			FunctionType:      redfish.PhysicalFunctionType,
+			DeviceClass:       redfish.NetworkControllerDeviceClass,
+			VendorID:          "0x8086",
+			DeviceID:          "0x159B",
+			SubsystemVendorID: "0x8086",
+			SubsystemID:       "0x0003",
+		}},
+	}}, devices)
+
+	_, err = New(host).PCIeDevices()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
@@ -0,0 +1,378 @@
+package redfishemulator
+
+import (
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const (
+	// DefaultUsername is the username accepted by the emulator unless changed with WithCredentials.
+	DefaultUsername = "admin"
+	// DefaultPassword is the password accepted by the emulator unless changed with WithCredentials.
+	DefaultPassword = "password"
+
+	// DefaultPowerConsumedWatts is the power consumption reported by the chassis while the system is on.
+	DefaultPowerConsumedWatts = 350
+)
+
+// Quirks holds the vendor specific behaviors of the emulated BMC. The zero value emulates a BMC that follows the
+// Redfish specification closely.
+type Quirks struct {
+	// Manufacturer is the manufacturer reported by the system. Defaults to "Redfish Emulator".
+	Manufacturer string
+	// ResetTypes are the reset types allowed by the system. Defaults to all the reset types the emulator supports.
+	ResetTypes []redfish.ResetType
+	// PowerTransitionDelay is how long the system reports PoweringOn or PoweringOff after a reset before reaching the
+	// final power state. Zero means power transitions are immediate.
+	PowerTransitionDelay time.Duration
+	// VirtualMediaUnderManager exposes the virtual media only under the manager instead of under the system.
+	VirtualMediaUnderManager bool
+	// VirtualMediaWithoutActions omits the InsertMedia and EjectMedia actions, so media can only be changed by
+	// patching the virtual media resource.
+	VirtualMediaWithoutActions bool
+	// BootOrderAppliedOnReset stages changes of the boot order until the next time the system boots, instead of
+	// applying them immediately.
+	BootOrderAppliedOnReset bool
+}
+
+// DellQuirks returns the quirks of Dell iDRAC BMCs.
+func DellQuirks() Quirks {
+	return Quirks{
+		Manufacturer: "Dell Inc.",
+		ResetTypes: []redfish.ResetType{
+			redfish.OnResetType,
+			redfish.ForceOffResetType,
+			redfish.ForceRestartResetType,
+			redfish.GracefulRestartResetType,
+			redfish.GracefulShutdownResetType,
+			redfish.PushPowerButtonResetType,
+			redfish.NmiResetType,
+			redfish.PowerCycleResetType,
+		},
+		BootOrderAppliedOnReset: true,
+	}
+}
+
+// HPEQuirks returns the quirks of HPE iLO BMCs.
+func HPEQuirks() Quirks {
+	return Quirks{
+		Manufacturer: "HPE",
+		ResetTypes: []redfish.ResetType{
+			redfish.OnResetType,
+			redfish.ForceOffResetType,
+			redfish.GracefulShutdownResetType,
+			redfish.ForceRestartResetType,
+			redfish.NmiResetType,
+			redfish.PushPowerButtonResetType,
+			redfish.GracefulRestartResetType,
+		},
+		VirtualMediaUnderManager: true,
+	}
+}
+
+// Failure describes an error the emulator returns instead of handling matching requests.
+type Failure struct {
+	// Method is the HTTP method of the requests to fail. Empty matches every method.
+	Method string
+	// Path is the path of the requests to fail, such as /redfish/v1/Systems/1.
+	Path string
+	// StatusCode is the HTTP status code of the error.
+	StatusCode int
+	// Message is the message of the Redfish error. Defaults to "Injected failure.".
+	Message string
+	// Count is the number of requests to fail. Zero fails every matching request until ClearFailures is called.
+	Count int
+}
+
+// Request holds a request received by the emulator.
+type Request struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// Path is the path of the request.
+	Path string
+}
+
+// VirtualMediaState holds the state of an emulated virtual media slot.
+type VirtualMediaState struct {
+	// Image is the URL of the inserted image.
+	Image string
+	// Inserted is true if media is inserted in the slot.
+	Inserted bool
+	// WriteProtected is true if the inserted media is read only.
+	WriteProtected bool
+}
+
+// Emulator is an in-process, stateful Redfish service emulating a BMC with a single system, manager and chassis. It
+// serves HTTPS on localhost, so a bmc.BMC can be pointed at Host. Power state, boot order, boot override, secure boot
+// and virtual media changes are kept, so flows spanning several requests can be tested.
+type Emulator struct {
+	server   *httptest.Server
+	quirks   Quirks
+	username string
+	password string
+
+	mutex    sync.Mutex
+	sessions map[string]string
+	nextID   int
+	failures []*Failure
+	requests []Request
+
+	powerState         redfish.PowerState
+	transitionDone     time.Time
+	resets             []redfish.ResetType
+	powerConsumedWatts float32
+	bootOrder          []string
+	pendingBootOrder   []string
+	overrideEnabled    redfish.BootSourceOverrideEnabled
+	overrideTarget     redfish.BootSourceOverrideTarget
+	secureBootEnabled  bool
+	virtualMedia       map[string]*VirtualMediaState
+}
+
+// New starts an emulator with the provided quirks. The system is initially on, boots from its disk and has a CD and
+// a USB stick virtual media slot, both empty. Close must be called to stop it.
+func New(quirks Quirks) *Emulator {
+	if quirks.Manufacturer == "" {
+		quirks.Manufacturer = "Redfish Emulator"
+	}
+
+	if len(quirks.ResetTypes) == 0 {
+		quirks.ResetTypes = []redfish.ResetType{
+			redfish.OnResetType,
+			redfish.ForceOffResetType,
+			redfish.GracefulShutdownResetType,
+			redfish.GracefulRestartResetType,
+			redfish.ForceRestartResetType,
+			redfish.NmiResetType,
+			redfish.PushPowerButtonResetType,
+			redfish.PowerCycleResetType,
+		}
+	}
+
+	emulator := &Emulator{
+		quirks:             quirks,
+		username:           DefaultUsername,
+		password:           DefaultPassword,
+		sessions:           make(map[string]string),
+		powerState:         redfish.OnPowerState,
+		powerConsumedWatts: DefaultPowerConsumedWatts,
+		bootOrder:          []string{"Boot0001", "Boot0000", "Boot0002"},
+		overrideEnabled:    redfish.DisabledBootSourceOverrideEnabled,
+		overrideTarget:     redfish.NoneBootSourceOverrideTarget,
+		virtualMedia: map[string]*VirtualMediaState{
+			"CD":  {},
+			"USB": {},
+		},
+	}
+
+	emulator.server = httptest.NewUnstartedServer(emulator.newHandler())
+	emulator.server.EnableHTTP2 = true
+	emulator.server.StartTLS()
+
+	return emulator
+}
+
+// WithCredentials changes the credentials accepted by the emulator. Existing sessions remain valid.
+func (emulator *Emulator) WithCredentials(username, password string) *Emulator {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	emulator.username = username
+	emulator.password = password
+
+	return emulator
+}
+
+// Host returns the host and port of the emulator, to be passed to bmc.New.
+func (emulator *Emulator) Host() string {
+	return strings.TrimPrefix(emulator.server.URL, "https://")
+}
+
+// URL returns the base URL of the emulator, such as https://127.0.0.1:12345.
+func (emulator *Emulator) URL() string {
+	return emulator.server.URL
+}
+
+// Close stops the emulator.
+func (emulator *Emulator) Close() {
+	emulator.server.Close()
+}
+
+// InjectFailure makes the emulator return an error for the requests matching failure. Failures are checked in the
+// order they were injected.
+func (emulator *Emulator) InjectFailure(failure Failure) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	if failure.Message == "" {
+		failure.Message = "Injected failure."
+	}
+
+	emulator.failures = append(emulator.failures, &failure)
+}
+
+// ClearFailures removes all the injected failures.
+func (emulator *Emulator) ClearFailures() {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	emulator.failures = nil
+}
+
+// Requests returns the requests received so far, oldest first.
+func (emulator *Emulator) Requests() []Request {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return slices.Clone(emulator.requests)
+}
+
+// PowerState returns the current power state of the system, including transitional states.
+func (emulator *Emulator) PowerState() redfish.PowerState {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return emulator.currentPowerState()
+}
+
+// SetPowerState sets the power state of the system immediately, without booting it.
+func (emulator *Emulator) SetPowerState(powerState redfish.PowerState) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	emulator.powerState = powerState
+	emulator.transitionDone = time.Time{}
+}
+
+// ResetHistory returns the reset types of the reset actions received so far, oldest first.
+func (emulator *Emulator) ResetHistory() []redfish.ResetType {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return slices.Clone(emulator.resets)
+}
+
+// SetPowerConsumedWatts sets the power consumption reported by the chassis while the system is on.
+func (emulator *Emulator) SetPowerConsumedWatts(watts float32) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	emulator.powerConsumedWatts = watts
+}
+
+// BootOrder returns the boot order the system currently uses.
+func (emulator *Emulator) BootOrder() []string {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return slices.Clone(emulator.bootOrder)
+}
+
+// PendingBootOrder returns the boot order staged until the next boot, or nil if there is none. Boot orders are only
+// staged with the BootOrderAppliedOnReset quirk.
+func (emulator *Emulator) PendingBootOrder() []string {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return slices.Clone(emulator.pendingBootOrder)
+}
+
+// BootSourceOverride returns the boot source override of the system. A Once override is disabled after the system
+// boots.
+func (emulator *Emulator) BootSourceOverride() (redfish.BootSourceOverrideEnabled, redfish.BootSourceOverrideTarget) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return emulator.overrideEnabled, emulator.overrideTarget
+}
+
+// SecureBootEnabled returns whether secure boot is enabled.
+func (emulator *Emulator) SecureBootEnabled() bool {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	return emulator.secureBootEnabled
+}
+
+// VirtualMedia returns the state of the virtual media slot with the provided ID, either CD or USB, or nil if there
+// is no such slot.
+func (emulator *Emulator) VirtualMedia(id string) *VirtualMediaState {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	media, found := emulator.virtualMedia[id]
+	if !found {
+		return nil
+	}
+
+	mediaCopy := *media
+
+	return &mediaCopy
+}
+
+// currentPowerState returns the power state of the system, finishing the transition in progress if its delay has
+// passed. It must be called with the mutex held.
+func (emulator *Emulator) currentPowerState() redfish.PowerState {
+	if emulator.transitionDone.IsZero() || time.Now().Before(emulator.transitionDone) {
+		return emulator.powerState
+	}
+
+	switch emulator.powerState {
+	case redfish.PoweringOnPowerState:
+		emulator.powerState = redfish.OnPowerState
+	case redfish.PoweringOffPowerState:
+		emulator.powerState = redfish.OffPowerState
+	}
+
+	emulator.transitionDone = time.Time{}
+
+	return emulator.powerState
+}
+
+// powerOn powers on the system, possibly through the PoweringOn state, and boots it. It must be called with the mutex
+// held.
+func (emulator *Emulator) powerOn() {
+	emulator.boot()
+
+	if emulator.quirks.PowerTransitionDelay > 0 {
+		emulator.powerState = redfish.PoweringOnPowerState
+		emulator.transitionDone = time.Now().Add(emulator.quirks.PowerTransitionDelay)
+
+		return
+	}
+
+	emulator.powerState = redfish.OnPowerState
+	emulator.transitionDone = time.Time{}
+}
+
+// powerOff powers off the system, possibly through the PoweringOff state. It must be called with the mutex held.
+func (emulator *Emulator) powerOff() {
+	if emulator.quirks.PowerTransitionDelay > 0 {
+		emulator.powerState = redfish.PoweringOffPowerState
+		emulator.transitionDone = time.Now().Add(emulator.quirks.PowerTransitionDelay)
+
+		return
+	}
+
+	emulator.powerState = redfish.OffPowerState
+	emulator.transitionDone = time.Time{}
+}
+
+// boot applies the changes that take effect when the system boots: the staged boot order and a Once boot override.
+// It must be called with the mutex held.
+func (emulator *Emulator) boot() {
+	if emulator.pendingBootOrder != nil {
+		emulator.bootOrder = emulator.pendingBootOrder
+		emulator.pendingBootOrder = nil
+	}
+
+	if emulator.overrideEnabled == redfish.OnceBootSourceOverrideEnabled {
+		emulator.overrideEnabled = redfish.DisabledBootSourceOverrideEnabled
+		emulator.overrideTarget = redfish.NoneBootSourceOverrideTarget
+	}
+}
@@ -0,0 +1,763 @@
+package redfishemulator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const (
+	rootPath          = "/redfish/v1"
+	sessionsPath      = rootPath + "/SessionService/Sessions"
+	systemsPath       = rootPath + "/Systems"
+	systemPath        = systemsPath + "/1"
+	resetActionPath   = systemPath + "/Actions/ComputerSystem.Reset"
+	bootOptionsPath   = systemPath + "/BootOptions"
+	secureBootPath    = systemPath + "/SecureBoot"
+	managersPath      = rootPath + "/Managers"
+	managerPath       = managersPath + "/1"
+	chassisCollection = rootPath + "/Chassis"
+	chassisPath       = chassisCollection + "/1"
+	powerPath         = chassisPath + "/Power"
+
+	insertMediaAction = "/Actions/VirtualMedia.InsertMedia"
+	ejectMediaAction  = "/Actions/VirtualMedia.EjectMedia"
+)
+
+// bootOption is a boot option of the emulated system.
+type bootOption struct {
+	displayName string
+	target      redfish.BootSourceOverrideTarget
+}
+
+// bootOptions are the boot options of the emulated system, indexed by their boot option reference.
+var bootOptions = map[string]bootOption{
+	"Boot0000": {displayName: "PXE Device 1: Embedded NIC 1 Port 1 Partition 1", target: redfish.PxeBootSourceOverrideTarget},
+	"Boot0001": {displayName: "Disk 0: Red Hat Enterprise Linux CoreOS", target: redfish.HddBootSourceOverrideTarget},
+	"Boot0002": {displayName: "Virtual Optical Drive", target: redfish.CdBootSourceOverrideTarget},
+}
+
+// virtualMediaTypes are the media types supported by each of the virtual media slots.
+var virtualMediaTypes = map[string][]redfish.VirtualMediaType{
+	"CD":  {redfish.CDMediaType, redfish.DVDMediaType},
+	"USB": {redfish.USBStickMediaType},
+}
+
+// newHandler returns the handler serving the Redfish API of the emulator. Every request is recorded and checked
+// against the injected failures before being routed.
+func (emulator *Emulator) newHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+rootPath, emulator.handleRoot)
+	mux.HandleFunc("GET "+rootPath+"/{$}", emulator.handleRoot)
+	mux.HandleFunc("POST "+sessionsPath, emulator.handleCreateSession)
+	mux.HandleFunc("DELETE "+sessionsPath+"/{id}", emulator.handleDeleteSession)
+
+	mux.HandleFunc("GET "+systemsPath, handleCollection(systemPath))
+	mux.HandleFunc("GET "+systemPath, emulator.handleGetSystem)
+	mux.HandleFunc("PATCH "+systemPath, emulator.handlePatchSystem)
+	mux.HandleFunc("POST "+resetActionPath, emulator.handleReset)
+	mux.HandleFunc("GET "+bootOptionsPath, handleBootOptions)
+	mux.HandleFunc("GET "+bootOptionsPath+"/{id}", handleBootOption)
+	mux.HandleFunc("GET "+secureBootPath, emulator.handleGetSecureBoot)
+	mux.HandleFunc("PATCH "+secureBootPath, emulator.handlePatchSecureBoot)
+
+	for _, parentPath := range []string{systemPath, managerPath} {
+		mux.HandleFunc("GET "+parentPath+"/VirtualMedia", emulator.handleVirtualMediaCollection)
+		mux.HandleFunc("GET "+parentPath+"/VirtualMedia/{id}", emulator.handleGetVirtualMedia)
+		mux.HandleFunc("PATCH "+parentPath+"/VirtualMedia/{id}", emulator.handlePatchVirtualMedia)
+		mux.HandleFunc("POST "+parentPath+"/VirtualMedia/{id}"+insertMediaAction, emulator.handleInsertMedia)
+		mux.HandleFunc("POST "+parentPath+"/VirtualMedia/{id}"+ejectMediaAction, emulator.handleEjectMedia)
+	}
+
+	mux.HandleFunc("GET "+managersPath, handleCollection(managerPath))
+	mux.HandleFunc("GET "+managerPath, emulator.handleGetManager)
+	mux.HandleFunc("GET "+chassisCollection, handleCollection(chassisPath))
+	mux.HandleFunc("GET "+chassisPath, handleGetChassis)
+	mux.HandleFunc("GET "+powerPath, emulator.handleGetPower)
+
+	mux.HandleFunc("/", handleNotFound)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+
+		emulator.mutex.Lock()
+		emulator.requests = append(emulator.requests, Request{Method: r.Method, Path: path})
+		failure := emulator.matchFailure(r.Method, path)
+		authorized := emulator.isAuthorized(r, path)
+		emulator.mutex.Unlock()
+
+		if failure != nil {
+			writeError(w, failure.StatusCode, failure.Message)
+
+			return
+		}
+
+		if !authorized {
+			writeError(w, http.StatusUnauthorized, "The authentication credentials included with this request are missing or invalid.")
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// matchFailure returns the first injected failure matching the request, consuming it if it has a count. It must be
+// called with the mutex held.
+func (emulator *Emulator) matchFailure(method, path string) *Failure {
+	for index, failure := range emulator.failures {
+		if failure.Path != path || (failure.Method != "" && failure.Method != method) {
+			continue
+		}
+
+		matched := *failure
+
+		if failure.Count > 0 {
+			failure.Count--
+
+			if failure.Count == 0 {
+				emulator.failures = slices.Delete(emulator.failures, index, index+1)
+			}
+		}
+
+		return &matched
+	}
+
+	return nil
+}
+
+// isAuthorized checks that the request has a valid session token or basic authentication credentials. The service
+// root and session creation do not need authentication. It must be called with the mutex held.
+func (emulator *Emulator) isAuthorized(r *http.Request, path string) bool {
+	if (r.Method == http.MethodGet && path == rootPath) || (r.Method == http.MethodPost && path == sessionsPath) {
+		return true
+	}
+
+	if token := r.Header.Get("X-Auth-Token"); token != "" {
+		_, found := emulator.sessions[token]
+
+		return found
+	}
+
+	username, password, ok := r.BasicAuth()
+
+	return ok && username == emulator.username && password == emulator.password
+}
+
+func (emulator *Emulator) handleRoot(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":      rootPath,
+		"@odata.type":    "#ServiceRoot.v1_5_0.ServiceRoot",
+		"Id":             "RootService",
+		"Name":           "Root Service",
+		"RedfishVersion": "1.11.0",
+		"Systems":        odataID(systemsPath),
+		"Chassis":        odataID(chassisCollection),
+		"Managers":       odataID(managersPath),
+		"SessionService": odataID(rootPath + "/SessionService"),
+		"Links":          map[string]any{"Sessions": odataID(sessionsPath)},
+	})
+}
+
+func (emulator *Emulator) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	var credentials struct {
+		UserName string
+		Password string
+	}
+
+	if !readJSON(w, r, &credentials) {
+		return
+	}
+
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	if credentials.UserName != emulator.username || credentials.Password != emulator.password {
+		writeError(w, http.StatusUnauthorized, "The authentication credentials included with this request are missing or invalid.")
+
+		return
+	}
+
+	tokenBytes := make([]byte, 16)
+	_, _ = rand.Read(tokenBytes)
+	token := hex.EncodeToString(tokenBytes)
+
+	emulator.nextID++
+	sessionID := fmt.Sprint(emulator.nextID)
+	emulator.sessions[token] = sessionID
+
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", sessionsPath+"/"+sessionID)
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"@odata.id": sessionsPath + "/" + sessionID,
+		"Id":        sessionID,
+		"UserName":  credentials.UserName,
+	})
+}
+
+func (emulator *Emulator) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	for token, sessionID := range emulator.sessions {
+		if sessionID == r.PathValue("id") {
+			delete(emulator.sessions, token)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("The session %s was not found.", r.PathValue("id")))
+}
+
+func (emulator *Emulator) handleGetSystem(w http.ResponseWriter, r *http.Request) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	system := map[string]any{
+		"@odata.id":    systemPath,
+		"@odata.type":  "#ComputerSystem.v1_13_0.ComputerSystem",
+		"Id":           "1",
+		"Name":         "System",
+		"Manufacturer": emulator.quirks.Manufacturer,
+		"Model":        "Emulated Server",
+		"PowerState":   emulator.currentPowerState(),
+		"Status":       map[string]any{"Health": "OK", "HealthRollup": "OK", "State": "Enabled"},
+		"Boot": map[string]any{
+			"BootOrder":                 emulator.bootOrder,
+			"BootSourceOverrideEnabled": emulator.overrideEnabled,
+			"BootSourceOverrideTarget":  emulator.overrideTarget,
+			"BootSourceOverrideTarget@Redfish.AllowableValues": bootTargets(),
+			"BootOptions": odataID(bootOptionsPath),
+		},
+		"SecureBoot": odataID(secureBootPath),
+		"Links": map[string]any{
+			"ManagedBy": []any{odataID(managerPath)},
+			"Chassis":   []any{odataID(chassisPath)},
+		},
+		"Actions": map[string]any{
+			"#ComputerSystem.Reset": map[string]any{
+				"target":                            resetActionPath,
+				"ResetType@Redfish.AllowableValues": emulator.quirks.ResetTypes,
+			},
+		},
+	}
+
+	if !emulator.quirks.VirtualMediaUnderManager {
+		system["VirtualMedia"] = odataID(systemPath + "/VirtualMedia")
+	}
+
+	writeJSON(w, http.StatusOK, system)
+}
+
+func (emulator *Emulator) handlePatchSystem(w http.ResponseWriter, r *http.Request) {
+	var patch struct {
+		Boot *struct {
+			BootOrder                 []string
+			BootSourceOverrideEnabled redfish.BootSourceOverrideEnabled
+			BootSourceOverrideTarget  redfish.BootSourceOverrideTarget
+		}
+	}
+
+	if !readJSON(w, r, &patch) {
+		return
+	}
+
+	if patch.Boot == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	for _, reference := range patch.Boot.BootOrder {
+		if _, found := bootOptions[reference]; !found {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("The boot option reference %s is not valid.", reference))
+
+			return
+		}
+	}
+
+	if target := patch.Boot.BootSourceOverrideTarget; target != "" && !slices.Contains(bootTargets(), target) {
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("The boot source override target %s is not supported.", patch.Boot.BootSourceOverrideTarget))
+
+		return
+	}
+
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	if len(patch.Boot.BootOrder) > 0 {
+		if emulator.quirks.BootOrderAppliedOnReset {
+			emulator.pendingBootOrder = slices.Clone(patch.Boot.BootOrder)
+		} else {
+			emulator.bootOrder = slices.Clone(patch.Boot.BootOrder)
+		}
+	}
+
+	if patch.Boot.BootSourceOverrideEnabled != "" {
+		emulator.overrideEnabled = patch.Boot.BootSourceOverrideEnabled
+	}
+
+	if patch.Boot.BootSourceOverrideTarget != "" {
+		emulator.overrideTarget = patch.Boot.BootSourceOverrideTarget
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (emulator *Emulator) handleReset(w http.ResponseWriter, r *http.Request) {
+	var reset struct {
+		ResetType redfish.ResetType
+	}
+
+	if !readJSON(w, r, &reset) {
+		return
+	}
+
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	if !slices.Contains(emulator.quirks.ResetTypes, reset.ResetType) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("The reset type %s is not supported.", reset.ResetType))
+
+		return
+	}
+
+	emulator.resets = append(emulator.resets, reset.ResetType)
+	isOn := emulator.currentPowerState() == redfish.OnPowerState || emulator.powerState == redfish.PoweringOnPowerState
+
+	switch reset.ResetType {
+	case redfish.OnResetType:
+		if !isOn {
+			emulator.powerOn()
+		}
+	case redfish.ForceOffResetType, redfish.GracefulShutdownResetType:
+		if isOn {
+			emulator.powerOff()
+		}
+	case redfish.PushPowerButtonResetType:
+		if isOn {
+			emulator.powerOff()
+		} else {
+			emulator.powerOn()
+		}
+	case redfish.ForceRestartResetType, redfish.GracefulRestartResetType, redfish.PowerCycleResetType:
+		emulator.powerOn()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleBootOptions(w http.ResponseWriter, r *http.Request) {
+	var members []string
+
+	for _, reference := range slices.Sorted(maps.Keys(bootOptions)) {
+		members = append(members, bootOptionsPath+"/"+reference)
+	}
+
+	writeCollection(w, bootOptionsPath, members...)
+}
+
+func handleBootOption(w http.ResponseWriter, r *http.Request) {
+	reference := r.PathValue("id")
+
+	option, found := bootOptions[reference]
+	if !found {
+		handleNotFound(w, r)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":           bootOptionsPath + "/" + reference,
+		"Id":                  reference,
+		"Name":                option.displayName,
+		"DisplayName":         option.displayName,
+		"BootOptionReference": reference,
+		"BootOptionEnabled":   true,
+	})
+}
+
+func (emulator *Emulator) handleGetSecureBoot(w http.ResponseWriter, r *http.Request) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	secureBootCurrentBoot := redfish.DisabledSecureBootCurrentBootType
+	if emulator.secureBootEnabled {
+		secureBootCurrentBoot = redfish.EnabledSecureBootCurrentBootType
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":             secureBootPath,
+		"Id":                    "SecureBoot",
+		"Name":                  "UEFI Secure Boot",
+		"SecureBootEnable":      emulator.secureBootEnabled,
+		"SecureBootCurrentBoot": secureBootCurrentBoot,
+		"SecureBootMode":        redfish.DeployedModeSecureBootModeType,
+	})
+}
+
+func (emulator *Emulator) handlePatchSecureBoot(w http.ResponseWriter, r *http.Request) {
+	var patch struct {
+		SecureBootEnable *bool
+	}
+
+	if !readJSON(w, r, &patch) {
+		return
+	}
+
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	if patch.SecureBootEnable != nil {
+		emulator.secureBootEnabled = *patch.SecureBootEnable
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (emulator *Emulator) handleVirtualMediaCollection(w http.ResponseWriter, r *http.Request) {
+	collectionPath := strings.TrimSuffix(r.URL.Path, "/")
+	if collectionPath != emulator.virtualMediaPath() {
+		handleNotFound(w, r)
+
+		return
+	}
+
+	var members []string
+
+	for _, id := range slices.Sorted(maps.Keys(virtualMediaTypes)) {
+		members = append(members, collectionPath+"/"+id)
+	}
+
+	writeCollection(w, collectionPath, members...)
+}
+
+func (emulator *Emulator) handleGetVirtualMedia(w http.ResponseWriter, r *http.Request) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	media := emulator.lookupVirtualMedia(w, r)
+	if media == nil {
+		return
+	}
+
+	id := r.PathValue("id")
+	mediaPath := emulator.virtualMediaPath() + "/" + id
+	virtualMedia := map[string]any{
+		"@odata.id":      mediaPath,
+		"@odata.type":    "#VirtualMedia.v1_3_0.VirtualMedia",
+		"Id":             id,
+		"Name":           "Virtual " + id,
+		"MediaTypes":     virtualMediaTypes[id],
+		"Image":          media.Image,
+		"Inserted":       media.Inserted,
+		"WriteProtected": media.WriteProtected,
+		"ConnectedVia":   redfish.NotConnectedConnectedVia,
+	}
+
+	if media.Inserted {
+		virtualMedia["ConnectedVia"] = redfish.URIConnectedVia
+	}
+
+	if !emulator.quirks.VirtualMediaWithoutActions {
+		virtualMedia["Actions"] = map[string]any{
+			"#VirtualMedia.InsertMedia": map[string]any{"target": mediaPath + insertMediaAction},
+			"#VirtualMedia.EjectMedia":  map[string]any{"target": mediaPath + ejectMediaAction},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, virtualMedia)
+}
+
+func (emulator *Emulator) handlePatchVirtualMedia(w http.ResponseWriter, r *http.Request) {
+	var patch struct {
+		Image          *string
+		Inserted       *bool
+		WriteProtected *bool
+	}
+
+	if !readJSON(w, r, &patch) {
+		return
+	}
+
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	media := emulator.lookupVirtualMedia(w, r)
+	if media == nil {
+		return
+	}
+
+	if patch.Image != nil {
+		media.Image = *patch.Image
+		media.Inserted = *patch.Image != ""
+	}
+
+	if patch.Inserted != nil {
+		media.Inserted = *patch.Inserted
+	}
+
+	if patch.WriteProtected != nil {
+		media.WriteProtected = *patch.WriteProtected
+	}
+
+	if !media.Inserted {
+		*media = VirtualMediaState{}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (emulator *Emulator) handleInsertMedia(w http.ResponseWriter, r *http.Request) {
+	var insert struct {
+		Image          string
+		Inserted       *bool
+		WriteProtected *bool
+	}
+
+	if !readJSON(w, r, &insert) {
+		return
+	}
+
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	media := emulator.lookupVirtualMediaAction(w, r)
+	if media == nil {
+		return
+	}
+
+	if insert.Image == "" {
+		writeError(w, http.StatusBadRequest, "The Image property is required to insert media.")
+
+		return
+	}
+
+	if media.Inserted {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Virtual media %s already has media inserted.", r.PathValue("id")))
+
+		return
+	}
+
+	*media = VirtualMediaState{
+		Image:          insert.Image,
+		Inserted:       insert.Inserted == nil || *insert.Inserted,
+		WriteProtected: insert.WriteProtected == nil || *insert.WriteProtected,
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (emulator *Emulator) handleEjectMedia(w http.ResponseWriter, r *http.Request) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	media := emulator.lookupVirtualMediaAction(w, r)
+	if media == nil {
+		return
+	}
+
+	if !media.Inserted {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Virtual media %s has no media inserted.", r.PathValue("id")))
+
+		return
+	}
+
+	*media = VirtualMediaState{}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (emulator *Emulator) handleGetManager(w http.ResponseWriter, r *http.Request) {
+	manager := map[string]any{
+		"@odata.id":       managerPath,
+		"@odata.type":     "#Manager.v1_10_0.Manager",
+		"Id":              "1",
+		"Name":            "Manager",
+		"ManagerType":     redfish.BMCManagerType,
+		"FirmwareVersion": "1.0.0",
+		"Status":          map[string]any{"Health": "OK", "State": "Enabled"},
+		"Links": map[string]any{
+			"ManagerForServers": []any{odataID(systemPath)},
+			"ManagerForChassis": []any{odataID(chassisPath)},
+		},
+	}
+
+	if emulator.quirks.VirtualMediaUnderManager {
+		manager["VirtualMedia"] = odataID(managerPath + "/VirtualMedia")
+	}
+
+	writeJSON(w, http.StatusOK, manager)
+}
+
+func handleGetChassis(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":   chassisPath,
+		"@odata.type": "#Chassis.v1_14_0.Chassis",
+		"Id":          "1",
+		"Name":        "Chassis",
+		"ChassisType": redfish.RackMountChassisType,
+		"Status":      map[string]any{"Health": "OK", "HealthRollup": "OK", "State": "Enabled"},
+		"Power":       odataID(powerPath),
+		"Links": map[string]any{
+			"ComputerSystems": []any{odataID(systemPath)},
+			"ManagedBy":       []any{odataID(managerPath)},
+		},
+	})
+}
+
+func (emulator *Emulator) handleGetPower(w http.ResponseWriter, r *http.Request) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+
+	var powerConsumedWatts float32
+	if emulator.currentPowerState() == redfish.OnPowerState {
+		powerConsumedWatts = emulator.powerConsumedWatts
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id": powerPath,
+		"Id":        "Power",
+		"Name":      "Power",
+		"PowerControl": []any{map[string]any{
+			"@odata.id":          powerPath + "#/PowerControl/0",
+			"MemberId":           "0",
+			"Name":               "System Power Control",
+			"PowerConsumedWatts": powerConsumedWatts,
+			"PowerCapacityWatts": 1600,
+		}},
+		"PowerSupplies": []any{map[string]any{
+			"@odata.id":          powerPath + "#/PowerSupplies/0",
+			"MemberId":           "0",
+			"Name":               "PS1 Status",
+			"PowerCapacityWatts": 800,
+			"Status":             map[string]any{"Health": "OK", "State": "Enabled"},
+		}},
+	})
+}
+
+func handleNotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("The resource at the URI %s was not found.", r.URL.Path))
+}
+
+// handleCollection returns a handler for a collection whose only member is memberPath.
+func handleCollection(memberPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeCollection(w, strings.TrimSuffix(r.URL.Path, "/"), memberPath)
+	}
+}
+
+// virtualMediaPath returns the path of the virtual media collection, which is under the system unless the
+// VirtualMediaUnderManager quirk is set.
+func (emulator *Emulator) virtualMediaPath() string {
+	if emulator.quirks.VirtualMediaUnderManager {
+		return managerPath + "/VirtualMedia"
+	}
+
+	return systemPath + "/VirtualMedia"
+}
+
+// lookupVirtualMedia returns the state of the virtual media slot addressed by the request, writing a not found error
+// if there is none. It must be called with the mutex held.
+func (emulator *Emulator) lookupVirtualMedia(w http.ResponseWriter, r *http.Request) *VirtualMediaState {
+	if !strings.HasPrefix(r.URL.Path, emulator.virtualMediaPath()+"/") {
+		handleNotFound(w, r)
+
+		return nil
+	}
+
+	media, found := emulator.virtualMedia[r.PathValue("id")]
+	if !found {
+		handleNotFound(w, r)
+
+		return nil
+	}
+
+	return media
+}
+
+// lookupVirtualMediaAction is like lookupVirtualMedia but also writes a not found error if the virtual media actions
+// are disabled by the VirtualMediaWithoutActions quirk. It must be called with the mutex held.
+func (emulator *Emulator) lookupVirtualMediaAction(w http.ResponseWriter, r *http.Request) *VirtualMediaState {
+	if emulator.quirks.VirtualMediaWithoutActions {
+		handleNotFound(w, r)
+
+		return nil
+	}
+
+	return emulator.lookupVirtualMedia(w, r)
+}
+
+// bootTargets returns the boot source override targets allowed by the emulated system.
+func bootTargets() []redfish.BootSourceOverrideTarget {
+	targets := []redfish.BootSourceOverrideTarget{redfish.NoneBootSourceOverrideTarget}
+
+	for _, reference := range slices.Sorted(maps.Keys(bootOptions)) {
+		targets = append(targets, bootOptions[reference].target)
+	}
+
+	return targets
+}
+
+func odataID(path string) map[string]any {
+	return map[string]any{"@odata.id": path}
+}
+
+func writeCollection(w http.ResponseWriter, collectionPath string, memberPaths ...string) {
+	members := []any{}
+
+	for _, memberPath := range memberPaths {
+		members = append(members, odataID(memberPath))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":           collectionPath,
+		"Members":             members,
+		"Members@odata.count": len(members),
+	})
+}
+
+// readJSON decodes the request body into value, writing a bad request error if it is not valid JSON.
+func readJSON(w http.ResponseWriter, r *http.Request, value any) bool {
+	err := json.NewDecoder(r.Body).Decode(value)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("The request body is not valid JSON: %v", err))
+
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes a Redfish error with the message both as the error message and as its extended info.
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"error": map[string]any{
+			"code":    "Base.1.8.GeneralError",
+			"message": message,
+			"@Message.ExtendedInfo": []any{map[string]any{
+				"MessageId": "Base.1.8.GeneralError",
+				"Message":   message,
+				"Severity":  "Critical",
+			}},
+		},
+	})
+}
@@ -0,0 +1,336 @@
+package bmc
+
+import (
+	"fmt"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// TemperatureReading holds the reading of a temperature sensor.
+type TemperatureReading struct {
+	// Name is the name of the sensor, such as "CPU1 Temp".
+	Name string
+	// PhysicalContext is the area the sensor measures, such as CPU or Intake.
+	PhysicalContext redfish.PhysicalContext
+	// ReadingCelsius is the current temperature.
+	ReadingCelsius float32
+	// UpperThresholdCritical is the temperature above which the reading is critical. Zero if the BMC does not report it.
+	UpperThresholdCritical float32
+	// Health is the health of the sensor as reported by the BMC.
+	Health common.Health
+}
+
+// FanReading holds the reading of a fan sensor.
+type FanReading struct {
+	// Name is the name of the fan.
+	Name string
+	// Reading is the current speed of the fan, in ReadingUnits.
+	Reading int
+	// ReadingUnits are the units of the reading, either RPM or Percent.
+	ReadingUnits redfish.ReadingUnits
+	// Health is the health of the fan as reported by the BMC.
+	Health common.Health
+}
+
+// ThermalReadings holds the temperature and fan readings of a chassis.
+type ThermalReadings struct {
+	// Chassis is the ID of the chassis the readings belong to.
+	Chassis string
+	// Temperatures are the readings of the enabled temperature sensors.
+	Temperatures []TemperatureReading
+	// Fans are the readings of the enabled fans.
+	Fans []FanReading
+}
+
+// PowerSupplyStatus holds the status of a power supply unit.
+type PowerSupplyStatus struct {
+	// Name is the name of the power supply.
+	Name string
+	// Health is the health of the power supply.
+	Health common.Health
+	// State is the state of the power supply, such as Enabled or Absent.
+	State common.State
+	// PowerInputWatts is the power drawn by the power supply.
+	PowerInputWatts float32
+	// PowerOutputWatts is the power delivered by the power supply.
+	PowerOutputWatts float32
+	// PowerCapacityWatts is the maximum power the power supply can deliver.
+	PowerCapacityWatts float32
+}
+
+// PowerRedundancy holds the status of a power supply redundancy group.
+type PowerRedundancy struct {
+	// Name is the name of the redundancy group.
+	Name string
+	// Mode is the redundancy mode, such as N+m or Sharing.
+	Mode redfish.RedundancyMode
+	// MinNumNeeded is the minimum number of power supplies needed to keep the group redundant.
+	MinNumNeeded int
+	// Health is the health of the group, which is not OK once redundancy is lost.
+	Health common.Health
+	// State is the state of the group, such as Enabled.
+	State common.State
+}
+
+// PowerSupplies holds the power supplies and their redundancy of a chassis.
+type PowerSupplies struct {
+	// Chassis is the ID of the chassis the power supplies belong to.
+	Chassis string
+	// PowerSupplies are the power supply units of the chassis.
+	PowerSupplies []PowerSupplyStatus
+	// Redundancy are the redundancy groups of the power supplies.
+	Redundancy []PowerRedundancy
+}
+
+// HealthRollup holds the overall health of the system and of every chassis as reported by the BMC.
+type HealthRollup struct {
+	// System is the health of the system resource itself.
+	System common.Health
+	// SystemRollup is the health of the system and all its dependent resources.
+	SystemRollup common.Health
+	// Chassis maps the ID of every chassis to its rollup health, or to its own health if the rollup is not reported.
+	Chassis map[string]common.Health
+}
+
+// IsHealthy returns whether the system and every chassis are OK. Health values the BMC does not report are ignored.
+func (rollup *HealthRollup) IsHealthy() bool {
+	if !isHealthOK(rollup.System) || !isHealthOK(rollup.SystemRollup) {
+		return false
+	}
+
+	for _, health := range rollup.Chassis {
+		if !isHealthOK(health) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ThermalReadings returns the temperature and fan readings using the Redfish API. This method uses the first chassis
+// with a thermal link.
+func (bmc *BMC) ThermalReadings() (*ThermalReadings, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting thermal readings from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	thermalReadings, err := redfishGetThermalReadings(redfishClient)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish thermal readings: %v", err)
+
+		return nil, fmt.Errorf("failed to get thermal readings: %w", err)
+	}
+
+	return thermalReadings, nil
+}
+
+// PowerSupplies returns the status of the power supplies and of their redundancy using the Redfish API. This method
+// uses the first chassis with a power link.
+func (bmc *BMC) PowerSupplies() (*PowerSupplies, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting power supplies from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish chassis collection: %v", err)
+
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
+
+	for _, chassis := range chassisCollection {
+		power, err := chassis.Power()
+		if err != nil {
+			klog.V(100).Infof("Failed to get power of redfish chassis %s: %v", chassis.ID, err)
+
+			return nil, fmt.Errorf("failed to get power for chassis %s: %w", chassis.ID, err)
+		}
+
+		if power == nil {
+			continue
+		}
+
+		powerSupplies := &PowerSupplies{Chassis: chassis.ID}
+
+		for _, powerSupply := range power.PowerSupplies {
+			powerSupplies.PowerSupplies = append(powerSupplies.PowerSupplies, PowerSupplyStatus{
+				Name:               powerSupply.Name,
+				Health:             powerSupply.Status.Health,
+				State:              powerSupply.Status.State,
+				PowerInputWatts:    powerSupply.PowerInputWatts,
+				PowerOutputWatts:   powerSupply.PowerOutputWatts,
+				PowerCapacityWatts: powerSupply.PowerCapacityWatts,
+			})
+		}
+
+		for _, redundancy := range power.Redundancy {
+			powerSupplies.Redundancy = append(powerSupplies.Redundancy, PowerRedundancy{
+				Name:         redundancy.Name,
+				Mode:         redundancy.Mode,
+				MinNumNeeded: redundancy.MinNumNeeded,
+				Health:       redundancy.Status.Health,
+				State:        redundancy.Status.State,
+			})
+		}
+
+		return powerSupplies, nil
+	}
+
+	klog.V(100).Info("No redfish chassis with a power link found")
+
+	return nil, fmt.Errorf("failed to get power supplies: no chassis with power link found")
+}
+
+// SystemHealth returns the health of the system and of every chassis using the Redfish API.
+func (bmc *BMC) SystemHealth() (*HealthRollup, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting system health from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish chassis collection: %v", err)
+
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
+
+	rollup := &HealthRollup{
+		System:       system.Status.Health,
+		SystemRollup: system.Status.HealthRollup,
+		Chassis:      make(map[string]common.Health),
+	}
+
+	for _, chassis := range chassisCollection {
+		health := chassis.Status.HealthRollup
+		if health == "" {
+			health = chassis.Status.Health
+		}
+
+		rollup.Chassis[chassis.ID] = health
+	}
+
+	return rollup, nil
+}
+
+// redfishGetThermalReadings gets the readings of the enabled temperature sensors and fans from the first chassis with
+// a thermal link.
+func redfishGetThermalReadings(redfishClient *gofish.APIClient) (*ThermalReadings, error) {
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
+
+	for _, chassis := range chassisCollection {
+		thermal, err := chassis.Thermal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get thermal for chassis %s: %w", chassis.ID, err)
+		}
+
+		if thermal == nil {
+			continue
+		}
+
+		thermalReadings := &ThermalReadings{Chassis: chassis.ID}
+
+		// Absent sensors, such as the ones of empty CPU sockets, report a zero reading.
+		for _, temperature := range thermal.Temperatures {
+			if temperature.Status.State != "" && temperature.Status.State != common.EnabledState {
+				continue
+			}
+
+			thermalReadings.Temperatures = append(thermalReadings.Temperatures, TemperatureReading{
+				Name:                   temperature.Name,
+				PhysicalContext:        temperature.PhysicalContext,
+				ReadingCelsius:         temperature.ReadingCelsius,
+				UpperThresholdCritical: temperature.UpperThresholdCritical,
+				Health:                 temperature.Status.Health,
+			})
+		}
+
+		for _, fan := range thermal.Fans {
+			if fan.Status.State != "" && fan.Status.State != common.EnabledState {
+				continue
+			}
+
+			thermalReadings.Fans = append(thermalReadings.Fans, FanReading{
+				Name:         fan.Name,
+				Reading:      fan.Reading,
+				ReadingUnits: fan.ReadingUnits,
+				Health:       fan.Status.Health,
+			})
+		}
+
+		return thermalReadings, nil
+	}
+
+	return nil, fmt.Errorf("no chassis with thermal link found")
+}
+
+// isHealthOK returns whether the health is OK or not reported.
+func isHealthOK(health common.Health) bool {
+	return health == "" || health == common.OKHealth
+}
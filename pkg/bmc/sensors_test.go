@@ -0,0 +1,160 @@
+package bmc
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/redfish_v1_thermal.json
+var redfishThermalJSONResponse string
+
+func TestBMCThermalReadings(t *testing.T) {
+	redfishServer := createFakeRedfishSensorServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	thermalReadings, err := bmc.ThermalReadings()
+	assert.Nil(t, err)
+	assert.Equal(t, &ThermalReadings{
+		Chassis: "System.Embedded.1",
+		Temperatures: []TemperatureReading{
+			{
+				Name:                   "CPU1 Temp",
+				PhysicalContext:        redfish.CPUPhysicalContext,
+				ReadingCelsius:         45,
+				UpperThresholdCritical: 95,
+				Health:                 common.OKHealth,
+			},
+			{
+				Name:                   "System Board Inlet Temp",
+				PhysicalContext:        redfish.IntakePhysicalContext,
+				ReadingCelsius:         24,
+				UpperThresholdCritical: 47,
+				Health:                 common.OKHealth,
+			},
+		},
+		Fans: []FanReading{{
+			Name:         "System Board Fan1A",
+			Reading:      6120,
+			ReadingUnits: redfish.RPMReadingUnits,
+			Health:       common.OKHealth,
+		}},
+	}, thermalReadings)
+}
+
+func TestBMCPowerSupplies(t *testing.T) {
+	redfishServer := createFakeRedfishSensorServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	powerSupplies, err := bmc.PowerSupplies()
+	assert.Nil(t, err)
+	assert.Equal(t, "System.Embedded.1", powerSupplies.Chassis)
+	assert.Len(t, powerSupplies.PowerSupplies, 2)
+	assert.Equal(t, PowerSupplyStatus{
+		Name:               "PS1 Status",
+		Health:             common.OKHealth,
+		State:              common.EnabledState,
+		PowerInputWatts:    179.5,
+		PowerOutputWatts:   164.25,
+		PowerCapacityWatts: 800,
+	}, powerSupplies.PowerSupplies[0])
+	assert.Equal(t, []PowerRedundancy{{
+		Name:         "System Board PS Redundancy",
+		Mode:         redfish.NMRedundancyMode,
+		MinNumNeeded: 1,
+		Health:       common.OKHealth,
+		State:        common.EnabledState,
+	}}, powerSupplies.Redundancy)
+}
+
+func TestBMCSystemHealth(t *testing.T) {
+	testCases := []struct {
+		systemHealthRollup common.Health
+		expectedHealthy    bool
+	}{
+		{
+			systemHealthRollup: common.OKHealth,
+			expectedHealthy:    true,
+		},
+		{
+			systemHealthRollup: common.WarningHealth,
+			expectedHealthy:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+		mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1", func(w http.ResponseWriter, r *http.Request) {
+			var system map[string]any
+
+			err := json.Unmarshal([]byte(redfishSystemJSONResponse), &system)
+			assert.Nil(t, err)
+
+			system["Status"] = map[string]any{"Health": "OK", "HealthRollup": testCase.systemHealthRollup, "State": "Enabled"}
+			writeFakeRedfishJSON(t, w, system)
+		})
+
+		redfishServer := startFakeRedfishLocalServer(mux)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		rollup, err := bmc.SystemHealth()
+
+		redfishServer.Close()
+
+		assert.Nil(t, err)
+		assert.Equal(t, common.OKHealth, rollup.System)
+		assert.Equal(t, testCase.systemHealthRollup, rollup.SystemRollup)
+		assert.Equal(t, map[string]common.Health{
+			"System.Embedded.1":      common.OKHealth,
+			"Enclosure.Internal.0-1": common.OKHealth,
+		}, rollup.Chassis)
+		assert.Equal(t, testCase.expectedHealthy, rollup.IsHealthy())
+	}
+}
+
+// createFakeRedfishSensorServer creates a fake Redfish server whose chassis reports thermal readings. Successive
+// thermal requests report the provided CPU1 temperatures, repeating the last one once exhausted.
+func createFakeRedfishSensorServer(t *testing.T, cpuTemperatures ...float32) *httptest.Server {
+	t.Helper()
+
+	var (
+		mutex        sync.Mutex
+		thermalGets  int
+		thermalState map[string]any
+	)
+
+	err := json.Unmarshal([]byte(redfishThermalJSONResponse), &thermalState)
+	assert.Nil(t, err)
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+	mux.HandleFunc("GET /redfish/v1/Chassis/System.Embedded.1/Thermal", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if len(cpuTemperatures) > 0 {
+			cpuTemperature := cpuTemperatures[min(thermalGets, len(cpuTemperatures)-1)]
+			thermalState["Temperatures"].([]any)[0].(map[string]any)["ReadingCelsius"] = cpuTemperature
+		}
+
+		thermalGets++
+
+		writeFakeRedfishJSON(t, w, thermalState)
+	})
+
+	return startFakeRedfishLocalServer(mux)
+}
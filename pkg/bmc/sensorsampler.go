@@ -0,0 +1,275 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// SensorSample holds the power and thermal readings taken at one point in time by a SensorSampler.
+type SensorSample struct {
+	// Time is when the readings were taken.
+	Time time.Time
+	// PowerWatts is the power consumption of the chassis, see PowerUsage.
+	PowerWatts float32
+	// Temperatures are the readings of the enabled temperature sensors.
+	Temperatures []TemperatureReading
+	// Fans are the readings of the enabled fans.
+	Fans []FanReading
+}
+
+// SensorSummary holds the extreme and average values of the samples of a SensorSampler.
+type SensorSummary struct {
+	// Samples is the number of samples taken.
+	Samples int
+	// MinPowerWatts is the lowest power consumption sampled.
+	MinPowerWatts float32
+	// MaxPowerWatts is the highest power consumption sampled.
+	MaxPowerWatts float32
+	// AveragePowerWatts is the average power consumption of the samples.
+	AveragePowerWatts float32
+	// MaxTemperaturesCelsius maps the name of every temperature sensor to its highest reading.
+	MaxTemperaturesCelsius map[string]float32
+}
+
+// SensorLimits holds the limits checked by SensorSampler.CheckLimits. Zero values disable the check, except for
+// MaxTemperatureCelsius.
+type SensorLimits struct {
+	// MaxPowerWatts is the highest power consumption allowed.
+	MaxPowerWatts float32
+	// MaxTemperatureCelsius is the highest temperature allowed for any sensor. If zero, the critical threshold of each
+	// sensor is used instead, for the sensors that report one.
+	MaxTemperatureCelsius float32
+}
+
+// SensorSampler records the power and thermal readings of a BMC at a regular interval, such as while a workload runs,
+// so that tests can assert on the values reached.
+type SensorSampler struct {
+	bmc      *BMC
+	interval time.Duration
+	mutex    sync.Mutex
+	samples  []SensorSample
+	failures int
+	lastErr  error
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewSensorSampler returns a SensorSampler that takes a sample every interval once started.
+func (bmc *BMC) NewSensorSampler(interval time.Duration) *SensorSampler {
+	klog.V(100).Infof("Creating sensor sampler with interval %s", interval)
+
+	return &SensorSampler{
+		bmc:      bmc,
+		interval: interval,
+	}
+}
+
+// Start starts sampling in the background. The first sample is taken immediately. Samples that fail, for example
+// because the BMC is busy, are skipped and counted, see Failures.
+func (sampler *SensorSampler) Start() error {
+	if valid, err := sampler.bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Starting sensor sampler with interval %s", sampler.interval)
+
+	if sampler.interval <= 0 {
+		klog.V(100).Infof("The sensor sampler interval %s is not positive", sampler.interval)
+
+		return fmt.Errorf("sensor sampler 'interval' must be positive")
+	}
+
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	if sampler.cancel != nil {
+		klog.V(100).Info("The sensor sampler is already running")
+
+		return fmt.Errorf("sensor sampler is already running")
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	sampler.cancel = cancel
+	sampler.done = make(chan struct{})
+
+	go func() {
+		defer close(sampler.done)
+
+		wait.UntilWithContext(ctx, sampler.takeSample, sampler.interval)
+	}()
+
+	return nil
+}
+
+// Stop stops sampling, waiting for the sample in progress if any, and returns the samples taken. It does nothing if
+// the sampler is not running.
+func (sampler *SensorSampler) Stop() []SensorSample {
+	klog.V(100).Info("Stopping sensor sampler")
+
+	sampler.mutex.Lock()
+	cancel, done := sampler.cancel, sampler.done
+	sampler.cancel = nil
+	sampler.mutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	return sampler.Samples()
+}
+
+// Samples returns the samples taken so far, oldest first.
+func (sampler *SensorSampler) Samples() []SensorSample {
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	return slices.Clone(sampler.samples)
+}
+
+// Failures returns the number of samples that could not be taken and the error of the last one.
+func (sampler *SensorSampler) Failures() (int, error) {
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	return sampler.failures, sampler.lastErr
+}
+
+// Summary returns the extreme and average values of the samples taken so far.
+func (sampler *SensorSampler) Summary() SensorSummary {
+	samples := sampler.Samples()
+	summary := SensorSummary{
+		Samples:                len(samples),
+		MaxTemperaturesCelsius: make(map[string]float32),
+	}
+
+	var totalPowerWatts float32
+
+	for index, sample := range samples {
+		if index == 0 || sample.PowerWatts < summary.MinPowerWatts {
+			summary.MinPowerWatts = sample.PowerWatts
+		}
+
+		summary.MaxPowerWatts = max(summary.MaxPowerWatts, sample.PowerWatts)
+		totalPowerWatts += sample.PowerWatts
+
+		for _, temperature := range sample.Temperatures {
+			maxTemperature, found := summary.MaxTemperaturesCelsius[temperature.Name]
+			if !found || temperature.ReadingCelsius > maxTemperature {
+				summary.MaxTemperaturesCelsius[temperature.Name] = temperature.ReadingCelsius
+			}
+		}
+	}
+
+	if len(samples) > 0 {
+		summary.AveragePowerWatts = totalPowerWatts / float32(len(samples))
+	}
+
+	return summary
+}
+
+// CheckLimits returns an error describing every sample that exceeded the limits, or nil if all samples are within
+// them. It also returns an error if no sample was taken, since the limits could not be checked.
+func (sampler *SensorSampler) CheckLimits(limits SensorLimits) error {
+	klog.V(100).Infof("Checking sensor samples against limits %+v", limits)
+
+	samples := sampler.Samples()
+	if len(samples) == 0 {
+		klog.V(100).Info("The sensor sampler has no samples")
+
+		return fmt.Errorf("sensor sampler has no samples to check")
+	}
+
+	var violations []string
+
+	for _, sample := range samples {
+		timestamp := sample.Time.Format(time.RFC3339)
+
+		if limits.MaxPowerWatts > 0 && sample.PowerWatts > limits.MaxPowerWatts {
+			violations = append(violations, fmt.Sprintf("%s: power %gW exceeds %gW",
+				timestamp, sample.PowerWatts, limits.MaxPowerWatts))
+		}
+
+		for _, temperature := range sample.Temperatures {
+			maxTemperature := limits.MaxTemperatureCelsius
+			if maxTemperature == 0 {
+				maxTemperature = temperature.UpperThresholdCritical
+			}
+
+			if maxTemperature > 0 && temperature.ReadingCelsius > maxTemperature {
+				violations = append(violations, fmt.Sprintf("%s: %s temperature %gC exceeds %gC",
+					timestamp, temperature.Name, temperature.ReadingCelsius, maxTemperature))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		klog.V(100).Infof("Sensor samples exceeded limits: %v", violations)
+
+		return fmt.Errorf("sensor samples exceeded limits: %s", strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// takeSample reads the power and thermal sensors using a single Redfish session and records the sample.
+func (sampler *SensorSampler) takeSample(ctx context.Context) {
+	sample, err := sampler.bmc.readSensors()
+
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	if err != nil {
+		klog.V(100).Infof("Failed to take sensor sample: %v", err)
+
+		sampler.failures++
+		sampler.lastErr = err
+
+		return
+	}
+
+	sampler.samples = append(sampler.samples, *sample)
+}
+
+// readSensors reads the power consumption and the thermal readings of the BMC.
+func (bmc *BMC) readSensors() (*SensorSample, error) {
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	sampleTime := time.Now()
+
+	powerControl, err := redfishGetPowerControl(redfishClient, bmc.powerControlIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish power control: %w", err)
+	}
+
+	thermalReadings, err := redfishGetThermalReadings(redfishClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thermal readings: %w", err)
+	}
+
+	return &SensorSample{
+		Time:         sampleTime,
+		PowerWatts:   powerControl.PowerConsumedWatts,
+		Temperatures: thermalReadings.Temperatures,
+		Fans:         thermalReadings.Fans,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package bmc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorSampler(t *testing.T) {
+	redfishServer := createFakeRedfishSensorServer(t, 45, 60, 97)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	sampler := bmc.NewSensorSampler(50 * time.Millisecond)
+
+	err := sampler.CheckLimits(SensorLimits{})
+	assert.EqualError(t, err, "sensor sampler has no samples to check")
+
+	err = sampler.Start()
+	assert.Nil(t, err)
+
+	err = sampler.Start()
+	assert.EqualError(t, err, "sensor sampler is already running")
+
+	assert.Eventually(t, func() bool {
+		return len(sampler.Samples()) >= 3
+	}, 10*time.Second, 10*time.Millisecond)
+
+	samples := sampler.Stop()
+	assert.GreaterOrEqual(t, len(samples), 3)
+	assert.Equal(t, float32(360), samples[0].PowerWatts)
+	assert.Len(t, samples[0].Temperatures, 2)
+	assert.Len(t, samples[0].Fans, 1)
+
+	// Stopping a stopped sampler does not take more samples.
+	assert.Equal(t, samples, sampler.Stop())
+
+	failures, lastErr := sampler.Failures()
+	assert.Zero(t, failures)
+	assert.Nil(t, lastErr)
+
+	summary := sampler.Summary()
+	assert.Equal(t, len(samples), summary.Samples)
+	assert.Equal(t, float32(360), summary.MinPowerWatts)
+	assert.Equal(t, float32(360), summary.MaxPowerWatts)
+	assert.Equal(t, float32(360), summary.AveragePowerWatts)
+	assert.Equal(t, map[string]float32{"CPU1 Temp": 97, "System Board Inlet Temp": 24}, summary.MaxTemperaturesCelsius)
+
+	assert.Nil(t, sampler.CheckLimits(SensorLimits{MaxPowerWatts: 400, MaxTemperatureCelsius: 100}))
+
+	// Without a temperature limit, the critical threshold of the CPU is exceeded by the third sample.
+	err = sampler.CheckLimits(SensorLimits{MaxPowerWatts: 400})
+	assert.ErrorContains(t, err, "CPU1 Temp temperature 97C exceeds 95C")
+	assert.NotContains(t, err.Error(), "power")
+
+	err = sampler.CheckLimits(SensorLimits{MaxPowerWatts: 300, MaxTemperatureCelsius: 100})
+	assert.ErrorContains(t, err, "power 360W exceeds 300W")
+}
+
+func TestSensorSamplerFailures(t *testing.T) {
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+	mux.HandleFunc("GET /redfish/v1/Chassis/System.Embedded.1/Thermal", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	redfishServer := startFakeRedfishLocalServer(mux)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	sampler := bmc.NewSensorSampler(0)
+	assert.EqualError(t, sampler.Start(), "sensor sampler 'interval' must be positive")
+
+	sampler = bmc.NewSensorSampler(50 * time.Millisecond)
+	assert.Nil(t, sampler.Start())
+
+	assert.Eventually(t, func() bool {
+		failures, _ := sampler.Failures()
+
+		return failures >= 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Empty(t, sampler.Stop())
+
+	_, lastErr := sampler.Failures()
+	assert.ErrorContains(t, lastErr, "failed to get thermal readings")
+}
@@ -0,0 +1,355 @@
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// StorageDrive holds the inventory of a drive of a storage subsystem.
+type StorageDrive struct {
+	// URI is the path of the drive resource, which can be passed to SecureEraseDrive.
+	URI string
+	// ID is the Redfish ID of the drive.
+	ID string
+	// Name is the name of the drive.
+	Name string
+	// Manufacturer is the manufacturer of the drive.
+	Manufacturer string
+	// Model is the model of the drive.
+	Model string
+	// SerialNumber is the serial number of the drive.
+	SerialNumber string
+	// WWN is the world wide name of the drive in the form Linux reports it, such as 0x5000c500a1b2c3d4 or
+	// eui.0025388b91b2e4a1. The 128-bit NAA 6 names are kept whole, which is the form Linux reports as the WWN with
+	// extension. It is empty if the BMC does not report a NAA or EUI identifier.
+	WWN string
+	// CapacityBytes is the size of the drive in bytes.
+	CapacityBytes int64
+	// MediaType is HDD, SSD or SMR.
+	MediaType redfish.MediaType
+	// Protocol is the protocol the drive uses, such as SATA, SAS or NVMe.
+	Protocol common.Protocol
+	// Health is the health of the drive.
+	Health common.Health
+}
+
+// StorageVolume holds the inventory of a volume of a storage subsystem.
+type StorageVolume struct {
+	// URI is the path of the volume resource, which can be passed to DeleteVolume.
+	URI string
+	// ID is the Redfish ID of the volume.
+	ID string
+	// Name is the name of the volume.
+	Name string
+	// CapacityBytes is the size of the volume in bytes.
+	CapacityBytes int
+	// RAIDType is the RAID level of the volume, such as RAID1.
+	RAIDType redfish.RAIDType
+	// Drives are the IDs of the drives backing the volume, sorted.
+	Drives []string
+	// Health is the health of the volume.
+	Health common.Health
+}
+
+// StorageSubsystem holds the drives and volumes of a storage subsystem of the system, such as a RAID controller.
+type StorageSubsystem struct {
+	// ID is the Redfish ID of the storage subsystem.
+	ID string
+	// Name is the name of the storage subsystem.
+	Name string
+	// Drives are the drives of the storage subsystem, sorted by ID.
+	Drives []StorageDrive
+	// Volumes are the volumes of the storage subsystem, sorted by ID.
+	Volumes []StorageVolume
+}
+
+// StorageInventory returns the storage subsystems of the system along with their drives and volumes using the Redfish
+// API, sorted by ID.
+func (bmc *BMC) StorageInventory() ([]StorageSubsystem, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting storage inventory from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	redfishStorages, err := system.Storage()
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish system's storage: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system's storage: %w", err)
+	}
+
+	slices.SortFunc(redfishStorages, func(a, b *redfish.Storage) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	var subsystems []StorageSubsystem
+
+	for _, redfishStorage := range redfishStorages {
+		subsystem, err := newStorageSubsystem(redfishStorage)
+		if err != nil {
+			klog.V(100).Infof("Failed to get inventory of storage %s: %v", redfishStorage.ID, err)
+
+			return nil, fmt.Errorf("failed to get inventory of storage %s: %w", redfishStorage.ID, err)
+		}
+
+		subsystems = append(subsystems, subsystem)
+	}
+
+	return subsystems, nil
+}
+
+// SecureEraseDrive starts the SecureErase action of the drive at driveURI, which removes all data from the drive. The
+// URI of the task tracking the erase is returned, see WaitForTask. It is empty if the BMC erased the drive before
+// responding.
+func (bmc *BMC) SecureEraseDrive(driveURI string) (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Securely erasing drive %s", driveURI)
+
+	if driveURI == "" {
+		klog.V(100).Info("The drive URI is empty")
+
+		return "", fmt.Errorf("drive 'uri' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	drive, err := redfish.GetDrive(redfishClient, driveURI)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish drive %s: %v", driveURI, err)
+
+		return "", fmt.Errorf("failed to get drive %s: %w", driveURI, err)
+	}
+
+	// gofish discards the response of SecureErase, which holds the task, so the action is posted directly.
+	var actions struct {
+		Actions struct {
+			SecureErase common.ActionTarget `json:"#Drive.SecureErase"`
+		}
+	}
+
+	err = json.Unmarshal(drive.RawData, &actions)
+	if err != nil || actions.Actions.SecureErase.Target == "" {
+		klog.V(100).Infof("The drive %s does not support SecureErase", driveURI)
+
+		return "", fmt.Errorf("drive %s does not support SecureErase", driveURI)
+	}
+
+	response, err := redfishClient.Post(actions.Actions.SecureErase.Target, struct{}{})
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to start SecureErase of drive %s: %v", driveURI, err)
+
+		return "", fmt.Errorf("failed to start secure erase of drive %s: %w", driveURI, err)
+	}
+
+	return redfishGetStorageTaskURI(response)
+}
+
+// DeleteVolume deletes the volume at volumeURI, destroying its data. The URI of the task tracking the deletion is
+// returned, see WaitForTask. It is empty if the BMC deleted the volume before responding.
+func (bmc *BMC) DeleteVolume(volumeURI string) (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Deleting volume %s", volumeURI)
+
+	if volumeURI == "" {
+		klog.V(100).Info("The volume URI is empty")
+
+		return "", fmt.Errorf("volume 'uri' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	response, err := redfishClient.Delete(volumeURI)
+	if err != nil {
+		err = describeRedfishError(err)
+
+		klog.V(100).Infof("Failed to delete volume %s: %v", volumeURI, err)
+
+		return "", fmt.Errorf("failed to delete volume %s: %w", volumeURI, err)
+	}
+
+	return redfishGetStorageTaskURI(response)
+}
+
+// newStorageSubsystem converts the Redfish storage, getting its drives and volumes.
+func newStorageSubsystem(redfishStorage *redfish.Storage) (StorageSubsystem, error) {
+	subsystem := StorageSubsystem{
+		ID:   redfishStorage.ID,
+		Name: redfishStorage.Name,
+	}
+
+	redfishDrives, err := redfishStorage.Drives()
+	if err != nil {
+		return StorageSubsystem{}, fmt.Errorf("failed to get drives: %w", err)
+	}
+
+	for _, redfishDrive := range redfishDrives {
+		subsystem.Drives = append(subsystem.Drives, StorageDrive{
+			URI:           redfishDrive.ODataID,
+			ID:            redfishDrive.ID,
+			Name:          redfishDrive.Name,
+			Manufacturer:  redfishDrive.Manufacturer,
+			Model:         redfishDrive.Model,
+			SerialNumber:  redfishDrive.SerialNumber,
+			WWN:           identifiersWWN(redfishDrive.Identifiers),
+			CapacityBytes: redfishDrive.CapacityBytes,
+			MediaType:     redfishDrive.MediaType,
+			Protocol:      redfishDrive.Protocol,
+			Health:        redfishDrive.Status.Health,
+		})
+	}
+
+	slices.SortFunc(subsystem.Drives, func(a, b StorageDrive) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	redfishVolumes, err := redfishStorage.Volumes()
+	if err != nil {
+		return StorageSubsystem{}, fmt.Errorf("failed to get volumes: %w", err)
+	}
+
+	for _, redfishVolume := range redfishVolumes {
+		volume, err := newStorageVolume(redfishVolume)
+		if err != nil {
+			return StorageSubsystem{}, err
+		}
+
+		subsystem.Volumes = append(subsystem.Volumes, volume)
+	}
+
+	slices.SortFunc(subsystem.Volumes, func(a, b StorageVolume) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return subsystem, nil
+}
+
+// newStorageVolume converts the Redfish volume, getting the IDs of its drives.
+func newStorageVolume(redfishVolume *redfish.Volume) (StorageVolume, error) {
+	volume := StorageVolume{
+		URI:           redfishVolume.ODataID,
+		ID:            redfishVolume.ID,
+		Name:          redfishVolume.Name,
+		CapacityBytes: redfishVolume.CapacityBytes,
+		RAIDType:      redfishVolume.RAIDType,
+		Health:        redfishVolume.Status.Health,
+	}
+
+	redfishDrives, err := redfishVolume.Drives()
+	if err != nil {
+		return StorageVolume{}, fmt.Errorf("failed to get drives of volume %s: %w", redfishVolume.ID, err)
+	}
+
+	for _, redfishDrive := range redfishDrives {
+		volume.Drives = append(volume.Drives, redfishDrive.ID)
+	}
+
+	slices.Sort(volume.Drives)
+
+	return volume, nil
+}
+
+// identifiersWWN returns the world wide name among the Redfish durable identifiers in the form Linux reports it, or
+// an empty string if there is no NAA or EUI identifier.
+func identifiersWWN(identifiers []common.Identifier) string {
+	for _, identifier := range identifiers {
+		name := strings.ToLower(strings.ReplaceAll(identifier.DurableName, ":", ""))
+		if name == "" {
+			continue
+		}
+
+		switch identifier.DurableNameFormat {
+		case common.NAADurableNameFormat:
+			return "0x" + strings.TrimPrefix(name, "0x")
+		case common.EUIDurableNameFormat:
+			return "eui." + strings.TrimPrefix(name, "eui.")
+		}
+	}
+
+	return ""
+}
+
+// redfishGetStorageTaskURI returns the URI of the task created by a storage request and closes its body. The URI is
+// empty if the BMC completed the request synchronously.
+func redfishGetStorageTaskURI(response *http.Response) (string, error) {
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusAccepted {
+		return "", nil
+	}
+
+	taskURI, err := getTaskURIFromResponse(response)
+	if err != nil {
+		klog.V(100).Infof("Failed to get storage task: %v", err)
+
+		return "", fmt.Errorf("failed to get storage task: %w", err)
+	}
+
+	return taskURI, nil
+}
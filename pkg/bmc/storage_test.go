@@ -0,0 +1,237 @@
+package bmc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeRedfishRAIDPath   = fakeRedfishSystemPath + "/Storage/RAID.SL.1-1"
+	fakeRedfishNVMePath   = fakeRedfishSystemPath + "/Storage/CPU.1"
+	fakeRedfishSSDPath    = fakeRedfishRAIDPath + "/Drives/Disk.Bay.0"
+	fakeRedfishHDDPath    = fakeRedfishRAIDPath + "/Drives/Disk.Bay.1"
+	fakeRedfishVolumePath = fakeRedfishRAIDPath + "/Volumes/Disk.Virtual.0"
+	fakeRedfishJobPath    = "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_002"
+
+	// redfishVolumeInUseJSONResponse is the error the fake storage returns when deleting the Disk.Virtual.1 volume.
+	redfishVolumeInUseJSONResponse = `{"error": {"code": "Base.1.8.GeneralError", "message": "A general error has occurred.",
+		"@Message.ExtendedInfo": [{"MessageId": "STOR023", "Message": "The virtual disk is in use.",
+		"Resolution": "Clear the foreign configuration and retry the operation.", "Severity": "Warning"}]}}`
+)
+
+func TestBMCStorageInventory(t *testing.T) {
+	redfishServer := createFakeRedfishStorageServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	subsystems, err := bmc.StorageInventory()
+	assert.Nil(t, err)
+	assert.Equal(t, []StorageSubsystem{
+		{
+			ID:   "CPU.1",
+			Name: "CPU.1",
+			Drives: []StorageDrive{{
+				URI:           fakeRedfishNVMePath + "/Drives/Disk.Bay.8",
+				ID:            "Disk.Bay.8",
+				Model:         "Dell Ent NVMe P5600 MU U.2 1.6TB",
+				SerialNumber:  "PHAB123400AB1P6AGN",
+				WWN:           "eui.0025388b91b2e4a1",
+				CapacityBytes: 1600321314816,
+				MediaType:     redfish.SSDMediaType,
+				Protocol:      common.NVMeProtocol,
+				Health:        common.OKHealth,
+			}},
+		},
+		{
+			ID:   "RAID.SL.1-1",
+			Name: "PERC H755 Front",
+			Drives: []StorageDrive{
+				{
+					URI:           fakeRedfishSSDPath,
+					ID:            "Disk.Bay.0",
+					Manufacturer:  "SEAGATE",
+					Model:         "ST480FN0021",
+					SerialNumber:  "7FA0A01Y",
+					WWN:           "0x5000c500a1b2c3d4",
+					CapacityBytes: 479559942144,
+					MediaType:     redfish.SSDMediaType,
+					Protocol:      common.SASProtocol,
+					Health:        common.OKHealth,
+				},
+				{
+					URI:           fakeRedfishHDDPath,
+					ID:            "Disk.Bay.1",
+					SerialNumber:  "WBN0AB12",
+					CapacityBytes: 1200243695616,
+					MediaType:     redfish.HDDMediaType,
+					Protocol:      common.SASProtocol,
+					Health:        common.WarningHealth,
+				},
+			},
+			Volumes: []StorageVolume{{
+				URI:           fakeRedfishVolumePath,
+				ID:            "Disk.Virtual.0",
+				Name:          "root",
+				CapacityBytes: 479559942144,
+				RAIDType:      redfish.RAID1RAIDType,
+				Drives:        []string{"Disk.Bay.0", "Disk.Bay.1"},
+				Health:        common.OKHealth,
+			}},
+		},
+	}, subsystems)
+
+	_, err = New(host).StorageInventory()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCSecureEraseDrive(t *testing.T) {
+	redfishServer := createFakeRedfishStorageServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	taskURI, err := bmc.SecureEraseDrive(fakeRedfishSSDPath)
+	assert.Nil(t, err)
+	assert.Equal(t, fakeRedfishJobPath, taskURI)
+	assert.Nil(t, bmc.WaitForTask(taskURI, time.Second, nil))
+
+	_, err = bmc.SecureEraseDrive(fakeRedfishHDDPath)
+	assert.EqualError(t, err, "drive "+fakeRedfishHDDPath+" does not support SecureErase")
+
+	_, err = bmc.SecureEraseDrive("")
+	assert.EqualError(t, err, "drive 'uri' cannot be empty")
+}
+
+func TestBMCDeleteVolume(t *testing.T) {
+	redfishServer := createFakeRedfishStorageServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	taskURI, err := bmc.DeleteVolume(fakeRedfishVolumePath)
+	assert.Nil(t, err)
+	assert.Empty(t, taskURI)
+
+	_, err = bmc.DeleteVolume(fakeRedfishRAIDPath + "/Volumes/Disk.Virtual.1")
+	assert.EqualError(t, err, "failed to delete volume "+fakeRedfishRAIDPath+"/Volumes/Disk.Virtual.1: status 400: "+
+		"The virtual disk is in use. (resolution: Clear the foreign configuration and retry the operation.)")
+
+	_, err = bmc.DeleteVolume("")
+	assert.EqualError(t, err, "volume 'uri' cannot be empty")
+}
+
+// createFakeRedfishStorageServer creates a fake Redfish server whose system has a RAID controller with an SSD that
+// supports SecureErase and an HDD mirrored in a volume, and an NVMe drive attached to the CPU. Deleting the
+// Disk.Virtual.1 volume fails.
+func createFakeRedfishStorageServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	resources := map[string]any{
+		fakeRedfishSystemPath + "/Storage": fakeRedfishCollection(fakeRedfishRAIDPath, fakeRedfishNVMePath),
+		fakeRedfishRAIDPath: map[string]any{
+			"@odata.id": fakeRedfishRAIDPath,
+			"Id":        "RAID.SL.1-1",
+			"Name":      "PERC H755 Front",
+			"Drives":    []any{map[string]any{"@odata.id": fakeRedfishHDDPath}, map[string]any{"@odata.id": fakeRedfishSSDPath}},
+			"Volumes":   map[string]any{"@odata.id": fakeRedfishRAIDPath + "/Volumes"},
+		},
+		fakeRedfishSSDPath: map[string]any{
+			"@odata.id":     fakeRedfishSSDPath,
+			"Id":            "Disk.Bay.0",
+			"Manufacturer":  "SEAGATE",
+			"Model":         "ST480FN0021",
+			"SerialNumber":  "7FA0A01Y",
+			"CapacityBytes": 479559942144,
+			"MediaType":     "SSD",
+			"Protocol":      "SAS",
+			"Identifiers":   []any{map[string]any{"DurableName": "5000C500A1B2C3D4", "DurableNameFormat": "NAA"}},
+			"Status":        map[string]any{"Health": "OK", "State": "Enabled"},
+			"Actions": map[string]any{
+				"#Drive.SecureErase": map[string]any{"target": fakeRedfishSSDPath + "/Actions/Drive.SecureErase"},
+			},
+		},
+		fakeRedfishHDDPath: map[string]any{
+			"@odata.id":     fakeRedfishHDDPath,
+			"Id":            "Disk.Bay.1",
+			"SerialNumber":  "WBN0AB12",
+			"CapacityBytes": 1200243695616,
+			"MediaType":     "HDD",
+			"Protocol":      "SAS",
+			"Identifiers":   []any{map[string]any{"DurableName": "", "DurableNameFormat": "NAA"}},
+			"Status":        map[string]any{"Health": "Warning", "State": "Enabled"},
+		},
+		fakeRedfishRAIDPath + "/Volumes": fakeRedfishCollection(fakeRedfishVolumePath),
+		fakeRedfishVolumePath: map[string]any{
+			"@odata.id":     fakeRedfishVolumePath,
+			"Id":            "Disk.Virtual.0",
+			"Name":          "root",
+			"CapacityBytes": 479559942144,
+			"RAIDType":      "RAID1",
+			"Status":        map[string]any{"Health": "OK", "State": "Enabled"},
+			"Links": map[string]any{
+				"Drives": []any{map[string]any{"@odata.id": fakeRedfishHDDPath}, map[string]any{"@odata.id": fakeRedfishSSDPath}},
+			},
+		},
+		fakeRedfishNVMePath: map[string]any{
+			"@odata.id": fakeRedfishNVMePath,
+			"Id":        "CPU.1",
+			"Name":      "CPU.1",
+			"Drives":    []any{map[string]any{"@odata.id": fakeRedfishNVMePath + "/Drives/Disk.Bay.8"}},
+		},
+		fakeRedfishNVMePath + "/Drives/Disk.Bay.8": map[string]any{
+			"@odata.id":     fakeRedfishNVMePath + "/Drives/Disk.Bay.8",
+			"Id":            "Disk.Bay.8",
+			"Model":         "Dell Ent NVMe P5600 MU U.2 1.6TB",
+			"SerialNumber":  "PHAB123400AB1P6AGN",
+			"CapacityBytes": 1600321314816,
+			"MediaType":     "SSD",
+			"Protocol":      "NVMe",
+			"Identifiers":   []any{map[string]any{"DurableName": "00:25:38:8B:91:B2:E4:A1", "DurableNameFormat": "EUI"}},
+			"Status":        map[string]any{"Health": "OK", "State": "Enabled"},
+		},
+		fakeRedfishJobPath: map[string]any{
+			"@odata.id":       fakeRedfishJobPath,
+			"@odata.type":     "#Task.v1_5_1.Task",
+			"Id":              "JID_002",
+			"TaskState":       "Completed",
+			"TaskStatus":      "OK",
+			"PercentComplete": 100,
+		},
+	}
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+
+	for path, resource := range resources {
+		mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			writeFakeRedfishJSON(t, w, resource)
+		})
+	}
+
+	mux.HandleFunc("POST "+fakeRedfishSSDPath+"/Actions/Drive.SecureErase", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://"+r.Host+fakeRedfishJobPath)
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("DELETE "+fakeRedfishVolumePath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("DELETE "+fakeRedfishRAIDPath+"/Volumes/Disk.Virtual.1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(redfishVolumeInUseJSONResponse))
+	})
+
+	return startFakeRedfishLocalServer(mux)
+}
@@ -0,0 +1,227 @@
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// RedfishTask holds the progress of a Redfish task, such as the one created for a firmware update.
+type RedfishTask struct {
+	// URI is the path of the task resource.
+	URI string
+	// State is the state of the task, such as Running or Completed.
+	State redfish.TaskState
+	// Status is the health of the task, set once it finishes.
+	Status common.Health
+	// PercentComplete is the progress of the task reported by the BMC.
+	PercentComplete int
+	// Messages are the messages the BMC associated with the task, oldest first.
+	Messages []string
+}
+
+// IsFinished returns whether the task reached a state it will not leave.
+func (task *RedfishTask) IsFinished() bool {
+	switch task.State {
+	case redfish.CompletedTaskState, redfish.ExceptionTaskState, redfish.KilledTaskState, redfish.CancelledTaskState:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSucceeded returns whether the task completed, possibly with warnings.
+func (task *RedfishTask) IsSucceeded() bool {
+	return task.State == redfish.CompletedTaskState
+}
+
+// RedfishTaskProgressFunc is called with the task each time WaitForTask polls it.
+type RedfishTaskProgressFunc func(task RedfishTask)
+
+// GetTask returns the current state of the Redfish task at taskURI.
+func (bmc *BMC) GetTask(taskURI string) (*RedfishTask, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting task %s from bmc's redfish endpoint", taskURI)
+
+	if taskURI == "" {
+		klog.V(100).Info("The task URI is empty")
+
+		return nil, fmt.Errorf("task 'uri' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	return redfishGetTask(redfishClient, taskURI)
+}
+
+// WaitForTask waits up to timeout until the Redfish task at taskURI finishes. If progress is not nil, it is called
+// with the task every time it is polled. An error is returned if the task does not complete successfully, including
+// the messages of the task. A single Redfish session is used for all polls, with the Redfish timeout applying to each
+// request. Errors getting the task are ignored and the session is reopened on the next poll since the BMC may restart
+// while applying an update.
+func (bmc *BMC) WaitForTask(taskURI string, timeout time.Duration, progress RedfishTaskProgressFunc) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting up to %s until task %s finishes", timeout, taskURI)
+
+	if taskURI == "" {
+		klog.V(100).Info("The task URI is empty")
+
+		return fmt.Errorf("task 'uri' cannot be empty")
+	}
+
+	var (
+		lastTask      *RedfishTask
+		redfishClient *gofish.APIClient
+		cancel        context.CancelFunc
+	)
+
+	disconnect := func() {
+		if redfishClient != nil {
+			redfishClient.Logout()
+			cancel()
+
+			redfishClient = nil
+		}
+	}
+
+	defer disconnect()
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if redfishClient == nil {
+				var err error
+
+				redfishClient, cancel, err = redfishConnectSession(
+					bmc.host,
+					bmc.redfishUser.Name,
+					bmc.redfishUser.Password,
+					bmc.timeOuts.Redfish)
+				if err != nil {
+					klog.V(100).Infof("Redfish connection error: %v", err)
+
+					redfishClient = nil
+
+					return false, nil
+				}
+			}
+
+			task, err := redfishGetTask(redfishClient, taskURI)
+			if err != nil {
+				klog.V(100).Infof("Failed to get task %s: %v", taskURI, err)
+
+				// The session may have been lost if the BMC restarted, so open a new one on the next poll.
+				disconnect()
+
+				return false, nil
+			}
+
+			lastTask = task
+
+			if progress != nil {
+				progress(*task)
+			}
+
+			return task.IsFinished(), nil
+		})
+	if err != nil {
+		if lastTask == nil {
+			klog.V(100).Infof("Failed to get task %s within %s: %v", taskURI, timeout, err)
+
+			return fmt.Errorf("failed to get task %s within %s: %w", taskURI, timeout, err)
+		}
+
+		klog.V(100).Infof("Task %s did not finish within %s, last state %s", taskURI, timeout, lastTask.State)
+
+		return fmt.Errorf("task %s did not finish within %s (state: %s, percent complete: %d): %w",
+			taskURI, timeout, lastTask.State, lastTask.PercentComplete, err)
+	}
+
+	if !lastTask.IsSucceeded() {
+		klog.V(100).Infof("Task %s finished in state %s: %v", taskURI, lastTask.State, lastTask.Messages)
+
+		return fmt.Errorf("task %s finished in state %s: %s", taskURI, lastTask.State, strings.Join(lastTask.Messages, "; "))
+	}
+
+	return nil
+}
+
+// redfishGetTask gets the Redfish task at taskURI using the provided client.
+func redfishGetTask(redfishClient *gofish.APIClient, taskURI string) (*RedfishTask, error) {
+	task, err := redfish.GetTask(redfishClient, taskURI)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish task %s: %v", taskURI, err)
+
+		return nil, fmt.Errorf("failed to get task %s: %w", taskURI, err)
+	}
+
+	redfishTask := &RedfishTask{
+		URI:             taskURI,
+		State:           task.TaskState,
+		Status:          task.TaskStatus,
+		PercentComplete: task.PercentComplete,
+	}
+
+	for _, message := range task.Messages {
+		redfishTask.Messages = append(redfishTask.Messages, message.Message)
+	}
+
+	return redfishTask, nil
+}
+
+// getTaskURIFromResponse returns the path of the task the BMC created for an asynchronous request. The task is taken
+// from the response body if it is a Task resource, otherwise from the Location header.
+func getTaskURIFromResponse(response *http.Response) (string, error) {
+	var body struct {
+		ODataID   string `json:"@odata.id"`
+		ODataType string `json:"@odata.type"`
+	}
+
+	// The body is optional, so decoding errors only mean the task must come from the Location header.
+	_ = json.NewDecoder(response.Body).Decode(&body)
+
+	if body.ODataID != "" && strings.HasPrefix(body.ODataType, "#Task.") {
+		return body.ODataID, nil
+	}
+
+	location := response.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("response with status %d has no task", response.StatusCode)
+	}
+
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse task location %s: %w", location, err)
+	}
+
+	return locationURL.Path, nil
+}
@@ -0,0 +1,517 @@
+package bmc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// VirtualMediaSlot holds the state of a virtual media slot as reported by the Redfish API.
+type VirtualMediaSlot struct {
+	// ID is the identifier of the slot. Managers can each have a slot with the same ID.
+	ID string
+	// ODataID is the Redfish path of the slot, which identifies it across the system and its managers.
+	ODataID string
+	// Name is the display name of the slot.
+	Name string
+	// MediaTypes are the media types the slot reports as supported. Some BMCs leave this empty.
+	MediaTypes []redfish.VirtualMediaType
+	// Inserted is true if media is currently inserted in the slot.
+	Inserted bool
+	// Image is the URL of the inserted image, if any.
+	Image string
+	// ImageName is the name of the inserted image, if any.
+	ImageName string
+	// WriteProtected is true if the inserted media is read only.
+	WriteProtected bool
+	// ConnectedVia is how the media is connected, such as URI or Applet.
+	ConnectedVia redfish.ConnectedVia
+}
+
+// SupportsMediaType returns whether the slot can be used for the provided media type. CD and DVD are treated as
+// interchangeable since BMCs differ in which of the two they report for ISO images. A slot that does not report its
+// media types is assumed to support all of them.
+func (slot *VirtualMediaSlot) SupportsMediaType(mediaType redfish.VirtualMediaType) bool {
+	return virtualMediaSupportsType(slot.MediaTypes, mediaType)
+}
+
+// VirtualMedia returns the virtual media slots of the system, sorted by ID. Slots are read from the system if it
+// links them, otherwise from the managers of the system, which is where older BMCs expose them.
+func (bmc *BMC) VirtualMedia() ([]VirtualMediaSlot, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Info("Getting virtual media slots from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	var slots []VirtualMediaSlot
+
+	for _, media := range virtualMedia {
+		slots = append(slots, newVirtualMediaSlot(media))
+	}
+
+	return slots, nil
+}
+
+// VirtualMediaStatus returns the state of the virtual media slot with the provided ID. Here and in the other virtual
+// media methods, the slot can be identified by its Redfish path or, if no other slot shares it, by its ID.
+func (bmc *BMC) VirtualMediaStatus(virtualMediaID string) (*VirtualMediaSlot, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting status of virtual media %s from bmc's redfish endpoint", virtualMediaID)
+
+	if virtualMediaID == "" {
+		klog.V(100).Info("The virtual media ID is empty")
+
+		return nil, fmt.Errorf("virtual media 'id' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	media, err := redfishGetVirtualMediaByID(redfishClient, bmc.systemSelector(), virtualMediaID)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish virtual media %s: %v", virtualMediaID, err)
+
+		return nil, err
+	}
+
+	slot := newVirtualMediaSlot(media)
+
+	return &slot, nil
+}
+
+// InsertMedia inserts the image at imageURL into a virtual media slot without changing the boot order. If
+// virtualMediaID is empty, the first slot supporting mediaType is used. Media already inserted in the slot is ejected
+// first, since some BMCs reject inserting into an occupied slot. CD and DVD images are inserted write protected. The
+// Redfish path of the slot used is returned.
+func (bmc *BMC) InsertMedia(
+	virtualMediaID, imageURL string, mediaType redfish.VirtualMediaType) (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Inserting %s image %s into virtual media %q", mediaType, imageURL, virtualMediaID)
+
+	if imageURL == "" {
+		klog.V(100).Info("The virtual media image URL is empty")
+
+		return "", fmt.Errorf("virtual media 'imageURL' cannot be empty")
+	}
+
+	if mediaType == "" {
+		klog.V(100).Info("The virtual media type is empty")
+
+		return "", fmt.Errorf("virtual media 'mediaType' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return "", fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	media, err := selectVirtualMedia(virtualMedia, virtualMediaID, mediaType)
+	if err != nil {
+		klog.V(100).Infof("Failed to select virtual media: %v", err)
+
+		return "", err
+	}
+
+	if media.Inserted {
+		klog.V(100).Infof("Ejecting image %s from virtual media %s before inserting", media.Image, media.ID)
+
+		err = redfishEjectVirtualMedia(media)
+		if err != nil {
+			klog.V(100).Infof("Failed to eject virtual media %s: %v", media.ID, err)
+
+			return "", fmt.Errorf("failed to eject virtual media %s: %w", media.ID, err)
+		}
+
+		// Refresh the virtual media so a patch based insert compares against the ejected state.
+		media, err = redfish.GetVirtualMedia(redfishClient, media.ODataID)
+		if err != nil {
+			klog.V(100).Infof("Failed to refresh virtual media %s: %v", media.ID, err)
+
+			return "", fmt.Errorf("failed to refresh virtual media: %w", err)
+		}
+	}
+
+	writeProtected := isOpticalMediaType(mediaType)
+
+	if media.SupportsMediaInsert {
+		err = media.InsertMedia(imageURL, true, writeProtected)
+	} else {
+		klog.V(100).Infof("Virtual media %s has no InsertMedia action, patching it instead", media.ID)
+
+		media.Image = imageURL
+		media.Inserted = true
+		media.WriteProtected = writeProtected
+		err = media.Update()
+	}
+
+	if err != nil {
+		klog.V(100).Infof("Failed to insert virtual media %s: %v", media.ID, err)
+
+		return "", fmt.Errorf("failed to insert virtual media %s: %w", media.ID, err)
+	}
+
+	return media.ODataID, nil
+}
+
+// EjectMedia ejects the media inserted in the virtual media slot with the provided ID. Ejecting an empty slot is not
+// an error.
+func (bmc *BMC) EjectMedia(virtualMediaID string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Ejecting virtual media %s", virtualMediaID)
+
+	if virtualMediaID == "" {
+		klog.V(100).Info("The virtual media ID is empty")
+
+		return fmt.Errorf("virtual media 'id' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	media, err := redfishGetVirtualMediaByID(redfishClient, bmc.systemSelector(), virtualMediaID)
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish virtual media %s: %v", virtualMediaID, err)
+
+		return err
+	}
+
+	if !media.Inserted {
+		klog.V(100).Infof("Virtual media %s has no media inserted", virtualMediaID)
+
+		return nil
+	}
+
+	err = redfishEjectVirtualMedia(media)
+	if err != nil {
+		klog.V(100).Infof("Failed to eject virtual media %s: %v", virtualMediaID, err)
+
+		return fmt.Errorf("failed to eject virtual media %s: %w", virtualMediaID, err)
+	}
+
+	return nil
+}
+
+// EjectAllMedia ejects the media inserted in every virtual media slot of the system, leaving all of them empty.
+func (bmc *BMC) EjectAllMedia() error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Info("Ejecting all virtual media")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		klog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, bmc.systemSelector())
+	if err != nil {
+		klog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	for _, media := range virtualMedia {
+		if !media.Inserted {
+			continue
+		}
+
+		err = redfishEjectVirtualMedia(media)
+		if err != nil {
+			klog.V(100).Infof("Failed to eject virtual media %s: %v", media.ID, err)
+
+			return fmt.Errorf("failed to eject virtual media %s: %w", media.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// WaitForMediaInserted waits up to timeout until the virtual media slot with the provided ID reports media inserted.
+// If imageURL is not empty, the inserted image must also match it.
+func (bmc *BMC) WaitForMediaInserted(virtualMediaID, imageURL string, timeout time.Duration) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting up to %s until virtual media %s has media inserted", timeout, virtualMediaID)
+
+	if virtualMediaID == "" {
+		klog.V(100).Info("The virtual media ID is empty")
+
+		return fmt.Errorf("virtual media 'id' cannot be empty")
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 10*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			slot, err := bmc.VirtualMediaStatus(virtualMediaID)
+			if err != nil {
+				klog.V(100).Infof("Failed to get status of virtual media %s: %v", virtualMediaID, err)
+
+				return false, nil
+			}
+
+			if !slot.Inserted {
+				return false, nil
+			}
+
+			return imageURL == "" || slot.Image == imageURL, nil
+		})
+}
+
+// redfishGetVirtualMedia gets the virtual media of the system with the provided index, sorted by ID. If the system
+// does not link any virtual media, the virtual media of its managers are returned instead.
+func redfishGetVirtualMedia(redfishClient *gofish.APIClient, selector systemSelector) ([]*redfish.VirtualMedia, error) {
+	system, err := redfishGetSystem(redfishClient, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	virtualMedia, err := system.VirtualMedia()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system virtual media: %w", err)
+	}
+
+	if len(virtualMedia) == 0 {
+		managers, err := system.ManagedBy()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system managers: %w", err)
+		}
+
+		for _, manager := range managers {
+			managerMedia, err := manager.VirtualMedia()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get virtual media of manager %s: %w", manager.ID, err)
+			}
+
+			virtualMedia = append(virtualMedia, managerMedia...)
+		}
+	}
+
+	slices.SortFunc(virtualMedia, func(a, b *redfish.VirtualMedia) int {
+		return cmp.Or(strings.Compare(a.ID, b.ID), strings.Compare(a.ODataID, b.ODataID))
+	})
+
+	return virtualMedia, nil
+}
+
+// redfishGetVirtualMediaByID gets the virtual media with the provided path or ID from the system with the provided
+// index, see findVirtualMedia.
+func redfishGetVirtualMediaByID(
+	redfishClient *gofish.APIClient, selector systemSelector, virtualMediaID string) (*redfish.VirtualMedia, error) {
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	return findVirtualMedia(virtualMedia, virtualMediaID)
+}
+
+// findVirtualMedia returns the virtual media whose Redfish path is virtualMediaID or, if there is none, the only one
+// whose ID is virtualMediaID. Managers can each have a slot with the same ID, in which case the path must be used.
+func findVirtualMedia(virtualMedia []*redfish.VirtualMedia, virtualMediaID string) (*redfish.VirtualMedia, error) {
+	var (
+		matches []*redfish.VirtualMedia
+		paths   []string
+	)
+
+	for _, media := range virtualMedia {
+		if media.ODataID == virtualMediaID {
+			return media, nil
+		}
+
+		if media.ID == virtualMediaID {
+			matches = append(matches, media)
+			paths = append(paths, media.ODataID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("virtual media %s not found", virtualMediaID)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("virtual media %s matches several slots, use one of their paths: %s",
+			virtualMediaID, strings.Join(paths, ", "))
+	}
+}
+
+// redfishEjectVirtualMedia ejects the media using the EjectMedia action, falling back to patching the Image and
+// Inserted properties for BMCs that do not provide the action.
+func redfishEjectVirtualMedia(media *redfish.VirtualMedia) error {
+	if media.SupportsMediaEject {
+		return media.EjectMedia()
+	}
+
+	media.Image = ""
+	media.Inserted = false
+
+	return media.Update()
+}
+
+// selectVirtualMedia returns the virtual media with the provided path or ID, see findVirtualMedia, or the first one
+// supporting mediaType if it is empty. An error is returned if the selected virtual media does not support mediaType.
+func selectVirtualMedia(
+	virtualMedia []*redfish.VirtualMedia,
+	virtualMediaID string,
+	mediaType redfish.VirtualMediaType) (*redfish.VirtualMedia, error) {
+	if virtualMediaID != "" {
+		media, err := findVirtualMedia(virtualMedia, virtualMediaID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !virtualMediaSupportsType(media.MediaTypes, mediaType) {
+			return nil, fmt.Errorf("virtual media %s does not support media type %s (supported: %v)",
+				virtualMediaID, mediaType, media.MediaTypes)
+		}
+
+		return media, nil
+	}
+
+	for _, media := range virtualMedia {
+		if virtualMediaSupportsType(media.MediaTypes, mediaType) {
+			return media, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no virtual media slot supports media type %s", mediaType)
+}
+
+// virtualMediaSupportsType returns whether mediaType is in supportedTypes. CD and DVD are interchangeable and empty
+// supportedTypes are assumed to support all media types.
+func virtualMediaSupportsType(
+	supportedTypes []redfish.VirtualMediaType, mediaType redfish.VirtualMediaType) bool {
+	if len(supportedTypes) == 0 {
+		return true
+	}
+
+	for _, supportedType := range supportedTypes {
+		if supportedType == mediaType {
+			return true
+		}
+
+		if isOpticalMediaType(supportedType) && isOpticalMediaType(mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOpticalMediaType returns whether the media type is for an ISO image.
+func isOpticalMediaType(mediaType redfish.VirtualMediaType) bool {
+	return mediaType == redfish.CDMediaType || mediaType == redfish.DVDMediaType
+}
+
+// newVirtualMediaSlot converts the gofish virtual media to a VirtualMediaSlot.
+func newVirtualMediaSlot(media *redfish.VirtualMedia) VirtualMediaSlot {
+	return VirtualMediaSlot{
+		ID:             media.ID,
+		ODataID:        media.ODataID,
+		Name:           media.Name,
+		MediaTypes:     media.MediaTypes,
+		Inserted:       media.Inserted,
+		Image:          media.Image,
+		ImageName:      media.ImageName,
+		WriteProtected: media.WriteProtected,
+		ConnectedVia:   media.ConnectedVia,
+	}
+}
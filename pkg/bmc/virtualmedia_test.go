@@ -0,0 +1,478 @@
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	defaultVirtualMediaPath = "/redfish/v1/Systems/System.Embedded.1/VirtualMedia/"
+	defaultImageURL         = "http://images.example.com/test.iso"
+)
+
+// fakeVirtualMediaSlot holds the mutable state of a virtual media slot on the fake Redfish server.
+type fakeVirtualMediaSlot struct {
+	MediaTypes     []redfish.VirtualMediaType
+	Image          string
+	Inserted       bool
+	WriteProtected bool
+	// actions is false for slots that must be inserted and ejected by patching.
+	actions bool
+}
+
+// fakeVirtualMedia holds the virtual media slots of the fake Redfish server, keyed by ID.
+type fakeVirtualMedia struct {
+	mutex sync.Mutex
+	slots map[string]*fakeVirtualMediaSlot
+}
+
+func TestBMCVirtualMedia(t *testing.T) {
+	redfishServer, _ := createFakeRedfishVirtualMediaServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	slots, err := bmc.VirtualMedia()
+	assert.Nil(t, err)
+	assert.Len(t, slots, 2)
+	assert.Equal(t, "1", slots[0].ID)
+	assert.Equal(t, "2", slots[1].ID)
+	assert.True(t, slots[0].SupportsMediaType(redfish.CDMediaType))
+	assert.False(t, slots[0].SupportsMediaType(redfish.USBStickMediaType))
+	assert.True(t, slots[1].SupportsMediaType(redfish.USBStickMediaType))
+}
+
+func TestBMCVirtualMediaStatus(t *testing.T) {
+	redfishServer, _ := createFakeRedfishVirtualMediaServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+
+	testCases := []struct {
+		virtualMediaID    string
+		expectedErrorText string
+	}{
+		{
+			virtualMediaID:    "1",
+			expectedErrorText: "",
+		},
+		{
+			virtualMediaID:    "",
+			expectedErrorText: "virtual media 'id' cannot be empty",
+		},
+		{
+			virtualMediaID:    "4",
+			expectedErrorText: "virtual media 4 not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+		slot, err := bmc.VirtualMediaStatus(testCase.virtualMediaID)
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.virtualMediaID, slot.ID)
+		assert.False(t, slot.Inserted)
+	}
+}
+
+func TestBMCInsertMedia(t *testing.T) {
+	testCases := []struct {
+		virtualMediaID    string
+		imageURL          string
+		mediaType         redfish.VirtualMediaType
+		expectedID        string
+		expectedErrorText string
+	}{
+		{
+			virtualMediaID: "1",
+			imageURL:       defaultImageURL,
+			mediaType:      redfish.CDMediaType,
+			expectedID:     "1",
+		},
+		{
+			virtualMediaID: defaultVirtualMediaPath + "1",
+			imageURL:       defaultImageURL,
+			mediaType:      redfish.CDMediaType,
+			expectedID:     "1",
+		},
+		{
+			virtualMediaID: "",
+			imageURL:       defaultImageURL,
+			mediaType:      redfish.DVDMediaType,
+			expectedID:     "1",
+		},
+		{
+			virtualMediaID: "",
+			imageURL:       defaultImageURL,
+			mediaType:      redfish.USBStickMediaType,
+			expectedID:     "2",
+		},
+		{
+			virtualMediaID:    "",
+			imageURL:          "",
+			mediaType:         redfish.CDMediaType,
+			expectedErrorText: "virtual media 'imageURL' cannot be empty",
+		},
+		{
+			virtualMediaID:    "",
+			imageURL:          defaultImageURL,
+			mediaType:         "",
+			expectedErrorText: "virtual media 'mediaType' cannot be empty",
+		},
+		{
+			virtualMediaID:    "1",
+			imageURL:          defaultImageURL,
+			mediaType:         redfish.USBStickMediaType,
+			expectedErrorText: "virtual media 1 does not support media type USBStick (supported: [CD DVD])",
+		},
+		{
+			virtualMediaID:    "",
+			imageURL:          defaultImageURL,
+			mediaType:         redfish.FloppyMediaType,
+			expectedErrorText: "no virtual media slot supports media type Floppy",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, virtualMedia := createFakeRedfishVirtualMediaServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		// Occupy the first slot to check that it is ejected before inserting.
+		virtualMedia.slots["1"].Image = "http://images.example.com/old.iso"
+		virtualMedia.slots["1"].Inserted = true
+
+		virtualMediaPath, err := bmc.InsertMedia(testCase.virtualMediaID, testCase.imageURL, testCase.mediaType)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, defaultVirtualMediaPath+testCase.expectedID, virtualMediaPath)
+
+		slot := virtualMedia.slots[testCase.expectedID]
+		assert.True(t, slot.Inserted)
+		assert.Equal(t, testCase.imageURL, slot.Image)
+		assert.Equal(t, isOpticalMediaType(testCase.mediaType), slot.WriteProtected)
+	}
+}
+
+func TestBMCEjectMedia(t *testing.T) {
+	testCases := []struct {
+		virtualMediaID    string
+		inserted          bool
+		expectedErrorText string
+	}{
+		{
+			virtualMediaID: "1",
+			inserted:       true,
+		},
+		{
+			virtualMediaID: "2",
+			inserted:       true,
+		},
+		{
+			virtualMediaID: "1",
+			inserted:       false,
+		},
+		{
+			virtualMediaID:    "",
+			expectedErrorText: "virtual media 'id' cannot be empty",
+		},
+		{
+			virtualMediaID:    "4",
+			expectedErrorText: "virtual media 4 not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, virtualMedia := createFakeRedfishVirtualMediaServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		if slot, found := virtualMedia.slots[testCase.virtualMediaID]; found && testCase.inserted {
+			slot.Image = defaultImageURL
+			slot.Inserted = true
+		}
+
+		err := bmc.EjectMedia(testCase.virtualMediaID)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.False(t, virtualMedia.slots[testCase.virtualMediaID].Inserted)
+		assert.Empty(t, virtualMedia.slots[testCase.virtualMediaID].Image)
+	}
+}
+
+func TestBMCEjectAllMedia(t *testing.T) {
+	redfishServer, virtualMedia := createFakeRedfishVirtualMediaServer(t)
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	for _, slot := range virtualMedia.slots {
+		slot.Image = defaultImageURL
+		slot.Inserted = true
+	}
+
+	err := bmc.EjectAllMedia()
+	assert.Nil(t, err)
+
+	for _, slot := range virtualMedia.slots {
+		assert.False(t, slot.Inserted)
+	}
+}
+
+func TestBMCWaitForMediaInserted(t *testing.T) {
+	testCases := []struct {
+		imageURL          string
+		inserted          bool
+		expectedErrorText string
+	}{
+		{
+			imageURL: defaultImageURL,
+			inserted: true,
+		},
+		{
+			imageURL: "",
+			inserted: true,
+		},
+		{
+			imageURL:          "http://images.example.com/other.iso",
+			inserted:          true,
+			expectedErrorText: "context deadline exceeded",
+		},
+		{
+			imageURL:          defaultImageURL,
+			inserted:          false,
+			expectedErrorText: "context deadline exceeded",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer, virtualMedia := createFakeRedfishVirtualMediaServer(t)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		if testCase.inserted {
+			virtualMedia.slots["1"].Image = defaultImageURL
+			virtualMedia.slots["1"].Inserted = true
+		}
+
+		err := bmc.WaitForMediaInserted("1", testCase.imageURL, 500*time.Millisecond)
+
+		redfishServer.Close()
+
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestFindVirtualMedia(t *testing.T) {
+	// Both managers have a slot named CD, so only their paths identify them.
+	virtualMedia := []*redfish.VirtualMedia{
+		{Entity: common.Entity{ODataID: "/redfish/v1/Managers/1/VirtualMedia/CD", ID: "CD"}},
+		{Entity: common.Entity{ODataID: "/redfish/v1/Managers/2/VirtualMedia/CD", ID: "CD"}},
+		{Entity: common.Entity{ODataID: "/redfish/v1/Managers/2/VirtualMedia/USB", ID: "USB"}},
+	}
+
+	media, err := findVirtualMedia(virtualMedia, "/redfish/v1/Managers/2/VirtualMedia/CD")
+	assert.Nil(t, err)
+	assert.Equal(t, virtualMedia[1], media)
+
+	media, err = findVirtualMedia(virtualMedia, "USB")
+	assert.Nil(t, err)
+	assert.Equal(t, virtualMedia[2], media)
+
+	_, err = findVirtualMedia(virtualMedia, "CD")
+	assert.EqualError(t, err, "virtual media CD matches several slots, use one of their paths: "+
+		"/redfish/v1/Managers/1/VirtualMedia/CD, /redfish/v1/Managers/2/VirtualMedia/CD")
+
+	_, err = findVirtualMedia(virtualMedia, "Floppy")
+	assert.EqualError(t, err, "virtual media Floppy not found")
+}
+
+func TestVirtualMediaSupportsType(t *testing.T) {
+	testCases := []struct {
+		supportedTypes []redfish.VirtualMediaType
+		mediaType      redfish.VirtualMediaType
+		expected       bool
+	}{
+		{
+			supportedTypes: []redfish.VirtualMediaType{redfish.CDMediaType},
+			mediaType:      redfish.CDMediaType,
+			expected:       true,
+		},
+		{
+			supportedTypes: []redfish.VirtualMediaType{redfish.DVDMediaType},
+			mediaType:      redfish.CDMediaType,
+			expected:       true,
+		},
+		{
+			supportedTypes: []redfish.VirtualMediaType{redfish.USBStickMediaType},
+			mediaType:      redfish.CDMediaType,
+			expected:       false,
+		},
+		{
+			supportedTypes: nil,
+			mediaType:      redfish.USBStickMediaType,
+			expected:       true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, virtualMediaSupportsType(testCase.supportedTypes, testCase.mediaType))
+	}
+}
+
+// createFakeRedfishVirtualMediaServer creates a fake Redfish server whose virtual media slots keep their state. Slot
+// 1 supports CD and DVD with the InsertMedia and EjectMedia actions, while slot 2 supports USB sticks and has no
+// actions, so it must be patched.
+func createFakeRedfishVirtualMediaServer(t *testing.T) (*httptest.Server, *fakeVirtualMedia) {
+	t.Helper()
+
+	virtualMedia := &fakeVirtualMedia{slots: map[string]*fakeVirtualMediaSlot{
+		"1": {MediaTypes: []redfish.VirtualMediaType{redfish.CDMediaType, redfish.DVDMediaType}, actions: true},
+		"2": {MediaTypes: []redfish.VirtualMediaType{redfish.USBStickMediaType}},
+	}}
+
+	mux := createFakeRedfishMux(false, redfishAPIResponseCallbacks{})
+
+	for id := range virtualMedia.slots {
+		mux.HandleFunc("GET "+defaultVirtualMediaPath+id, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(virtualMedia.marshal(t, id))
+		})
+
+		mux.HandleFunc("PATCH "+defaultVirtualMediaPath+id, func(w http.ResponseWriter, r *http.Request) {
+			virtualMedia.update(t, id, r)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		mux.HandleFunc("POST "+defaultVirtualMediaPath+id+"/Actions/VirtualMedia.InsertMedia",
+			func(w http.ResponseWriter, r *http.Request) {
+				virtualMedia.update(t, id, r)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+		mux.HandleFunc("POST "+defaultVirtualMediaPath+id+"/Actions/VirtualMedia.EjectMedia",
+			func(w http.ResponseWriter, r *http.Request) {
+				virtualMedia.mutex.Lock()
+				defer virtualMedia.mutex.Unlock()
+
+				virtualMedia.slots[id].Image = ""
+				virtualMedia.slots[id].Inserted = false
+
+				w.WriteHeader(http.StatusNoContent)
+			})
+	}
+
+	return startFakeRedfishLocalServer(mux), virtualMedia
+}
+
+// marshal returns the Redfish VirtualMedia resource for the slot with the provided ID.
+func (virtualMedia *fakeVirtualMedia) marshal(t *testing.T, id string) []byte {
+	t.Helper()
+
+	virtualMedia.mutex.Lock()
+	defer virtualMedia.mutex.Unlock()
+
+	slot := virtualMedia.slots[id]
+	resource := map[string]any{
+		"@odata.id":      defaultVirtualMediaPath + id,
+		"@odata.type":    "#VirtualMedia.v1_6_1.VirtualMedia",
+		"Id":             id,
+		"Name":           fmt.Sprintf("Virtual Media %s", id),
+		"MediaTypes":     slot.MediaTypes,
+		"Image":          slot.Image,
+		"Inserted":       slot.Inserted,
+		"WriteProtected": slot.WriteProtected,
+	}
+
+	if slot.actions {
+		resource["Actions"] = map[string]any{
+			"#VirtualMedia.EjectMedia": map[string]string{
+				"target": defaultVirtualMediaPath + id + "/Actions/VirtualMedia.EjectMedia",
+			},
+			"#VirtualMedia.InsertMedia": map[string]string{
+				"target": defaultVirtualMediaPath + id + "/Actions/VirtualMedia.InsertMedia",
+			},
+		}
+	}
+
+	body, err := json.Marshal(resource)
+	if err != nil {
+		t.Errorf("Failed to marshal virtual media %s: %v", id, err)
+	}
+
+	return body
+}
+
+// update applies the Image, Inserted and WriteProtected properties in the request body to the slot with the
+// provided ID. Properties missing from the body are left unchanged.
+func (virtualMedia *fakeVirtualMedia) update(t *testing.T, id string, r *http.Request) {
+	t.Helper()
+
+	virtualMedia.mutex.Lock()
+	defer virtualMedia.mutex.Unlock()
+
+	var properties struct {
+		Image          *string
+		Inserted       *bool
+		WriteProtected *bool
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&properties)
+	if err != nil {
+		t.Errorf("Failed to decode virtual media %s request: %v", id, err)
+
+		return
+	}
+
+	slot := virtualMedia.slots[id]
+
+	if properties.Image != nil {
+		slot.Image = *properties.Image
+	}
+
+	if properties.Inserted != nil {
+		slot.Inserted = *properties.Inserted
+	}
+
+	if properties.WriteProtected != nil {
+		slot.WriteProtected = *properties.WriteProtected
+	}
+}
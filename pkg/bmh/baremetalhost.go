@@ -2,6 +2,7 @@ package bmh
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,6 +13,7 @@ import (
 	"fmt"
 
 	bmhv1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/msg"
@@ -342,6 +344,33 @@ func (builder *BmhBuilder) WithRootDeviceRotationalDisk(rotational bool) *BmhBui
 	return builder
 }
 
+// WithRootDeviceHintsFromDrive sets rootDeviceHints to the WWN and serial number of the drive, as returned by the
+// BMC storage inventory. Since the wwn hint only holds the first 64 bits of a name, 128-bit NAA 6 names are set as the
+// wwnWithExtension hint instead. All hints must match, so the drive must be exposed to the host directly rather than
+// through a RAID volume. If the drive has neither a WWN nor a serial number, the builder is left with an error.
+func (builder *BmhBuilder) WithRootDeviceHintsFromDrive(drive bmc.StorageDrive) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting rootDeviceHints to drive %s with WWN %s and serial number %s",
+		drive.ID, drive.WWN, drive.SerialNumber)
+
+	switch {
+	case isExtendedNAAWWN(drive.WWN):
+		builder = builder.WithRootDeviceWWNWithExtension(drive.WWN)
+	case drive.WWN != "":
+		builder = builder.WithRootDeviceWWN(drive.WWN)
+	}
+
+	// Setting an empty serial number records the error on the builder when there is no hint at all.
+	if drive.SerialNumber != "" || drive.WWN == "" {
+		builder = builder.WithRootDeviceSerialNumber(drive.SerialNumber)
+	}
+
+	return builder
+}
+
 // WithOptions creates bmh with generic mutation options.
 func (builder *BmhBuilder) WithOptions(options ...AdditionalOptions) *BmhBuilder {
 	if valid, _ := builder.validate(); !valid {
@@ -726,3 +755,8 @@ func (builder *BmhBuilder) validate() (bool, error) {
 
 	return true, nil
 }
+
+// isExtendedNAAWWN returns whether the WWN reported by the BMC is a 128-bit NAA 6 name, which has 32 hex digits.
+func isExtendedNAAWWN(wwn string) bool {
+	return strings.HasPrefix(wwn, "0x") && len(wwn) == len("0x")+32
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	bmhv1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -604,6 +605,42 @@ func TestBareMetalHostWithRootDeviceWWNWithExtension(t *testing.T) {
 	}
 }
 
+func TestBareMetalHostWithRootDeviceHintsFromDrive(t *testing.T) {
+	testCases := []struct {
+		drive         bmc.StorageDrive
+		expectedHints *bmhv1alpha1.RootDeviceHints
+		expectedError string
+	}{
+		{
+			drive:         bmc.StorageDrive{ID: "Disk.Bay.0", WWN: "0x5000c500a1b2c3d4", SerialNumber: "7FA0A01Y"},
+			expectedHints: &bmhv1alpha1.RootDeviceHints{WWN: "0x5000c500a1b2c3d4", SerialNumber: "7FA0A01Y"},
+		},
+		{
+			drive: bmc.StorageDrive{ID: "Disk.Bay.3", WWN: "0x600508b1001c4d4b9f1e2a3b4c5d6e7f", SerialNumber: "PWXKA0AB"},
+			expectedHints: &bmhv1alpha1.RootDeviceHints{
+				WWNWithExtension: "0x600508b1001c4d4b9f1e2a3b4c5d6e7f", SerialNumber: "PWXKA0AB"},
+		},
+		{
+			drive:         bmc.StorageDrive{ID: "Disk.Bay.1", SerialNumber: "WBN0AB12"},
+			expectedHints: &bmhv1alpha1.RootDeviceHints{SerialNumber: "WBN0AB12"},
+		},
+		{
+			drive:         bmc.StorageDrive{ID: "Disk.Bay.2"},
+			expectedError: "the baremetalhost rootDeviceHint serialNumber cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBmHostBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()).
+			WithRootDeviceHintsFromDrive(testCase.drive)
+		assert.Equal(t, testCase.expectedError, testBmHostBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.expectedHints, testBmHostBuilder.Definition.Spec.RootDeviceHints)
+		}
+	}
+}
+
 func TestBareMetalHostWithRootDeviceWWNVendorExtension(t *testing.T) {
 	testCases := []struct {
 		testBmHost                   *BmhBuilder
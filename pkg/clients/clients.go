@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	appsV1Client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	batchV1Client "k8s.io/client-go/kubernetes/typed/batch/v1"
 	networkV1Client "k8s.io/client-go/kubernetes/typed/networking/v1"
 	rbacV1Client "k8s.io/client-go/kubernetes/typed/rbac/v1"
 	"k8s.io/client-go/rest"
@@ -25,12 +26,14 @@ import (
 	imageregistryV1 "github.com/openshift/api/imageregistry/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	agentInstallV1Beta1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/assisted/api/v1beta1"
+	metricsV1Beta1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/metrics/v1beta1"
 	"k8s.io/client-go/kubernetes/scheme"
 	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
 	storageV1Client "k8s.io/client-go/kubernetes/typed/storage/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
 	scalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -55,6 +58,7 @@ type Settings struct {
 	clientConfigV1.ConfigV1Interface
 	networkV1Client.NetworkingV1Interface
 	appsV1Client.AppsV1Interface
+	batchV1Client.BatchV1Interface
 	rbacV1Client.RbacV1Interface
 	Config *rest.Config
 	runtimeClient.Client
@@ -101,6 +105,7 @@ func New(kubeconfig string) *Settings {
 	clientSet.CoreV1Interface = coreV1Client.NewForConfigOrDie(config)
 	clientSet.ConfigV1Interface = clientConfigV1.NewForConfigOrDie(config)
 	clientSet.AppsV1Interface = appsV1Client.NewForConfigOrDie(config)
+	clientSet.BatchV1Interface = batchV1Client.NewForConfigOrDie(config)
 	clientSet.NetworkingV1Interface = networkV1Client.NewForConfigOrDie(config)
 	clientSet.RbacV1Interface = rbacV1Client.NewForConfigOrDie(config)
 	clientSet.Interface = dynamic.NewForConfigOrDie(config)
@@ -169,6 +174,10 @@ func SetScheme(crScheme *runtime.Scheme) error {
 		return err
 	}
 
+	if err := metricsV1Beta1.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -255,6 +264,12 @@ func GetModifiableTestClients(tcp TestClientParams) (*Settings, *fakeRuntimeClie
 			k8sClientObjects = append(k8sClientObjects, v)
 		case *appsv1.ReplicaSet:
 			k8sClientObjects = append(k8sClientObjects, v)
+		case *appsv1.ControllerRevision:
+			k8sClientObjects = append(k8sClientObjects, v)
+		case *batchv1.Job:
+			k8sClientObjects = append(k8sClientObjects, v)
+		case *batchv1.CronJob:
+			k8sClientObjects = append(k8sClientObjects, v)
 		case *corev1.ResourceQuota:
 			k8sClientObjects = append(k8sClientObjects, v)
 		case *corev1.PersistentVolume:
@@ -292,6 +307,10 @@ func GetModifiableTestClients(tcp TestClientParams) (*Settings, *fakeRuntimeClie
 			genericClientObjects = append(genericClientObjects, v)
 		case *agentInstallV1Beta1.AgentServiceConfig:
 			genericClientObjects = append(genericClientObjects, v)
+		case *metricsV1Beta1.PodMetrics:
+			genericClientObjects = append(genericClientObjects, v)
+		case *metricsV1Beta1.NodeMetrics:
+			genericClientObjects = append(genericClientObjects, v)
 		}
 	}
 
@@ -299,6 +318,7 @@ func GetModifiableTestClients(tcp TestClientParams) (*Settings, *fakeRuntimeClie
 	clientSet.K8sClient = k8sFakeClient.NewSimpleClientset(k8sClientObjects...)
 	clientSet.CoreV1Interface = clientSet.K8sClient.CoreV1()
 	clientSet.AppsV1Interface = clientSet.K8sClient.AppsV1()
+	clientSet.BatchV1Interface = clientSet.K8sClient.BatchV1()
 	clientSet.NetworkingV1Interface = clientSet.K8sClient.NetworkingV1()
 	clientSet.RbacV1Interface = clientSet.K8sClient.RbacV1()
 	clientSet.StorageV1Interface = clientSet.K8sClient.StorageV1()
@@ -0,0 +1,486 @@
+package cronjob
+
+import (
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/job"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/msg"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// instantiateAnnotation marks jobs created from a cronjob outside of its schedule, matching kubectl create job
+	// --from.
+	instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+	// manualJobSuffixLength is the length of the random suffix of jobs created by TriggerNow.
+	manualJobSuffixLength = 5
+	// maxJobNameLength is the maximum length of a job name, since it is used as a label value on its pods.
+	maxJobNameLength = 63
+)
+
+// Builder provides struct for cronjob object containing connection to the cluster and the cronjob definitions.
+type Builder struct {
+	// CronJob definition. Used to create the cronjob object.
+	Definition *batchv1.CronJob
+	// Created cronjob object
+	Object *batchv1.CronJob
+	// Used in functions that define or mutate cronjob definition. errorMsg is processed before the cronjob object is
+	// created.
+	errorMsg  string
+	apiClient *clients.Settings
+}
+
+// AdditionalOptions additional options for cronjob object.
+type AdditionalOptions func(builder *Builder) (*Builder, error)
+
+// NewBuilder creates a new instance of Builder. The cronjob runs a job with a single container using the provided
+// image and command on the provided schedule, which uses the standard cron format.
+func NewBuilder(apiClient *clients.Settings, name, nsname, schedule, image string, command []string) *Builder {
+	klog.V(100).Infof(
+		"Initializing new cronjob structure with the following params: name: %s, namespace: %s, schedule: %s, "+
+			"image: %s, command: %v", name, nsname, schedule, image, command)
+
+	if apiClient == nil {
+		klog.V(100).Info("The apiClient is empty, cronjob 'apiClient' cannot be empty")
+
+		return nil
+	}
+
+	builder := &Builder{
+		apiClient: apiClient,
+		Definition: &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: schedule,
+				JobTemplate: batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyNever,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if name == "" {
+		klog.V(100).Info("The name of the cronjob is empty")
+
+		builder.errorMsg = "cronjob 'name' cannot be empty"
+
+		return builder
+	}
+
+	if nsname == "" {
+		klog.V(100).Info("The namespace of the cronjob is empty")
+
+		builder.errorMsg = "cronjob 'namespace' cannot be empty"
+
+		return builder
+	}
+
+	if schedule == "" {
+		klog.V(100).Info("The schedule of the cronjob is empty")
+
+		builder.errorMsg = "cronjob 'schedule' cannot be empty"
+
+		return builder
+	}
+
+	if image == "" {
+		klog.V(100).Info("The image of the cronjob is empty")
+
+		builder.errorMsg = "cronjob 'image' cannot be empty"
+
+		return builder
+	}
+
+	defaultContainer, err := pod.NewContainerBuilder("job", image, command).GetContainerCfg()
+	if err != nil {
+		klog.V(100).Info("Failed to define the default container settings")
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{*defaultContainer}
+
+	return builder
+}
+
+// Pull loads an existing cronjob into the Builder struct.
+func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
+	klog.V(100).Infof("Pulling existing cronjob name: %s under namespace: %s", name, nsname)
+
+	if apiClient == nil {
+		klog.V(100).Info("The apiClient is empty")
+
+		return nil, fmt.Errorf("cronjob 'apiClient' cannot be empty")
+	}
+
+	builder := &Builder{
+		apiClient: apiClient,
+		Definition: &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		klog.V(100).Info("The name of the cronjob is empty")
+
+		return nil, fmt.Errorf("cronjob 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		klog.V(100).Info("The namespace of the cronjob is empty")
+
+		return nil, fmt.Errorf("cronjob 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cronjob object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Create makes a cronjob according to the cronjob definition and stores the created object in the cronjob builder.
+func (builder *Builder) Create() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Creating cronjob %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	if !builder.Exists() {
+		builder.Object, err = builder.apiClient.CronJobs(builder.Definition.Namespace).Create(
+			logging.DiscardContext(), builder.Definition, metav1.CreateOptions{})
+	}
+
+	return builder, err
+}
+
+// Update renovates the existing cronjob object with the cronjob definition in builder.
+func (builder *Builder) Update() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Updating cronjob %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot update cronjob %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	var err error
+
+	builder.Object, err = builder.apiClient.CronJobs(builder.Definition.Namespace).Update(
+		logging.DiscardContext(), builder.Definition, metav1.UpdateOptions{})
+
+	return builder, err
+}
+
+// Exists checks whether the given cronjob exists.
+func (builder *Builder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	klog.V(100).Infof("Checking if cronjob %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+
+	builder.Object, err = builder.apiClient.CronJobs(builder.Definition.Namespace).Get(
+		logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Delete removes the cronjob from the cluster. Jobs created by the cronjob, and their pods, are deleted in the
+// background.
+func (builder *Builder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Deleting cronjob %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		klog.V(100).Infof("Cronjob %s in namespace %s does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		builder.Object = nil
+
+		return nil
+	}
+
+	err := builder.apiClient.CronJobs(builder.Definition.Namespace).Delete(
+		logging.DiscardContext(), builder.Definition.Name, metav1.DeleteOptions{
+			PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+		})
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// TriggerNow creates a job from the cronjob's job template right away, regardless of the schedule or whether the
+// cronjob is suspended, the same way kubectl create job --from=cronjob/<name> does. The created job is owned by the
+// cronjob, so it is subject to its history limits and is deleted along with it.
+func (builder *Builder) TriggerNow() (*job.Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Triggering a job from cronjob %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot trigger cronjob %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	jobTemplate := builder.Object.Spec.JobTemplate.DeepCopy()
+
+	annotations := map[string]string{instantiateAnnotation: "manual"}
+	for key, value := range jobTemplate.Annotations {
+		annotations[key] = value
+	}
+
+	jobDefinition := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getManualJobName(builder.Definition.Name),
+			Namespace:   builder.Definition.Namespace,
+			Labels:      jobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         batchv1.SchemeGroupVersion.String(),
+				Kind:               "CronJob",
+				Name:               builder.Object.Name,
+				UID:                builder.Object.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			}},
+		},
+		Spec: jobTemplate.Spec,
+	}
+
+	createdJob, err := builder.apiClient.Jobs(builder.Definition.Namespace).Create(
+		logging.DiscardContext(), jobDefinition, metav1.CreateOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to create job from cronjob %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return nil, err
+	}
+
+	return job.Pull(builder.apiClient, createdJob.Name, createdJob.Namespace)
+}
+
+// getManualJobName returns a name for a job triggered manually from the cronjob called name. The cronjob name is
+// truncated so the job name fits in a label value, and a random suffix keeps jobs triggered in quick succession apart.
+func getManualJobName(name string) string {
+	const infix = "-manual-"
+
+	if maxNameLength := maxJobNameLength - len(infix) - manualJobSuffixLength; len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+
+	return name + infix + rand.String(manualJobSuffixLength)
+}
+
+// WithOptions creates cronjob with generic mutation options.
+func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Info("Setting cronjob additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+			if err != nil {
+				klog.V(100).Info("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// WithSuspend sets whether the cronjob skips its scheduled runs. Jobs that are already running are not affected.
+func (builder *Builder) WithSuspend(suspend bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting suspend %t on cronjob %s in namespace %s",
+		suspend, builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.Suspend = ptr.To(suspend)
+
+	return builder
+}
+
+// WithConcurrencyPolicy sets how the cronjob treats a scheduled run while a previous job is still running.
+func (builder *Builder) WithConcurrencyPolicy(policy batchv1.ConcurrencyPolicy) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting concurrencyPolicy %s on cronjob %s in namespace %s",
+		policy, builder.Definition.Name, builder.Definition.Namespace)
+
+	switch policy {
+	case batchv1.AllowConcurrent, batchv1.ForbidConcurrent, batchv1.ReplaceConcurrent:
+	default:
+		klog.V(100).Infof("The concurrencyPolicy %s is not supported", policy)
+
+		builder.errorMsg = "cronjob concurrency policy must be one of Allow, Forbid or Replace"
+
+		return builder
+	}
+
+	builder.Definition.Spec.ConcurrencyPolicy = policy
+
+	return builder
+}
+
+// WithHistoryLimits sets how many successful and failed finished jobs the cronjob keeps.
+func (builder *Builder) WithHistoryLimits(successful, failed int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting history limits successful: %d, failed: %d on cronjob %s in namespace %s",
+		successful, failed, builder.Definition.Name, builder.Definition.Namespace)
+
+	if successful < 0 || failed < 0 {
+		klog.V(100).Info("The history limits of the cronjob cannot be negative")
+
+		builder.errorMsg = "cronjob history limits cannot be negative"
+
+		return builder
+	}
+
+	builder.Definition.Spec.SuccessfulJobsHistoryLimit = ptr.To(successful)
+	builder.Definition.Spec.FailedJobsHistoryLimit = ptr.To(failed)
+
+	return builder
+}
+
+// WithBackoffLimit sets the number of retries before each job created by the cronjob is marked as failed.
+func (builder *Builder) WithBackoffLimit(backoffLimit int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting backoffLimit %d on cronjob %s in namespace %s",
+		backoffLimit, builder.Definition.Name, builder.Definition.Namespace)
+
+	if backoffLimit < 0 {
+		klog.V(100).Info("The backoffLimit of the cronjob is negative")
+
+		builder.errorMsg = "cronjob 'backoffLimit' cannot be negative"
+
+		return builder
+	}
+
+	builder.Definition.Spec.JobTemplate.Spec.BackoffLimit = ptr.To(backoffLimit)
+
+	return builder
+}
+
+// WithTTLSecondsAfterFinished sets how long each job created by the cronjob is kept after it finishes before it is
+// deleted automatically.
+func (builder *Builder) WithTTLSecondsAfterFinished(ttlSeconds int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting ttlSecondsAfterFinished %d on cronjob %s in namespace %s",
+		ttlSeconds, builder.Definition.Name, builder.Definition.Namespace)
+
+	if ttlSeconds < 0 {
+		klog.V(100).Info("The ttlSecondsAfterFinished of the cronjob is negative")
+
+		builder.errorMsg = "cronjob 'ttlSecondsAfterFinished' cannot be negative"
+
+		return builder
+	}
+
+	builder.Definition.Spec.JobTemplate.Spec.TTLSecondsAfterFinished = ptr.To(ttlSeconds)
+
+	return builder
+}
+
+// GetGVR returns cronjob's GroupVersionResource which could be used for Clean function.
+func GetGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *Builder) validate() (bool, error) {
+	resourceCRD := "CronJob"
+
+	if builder == nil {
+		klog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		klog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		return false, fmt.Errorf("%s", msg.UndefinedCrdObjectErrString(resourceCRD))
+	}
+
+	if builder.apiClient == nil {
+		klog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		return false, fmt.Errorf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		klog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf("%s", builder.errorMsg)
+	}
+
+	return true, nil
+}
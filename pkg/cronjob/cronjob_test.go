@@ -0,0 +1,400 @@
+package cronjob
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	defaultCronJobName      = "test-cronjob"
+	defaultCronJobNamespace = "test-namespace"
+	defaultCronJobSchedule  = "*/5 * * * *"
+	defaultCronJobUID       = types.UID("test-cronjob-uid")
+)
+
+var defaultCronJobCommand = []string{"/bin/sh", "-c", "true"}
+
+func TestCronJobNewBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     string
+		schedule      string
+		image         string
+		expectedError string
+	}{
+		{
+			name:          defaultCronJobName,
+			namespace:     defaultCronJobNamespace,
+			schedule:      defaultCronJobSchedule,
+			image:         "test-image",
+			expectedError: "",
+		},
+		{
+			name:          "",
+			namespace:     defaultCronJobNamespace,
+			schedule:      defaultCronJobSchedule,
+			image:         "test-image",
+			expectedError: "cronjob 'name' cannot be empty",
+		},
+		{
+			name:          defaultCronJobName,
+			namespace:     "",
+			schedule:      defaultCronJobSchedule,
+			image:         "test-image",
+			expectedError: "cronjob 'namespace' cannot be empty",
+		},
+		{
+			name:          defaultCronJobName,
+			namespace:     defaultCronJobNamespace,
+			schedule:      "",
+			image:         "test-image",
+			expectedError: "cronjob 'schedule' cannot be empty",
+		},
+		{
+			name:          defaultCronJobName,
+			namespace:     defaultCronJobNamespace,
+			schedule:      defaultCronJobSchedule,
+			image:         "",
+			expectedError: "cronjob 'image' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewBuilder(clients.GetTestClients(clients.TestClientParams{}),
+			testCase.name, testCase.namespace, testCase.schedule, testCase.image, defaultCronJobCommand)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.schedule, testBuilder.Definition.Spec.Schedule)
+
+			podSpec := testBuilder.Definition.Spec.JobTemplate.Spec.Template.Spec
+			assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+			assert.Equal(t, testCase.image, podSpec.Containers[0].Image)
+		}
+	}
+
+	assert.Nil(t, NewBuilder(nil, defaultCronJobName, defaultCronJobNamespace,
+		defaultCronJobSchedule, "test-image", defaultCronJobCommand))
+}
+
+func TestCronJobPull(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     string
+		exists        bool
+		expectedError error
+	}{
+		{
+			name:          defaultCronJobName,
+			namespace:     defaultCronJobNamespace,
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			name:          defaultCronJobName,
+			namespace:     defaultCronJobNamespace,
+			exists:        false,
+			expectedError: fmt.Errorf("cronjob object test-cronjob does not exist in namespace test-namespace"),
+		},
+		{
+			name:          "",
+			namespace:     defaultCronJobNamespace,
+			exists:        true,
+			expectedError: fmt.Errorf("cronjob 'name' cannot be empty"),
+		},
+		{
+			name:          defaultCronJobName,
+			namespace:     "",
+			exists:        true,
+			expectedError: fmt.Errorf("cronjob 'namespace' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyCronJob())
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		testBuilder, err := Pull(testSettings, testCase.name, testCase.namespace)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+		}
+	}
+
+	_, err := Pull(nil, defaultCronJobName, defaultCronJobNamespace)
+	assert.Equal(t, fmt.Errorf("cronjob 'apiClient' cannot be empty"), err)
+}
+
+func TestCronJobCreate(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *Builder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidCronJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildValidCronJobTestBuilder(buildCronJobTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBuilder: NewBuilder(clients.GetTestClients(clients.TestClientParams{}), "",
+				defaultCronJobNamespace, defaultCronJobSchedule, "test-image", defaultCronJobCommand),
+			expectedError: fmt.Errorf("cronjob 'name' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.testBuilder.Create()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testBuilder.Definition.Name, testBuilder.Object.Name)
+		}
+	}
+}
+
+func TestCronJobUpdate(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot update cronjob test-cronjob in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+		if testCase.exists {
+			testSettings = buildCronJobTestClientWithDummyObject()
+		}
+
+		testBuilder, err := buildValidCronJobTestBuilder(testSettings).WithSuspend(true).Update()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, ptr.To(true), testBuilder.Object.Spec.Suspend)
+		}
+	}
+}
+
+func TestCronJobDelete(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *Builder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidCronJobTestBuilder(buildCronJobTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildValidCronJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.testBuilder.Delete()
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Nil(t, testCase.testBuilder.Object)
+	}
+}
+
+func TestCronJobTriggerNow(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot trigger cronjob test-cronjob in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+		if testCase.exists {
+			testSettings = buildCronJobTestClientWithDummyObject()
+		}
+
+		jobBuilder, err := buildValidCronJobTestBuilder(testSettings).TriggerNow()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Contains(t, jobBuilder.Definition.Name, "test-cronjob-manual-")
+			assert.Equal(t, "manual", jobBuilder.Definition.Annotations[instantiateAnnotation])
+			assert.Equal(t, "keep", jobBuilder.Definition.Annotations["template-annotation"])
+			assert.Equal(t, map[string]string{"app": "test"}, jobBuilder.Definition.Labels)
+			assert.Equal(t, defaultCronJobUID, jobBuilder.Definition.OwnerReferences[0].UID)
+			assert.Equal(t, "cron-image", jobBuilder.Definition.Spec.Template.Spec.Containers[0].Image)
+		}
+	}
+}
+
+func TestCronJobTriggerNowJobName(t *testing.T) {
+	longName := strings.Repeat("a", 52)
+	cronJob := buildDummyCronJob()
+	cronJob.Name = longName
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{cronJob}})
+	testBuilder := NewBuilder(testSettings, longName, defaultCronJobNamespace,
+		defaultCronJobSchedule, "test-image", defaultCronJobCommand)
+
+	firstJob, err := testBuilder.TriggerNow()
+	assert.Nil(t, err)
+	assert.Len(t, firstJob.Definition.Name, 63)
+	assert.True(t, strings.HasPrefix(firstJob.Definition.Name, strings.Repeat("a", 50)+"-manual-"))
+
+	secondJob, err := testBuilder.TriggerNow()
+	assert.Nil(t, err)
+	assert.NotEqual(t, firstJob.Definition.Name, secondJob.Definition.Name)
+}
+
+func TestCronJobWithSettings(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *Builder) *Builder
+		verify        func(t *testing.T, spec batchv1.CronJobSpec)
+		expectedError string
+	}{
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithSuspend(true) },
+			verify: func(t *testing.T, spec batchv1.CronJobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To(true), spec.Suspend)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithConcurrencyPolicy(batchv1.ForbidConcurrent) },
+			verify: func(t *testing.T, spec batchv1.CronJobSpec) {
+				t.Helper()
+				assert.Equal(t, batchv1.ForbidConcurrent, spec.ConcurrencyPolicy)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithConcurrencyPolicy("Sometimes") },
+			expectedError: "cronjob concurrency policy must be one of Allow, Forbid or Replace",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithHistoryLimits(3, 1) },
+			verify: func(t *testing.T, spec batchv1.CronJobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int32](3), spec.SuccessfulJobsHistoryLimit)
+				assert.Equal(t, ptr.To[int32](1), spec.FailedJobsHistoryLimit)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithHistoryLimits(3, -1) },
+			expectedError: "cronjob history limits cannot be negative",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithBackoffLimit(0).WithTTLSecondsAfterFinished(600)
+			},
+			verify: func(t *testing.T, spec batchv1.CronJobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int32](0), spec.JobTemplate.Spec.BackoffLimit)
+				assert.Equal(t, ptr.To[int32](600), spec.JobTemplate.Spec.TTLSecondsAfterFinished)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithBackoffLimit(-1) },
+			expectedError: "cronjob 'backoffLimit' cannot be negative",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithTTLSecondsAfterFinished(-1) },
+			expectedError: "cronjob 'ttlSecondsAfterFinished' cannot be negative",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithOptions(func(builder *Builder) (*Builder, error) {
+					return builder, fmt.Errorf("error")
+				})
+			},
+			expectedError: "error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(
+			buildValidCronJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			testCase.verify(t, testBuilder.Definition.Spec)
+		}
+	}
+}
+
+func TestCronJobGetGVR(t *testing.T) {
+	testGVR := GetGVR()
+	assert.Equal(t, "cronjobs", testGVR.Resource)
+	assert.Equal(t, "v1", testGVR.Version)
+	assert.Equal(t, "batch", testGVR.Group)
+}
+
+func buildValidCronJobTestBuilder(apiClient *clients.Settings) *Builder {
+	return NewBuilder(apiClient, defaultCronJobName, defaultCronJobNamespace,
+		defaultCronJobSchedule, "test-image", defaultCronJobCommand)
+}
+
+func buildCronJobTestClientWithDummyObject() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{buildDummyCronJob()}})
+}
+
+func buildDummyCronJob() *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultCronJobName,
+			Namespace: defaultCronJobNamespace,
+			UID:       defaultCronJobUID,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: defaultCronJobSchedule,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "test"},
+					Annotations: map[string]string{"template-annotation": "keep"},
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers:    []corev1.Container{{Name: "job", Image: "cron-image"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
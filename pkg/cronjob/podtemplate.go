@@ -0,0 +1,208 @@
+package cronjob
+
+import (
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/podtemplate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// WithNodeSelector applies a nodeSelector to the cronjob's pod template.
+func (builder *Builder) WithNodeSelector(nodeSelector map[string]string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if len(nodeSelector) == 0 {
+		klog.V(100).Info("The nodeSelector is empty")
+
+		builder.errorMsg = "can not define cronjob with empty nodeSelector"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("nodeSelector", podtemplate.WithNodeSelector(nodeSelector))
+}
+
+// WithToleration appends a toleration to the cronjob's pod template.
+func (builder *Builder) WithToleration(toleration corev1.Toleration) *Builder {
+	return builder.withPodTemplateMutation("toleration", podtemplate.WithToleration(toleration))
+}
+
+// WithVolume attaches given volume to the cronjob's pod template.
+func (builder *Builder) WithVolume(volume corev1.Volume) *Builder {
+	return builder.withPodTemplateMutation("volume", podtemplate.WithVolume(volume))
+}
+
+// WithHugePages adds a hugepages volume to the cronjob's pod template and mounts it in all containers.
+func (builder *Builder) WithHugePages() *Builder {
+	return builder.withPodTemplateMutation("hugepages", podtemplate.WithHugePages())
+}
+
+// WithAffinity applies affinity to the cronjob's pod template.
+func (builder *Builder) WithAffinity(affinity *corev1.Affinity) *Builder {
+	return builder.withPodTemplateMutation("affinity", podtemplate.WithAffinity(affinity))
+}
+
+// WithTopologySpreadConstraint appends a topology spread constraint to the cronjob's pod template.
+func (builder *Builder) WithTopologySpreadConstraint(constraint corev1.TopologySpreadConstraint) *Builder {
+	return builder.withPodTemplateMutation(
+		"topology spread constraint", podtemplate.WithTopologySpreadConstraint(constraint))
+}
+
+// WithPriorityClassName sets the priority class of the cronjob's pod template.
+func (builder *Builder) WithPriorityClassName(priorityClassName string) *Builder {
+	return builder.withPodTemplateMutation("priorityClassName", podtemplate.WithPriorityClassName(priorityClassName))
+}
+
+// WithRuntimeClassName sets the runtime class of the cronjob's pod template.
+func (builder *Builder) WithRuntimeClassName(runtimeClassName string) *Builder {
+	return builder.withPodTemplateMutation("runtimeClassName", podtemplate.WithRuntimeClassName(runtimeClassName))
+}
+
+// WithDNSConfig sets the DNS policy and DNS config of the cronjob's pod template. The dnsConfig must define at
+// least one nameserver when the dnsPolicy is None and may be nil otherwise.
+func (builder *Builder) WithDNSConfig(dnsPolicy corev1.DNSPolicy, dnsConfig *corev1.PodDNSConfig) *Builder {
+	return builder.withPodTemplateMutation("dnsConfig", podtemplate.WithDNSConfig(dnsPolicy, dnsConfig))
+}
+
+// WithSecurityContext sets the pod security context of the cronjob's pod template.
+func (builder *Builder) WithSecurityContext(securityContext *corev1.PodSecurityContext) *Builder {
+	return builder.withPodTemplateMutation("securityContext", podtemplate.WithSecurityContext(securityContext))
+}
+
+// WithServiceAccountName sets the service account the cronjob's pods run as.
+func (builder *Builder) WithServiceAccountName(serviceAccountName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if serviceAccountName == "" {
+		klog.V(100).Info("The 'serviceAccountName' of the cronjob is empty")
+
+		builder.errorMsg = "can not define cronjob with empty serviceAccountName"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation(
+		"serviceAccountName", podtemplate.WithServiceAccountName(serviceAccountName))
+}
+
+// WithSchedulerName configures a scheduler to process the scheduling of the cronjob's pods.
+func (builder *Builder) WithSchedulerName(schedulerName string) *Builder {
+	return builder.withPodTemplateMutation("schedulerName", podtemplate.WithSchedulerName(schedulerName))
+}
+
+// WithTerminationGracePeriodSeconds configures terminationGracePeriodSeconds on the cronjob's pod template.
+func (builder *Builder) WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds int64) *Builder {
+	return builder.withPodTemplateMutation(
+		"terminationGracePeriodSeconds", podtemplate.WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds))
+}
+
+// DefineOnNode adds nodeName to the cronjob's pod template.
+func (builder *Builder) DefineOnNode(nodeName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if nodeName == "" {
+		klog.V(100).Info("The node name is empty")
+
+		builder.errorMsg = "can not define cronjob on empty node"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("nodeName", podtemplate.WithNodeName(nodeName))
+}
+
+// RedefineDefaultCMD redefines the command of the cronjob's default container.
+func (builder *Builder) RedefineDefaultCMD(command []string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if len(command) == 0 {
+		klog.V(100).Info("The command is empty")
+
+		builder.errorMsg = "can not redefine cronjob with empty command"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("default container command", podtemplate.WithDefaultCommand(command))
+}
+
+// RedefineDefaultContainer redefines the cronjob's default container with the new one.
+func (builder *Builder) RedefineDefaultContainer(container corev1.Container) *Builder {
+	return builder.withPodTemplateMutation("default container", podtemplate.WithDefaultContainer(container))
+}
+
+// WithRestartPolicy applies restart policy to the cronjob's pod template. Jobs only accept the Never and OnFailure
+// policies.
+func (builder *Builder) WithRestartPolicy(restartPolicy corev1.RestartPolicy) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if restartPolicy != corev1.RestartPolicyNever && restartPolicy != corev1.RestartPolicyOnFailure {
+		klog.V(100).Infof("The RestartPolicy %s is not supported by jobs", restartPolicy)
+
+		builder.errorMsg = "cronjob restart policy must be either Never or OnFailure"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("restartPolicy", podtemplate.WithRestartPolicy(restartPolicy))
+}
+
+// WithPrivilegedFlag sets privileged flag on all containers of the cronjob's pod template.
+func (builder *Builder) WithPrivilegedFlag() *Builder {
+	return builder.withPodTemplateMutation("privileged flag", podtemplate.WithPrivilegedFlag())
+}
+
+// WithAdditionalContainer appends additional container to the cronjob's pod template.
+func (builder *Builder) WithAdditionalContainer(container *corev1.Container) *Builder {
+	return builder.withPodTemplateMutation("additional container", podtemplate.WithAdditionalContainer(container))
+}
+
+// WithAdditionalInitContainer appends additional init container to the cronjob's pod template.
+func (builder *Builder) WithAdditionalInitContainer(container *corev1.Container) *Builder {
+	return builder.withPodTemplateMutation(
+		"additional init container", podtemplate.WithAdditionalInitContainer(container))
+}
+
+// WithHostNetwork applies HostNetwork to the cronjob's pod template.
+func (builder *Builder) WithHostNetwork() *Builder {
+	return builder.withPodTemplateMutation("hostNetwork", podtemplate.WithHostNetwork(true))
+}
+
+// WithHostPid configures the access of the cronjob's pods to the host process ID namespace.
+func (builder *Builder) WithHostPid(hostPid bool) *Builder {
+	return builder.withPodTemplateMutation("hostPID", podtemplate.WithHostPID(hostPid))
+}
+
+// WithLabel adds a label to the cronjob's pod template.
+func (builder *Builder) WithLabel(labelKey, labelValue string) *Builder {
+	return builder.withPodTemplateMutation("label", podtemplate.WithLabel(labelKey, labelValue))
+}
+
+// withPodTemplateMutation validates the builder and applies the mutator to the cronjob's pod template, saving any
+// error on the builder.
+func (builder *Builder) withPodTemplateMutation(field string, mutator podtemplate.Mutator) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Applying %s to the pod template of cronjob %s in namespace %s",
+		field, builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := mutator(&builder.Definition.Spec.JobTemplate.Spec.Template); err != nil {
+		klog.V(100).Infof("Failed to apply %s to the pod template of cronjob %s in namespace %s: %v",
+			field, builder.Definition.Name, builder.Definition.Namespace, err)
+
+		builder.errorMsg = err.Error()
+	}
+
+	return builder
+}
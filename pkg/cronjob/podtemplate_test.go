@@ -0,0 +1,62 @@
+package cronjob
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCronJobPodTemplateMutators(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *Builder) *Builder
+		verify        func(t *testing.T, template corev1.PodTemplateSpec)
+		expectedError string
+	}{
+		{
+			mutate: func(builder *Builder) *Builder { return builder.DefineOnNode("test-node").WithLabel("app", "fio") },
+			verify: func(t *testing.T, template corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, "test-node", template.Spec.NodeName)
+				assert.Equal(t, map[string]string{"app": "fio"}, template.Labels)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.DefineOnNode("") },
+			expectedError: "can not define cronjob on empty node",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.RedefineDefaultCMD(nil) },
+			expectedError: "can not redefine cronjob with empty command",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithRestartPolicy(corev1.RestartPolicyOnFailure) },
+			verify: func(t *testing.T, template corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.RestartPolicyOnFailure, template.Spec.RestartPolicy)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithRestartPolicy(corev1.RestartPolicyAlways) },
+			expectedError: "cronjob restart policy must be either Never or OnFailure",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithNodeSelector(nil) },
+			expectedError: "can not define cronjob with empty nodeSelector",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithServiceAccountName("") },
+			expectedError: "can not define cronjob with empty serviceAccountName",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(buildValidCronJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			testCase.verify(t, testBuilder.Definition.Spec.JobTemplate.Spec.Template)
+		}
+	}
+}
@@ -14,7 +14,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
-	appsv1Typed "k8s.io/client-go/kubernetes/typed/apps/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -27,7 +26,7 @@ type Builder struct {
 	// Used in functions that define or mutate daemonset definition. errorMsg is processed before the daemonset
 	// object is created.
 	errorMsg  string
-	apiClient appsv1Typed.DaemonSetInterface
+	apiClient *clients.Settings
 }
 
 // AdditionalOptions additional options for daemonset object.
@@ -50,7 +49,7 @@ func NewBuilder(
 	}
 
 	builder := &Builder{
-		apiClient: apiClient.DaemonSets(nsname),
+		apiClient: apiClient,
 		Definition: &appsv1.DaemonSet{
 			Spec: appsv1.DaemonSetSpec{
 				Selector: &metav1.LabelSelector{
@@ -109,7 +108,7 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	}
 
 	builder := &Builder{
-		apiClient: apiClient.DaemonSets(nsname),
+		apiClient: apiClient,
 		Definition: &appsv1.DaemonSet{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
@@ -139,28 +138,6 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	return builder, nil
 }
 
-// WithNodeSelector applies nodeSelector to the daemonset definition.
-func (builder *Builder) WithNodeSelector(selector map[string]string) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying nodeSelector %s to daemonset %s in namespace %s",
-		selector, builder.Definition.Name, builder.Definition.Namespace)
-
-	if len(selector) == 0 {
-		klog.V(100).Info("The nodeselector is empty")
-
-		builder.errorMsg = "cannot accept empty map as nodeselector"
-
-		return builder
-	}
-
-	builder.Definition.Spec.Template.Spec.NodeSelector = selector
-
-	return builder
-}
-
 // WithHostNetwork applies HostNetwork to daemonset definition.
 func (builder *Builder) WithHostNetwork() *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -175,52 +152,6 @@ func (builder *Builder) WithHostNetwork() *Builder {
 	return builder
 }
 
-// WithPodAffinity applies pod's Affinity to daemonset definition.
-func (builder *Builder) WithPodAffinity(podAffinity *corev1.Affinity) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if podAffinity == nil {
-		klog.V(100).Info("The Affinity parameter is empty")
-
-		builder.errorMsg = "affinity parameter is empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding pod affinity to daemonset %s in namespace %s",
-		builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.Affinity = podAffinity
-
-	return builder
-}
-
-// WithVolume defines Volume of daemonset under PodTemplateSpec.
-func (builder *Builder) WithVolume(dsVolume corev1.Volume) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if dsVolume.Name == "" {
-		klog.V(100).Info("The Volume name parameter is empty")
-
-		builder.errorMsg = "Volume name parameter is empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding volume %s for daemonset %s pod template in namespace %s",
-		dsVolume.Name, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.Volumes = append(
-		builder.Definition.Spec.Template.Spec.Volumes,
-		dsVolume)
-
-	return builder
-}
-
 // WithAdditionalContainerSpecs appends a list of container specs to the daemonset definition.
 func (builder *Builder) WithAdditionalContainerSpecs(specs []corev1.Container) *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -277,7 +208,7 @@ func (builder *Builder) Create() (*Builder, error) {
 
 	var err error
 	if !builder.Exists() {
-		builder.Object, err = builder.apiClient.Create(
+		builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Create(
 			logging.DiscardContext(), builder.Definition, metav1.CreateOptions{})
 	}
 
@@ -294,7 +225,7 @@ func (builder *Builder) Update() (*Builder, error) {
 
 	var err error
 
-	builder.Object, err = builder.apiClient.Update(
+	builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Update(
 		logging.DiscardContext(), builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
@@ -315,7 +246,7 @@ func (builder *Builder) Delete() error {
 		return nil
 	}
 
-	err := builder.apiClient.Delete(
+	err := builder.apiClient.DaemonSets(builder.Definition.Namespace).Delete(
 		logging.DiscardContext(), builder.Definition.Name, metav1.DeleteOptions{})
 
 	if err != nil && !k8serrors.IsNotFound(err) {
@@ -346,7 +277,7 @@ func (builder *Builder) CreateAndWaitUntilReady(timeout time.Duration) (*Builder
 	// Polls every retryInterval to determine if daemonset is available.
 	err = wait.PollUntilContextTimeout(
 		context.TODO(), retryInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			builder.Object, err = builder.apiClient.Get(
+			builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Get(
 				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, nil
@@ -394,7 +325,7 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
 	// Polls the daemonset every retryInterval until it is removed.
 	return wait.PollUntilContextTimeout(
 		context.TODO(), retryInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			_, err := builder.apiClient.Get(
+			_, err := builder.apiClient.DaemonSets(builder.Definition.Namespace).Get(
 				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 			if k8serrors.IsNotFound(err) {
 				return true, nil
@@ -415,7 +346,7 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 
-	builder.Object, err = builder.apiClient.Get(
+	builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Get(
 		logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
@@ -435,7 +366,7 @@ func (builder *Builder) IsReady(timeout time.Duration) bool {
 		context.TODO(), retryInterval, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 
-			builder.Object, err = builder.apiClient.Get(
+			builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Get(
 				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
 				klog.V(100).Infof("Failed to get daemonset from cluster. Error is: '%s'", err.Error())
@@ -0,0 +1,242 @@
+package daemonset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/rollout"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// RolloutStatus provides a summary of the rollout progress of a daemonset.
+type RolloutStatus = rollout.Status
+
+// RolloutRevision describes a single revision in the rollout history of a daemonset.
+type RolloutRevision = rollout.Revision
+
+// RolloutRestart triggers a rolling restart of the daemonset by updating the restartedAt annotation on its pod
+// template, the same way kubectl rollout restart does.
+func (builder *Builder) RolloutRestart() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Restarting rollout of daemonset %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot restart daemonset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	patch, restartedAt, err := rollout.RestartPatch()
+	if err != nil {
+		return builder, err
+	}
+
+	builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Patch(
+		logging.DiscardContext(), builder.Definition.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to restart daemonset %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, err
+	}
+
+	if builder.Definition.Spec.Template.Annotations == nil {
+		builder.Definition.Spec.Template.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Spec.Template.Annotations[rollout.RestartedAtAnnotation] = restartedAt
+
+	return builder, nil
+}
+
+// RolloutStatus returns the current rollout status of the daemonset, including the number of daemon pods owned by
+// each revision.
+func (builder *Builder) RolloutStatus() (*RolloutStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rollout status of daemonset %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get rollout status of daemonset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	revisions, err := builder.listOwnedControllerRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	revisionNumbers := make(map[string]int64)
+	for _, revision := range revisions {
+		revisionNumbers[revision.Labels[appsv1.ControllerRevisionHashLabelKey]] = revision.Revision
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(builder.Object.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse selector of daemonset %s: %w", builder.Definition.Name, err)
+	}
+
+	podList, err := builder.apiClient.Pods(builder.Definition.Namespace).List(
+		logging.DiscardContext(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		klog.V(100).Infof("Failed to list pods of daemonset %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return nil, err
+	}
+
+	status := getRolloutStatus(builder.Object)
+
+	for index := range podList.Items {
+		pod := &podList.Items[index]
+		if !metav1.IsControlledBy(pod, builder.Object) {
+			continue
+		}
+
+		if revision, ok := revisionNumbers[pod.Labels[appsv1.ControllerRevisionHashLabelKey]]; ok {
+			status.RevisionReplicas[revision]++
+		}
+	}
+
+	return status, nil
+}
+
+// WaitForRolloutComplete waits for the duration of the defined timeout or until the latest revision of the
+// daemonset has been fully rolled out.
+func (builder *Builder) WaitForRolloutComplete(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until rollout of daemonset %s in namespace %s is complete",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return fmt.Errorf("cannot wait for rollout of daemonset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("Failed to get daemonset %s in namespace %s: %v",
+					builder.Definition.Name, builder.Definition.Namespace, err)
+
+				return false, nil
+			}
+
+			return getRolloutStatus(builder.Object).Complete, nil
+		})
+}
+
+// RolloutHistory returns the revisions of the daemonset, ordered from oldest to newest, based on the
+// ControllerRevisions owned by the daemonset.
+func (builder *Builder) RolloutHistory() ([]RolloutRevision, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rollout history of daemonset %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get rollout history of daemonset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	revisions, err := builder.listOwnedControllerRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	return rollout.ControllerRevisionHistory(revisions)
+}
+
+// RolloutUndo rolls the daemonset back to the pod template of the provided revision. If toRevision is 0, the
+// daemonset is rolled back to the revision immediately preceding the current one.
+func (builder *Builder) RolloutUndo(toRevision int64) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Rolling back daemonset %s in namespace %s to revision %d",
+		builder.Definition.Name, builder.Definition.Namespace, toRevision)
+
+	if toRevision < 0 {
+		return builder, fmt.Errorf("daemonset revision cannot be negative")
+	}
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot roll back daemonset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	revisions, err := builder.listOwnedControllerRevisions()
+	if err != nil {
+		return builder, err
+	}
+
+	target, err := rollout.FindUndoControllerRevision(revisions, toRevision)
+	if err != nil {
+		return builder, fmt.Errorf("cannot roll back daemonset %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	// ControllerRevision data for daemonsets is a strategic merge patch that replaces the pod template, so it can
+	// be applied directly.
+	builder.Object, err = builder.apiClient.DaemonSets(builder.Definition.Namespace).Patch(
+		logging.DiscardContext(), builder.Definition.Name, types.StrategicMergePatchType, target.Data.Raw,
+		metav1.PatchOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to roll back daemonset %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, err
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// listOwnedControllerRevisions returns the ControllerRevisions controlled by the daemonset, ordered from oldest to
+// newest. It assumes the builder is valid and builder.Object is set.
+func (builder *Builder) listOwnedControllerRevisions() ([]*appsv1.ControllerRevision, error) {
+	return rollout.ListControllerRevisions(builder.apiClient, "daemonset", builder.Object, builder.Object.Spec.Selector)
+}
+
+// getRolloutStatus computes the rollout status of the daemonset from its status, using the same completeness checks
+// as kubectl rollout status. RevisionReplicas is initialized but left empty.
+func getRolloutStatus(daemonSet *appsv1.DaemonSet) *RolloutStatus {
+	status := &RolloutStatus{
+		Generation:         daemonSet.Generation,
+		ObservedGeneration: daemonSet.Status.ObservedGeneration,
+		DesiredReplicas:    daemonSet.Status.DesiredNumberScheduled,
+		UpdatedReplicas:    daemonSet.Status.UpdatedNumberScheduled,
+		ReadyReplicas:      daemonSet.Status.NumberReady,
+		AvailableReplicas:  daemonSet.Status.NumberAvailable,
+		RevisionReplicas:   make(map[int64]int32),
+	}
+
+	status.Complete = daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.UpdatedNumberScheduled == daemonSet.Status.DesiredNumberScheduled &&
+		daemonSet.Status.NumberAvailable == daemonSet.Status.DesiredNumberScheduled
+
+	return status
+}
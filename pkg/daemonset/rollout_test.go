@@ -0,0 +1,282 @@
+package daemonset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/rollout"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const defaultDaemonSetUID = types.UID("test-daemonset-uid")
+
+func TestDaemonSetRolloutRestart(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot restart daemonset test-name in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutDaemonSet(true))
+		}
+
+		testBuilder, err := buildValidTestBuilderWithClient(runtimeObjects).RolloutRestart()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.NotEmpty(t, testBuilder.Object.Spec.Template.Annotations[rollout.RestartedAtAnnotation])
+			assert.Equal(t, testBuilder.Object.Spec.Template.Annotations[rollout.RestartedAtAnnotation],
+				testBuilder.Definition.Spec.Template.Annotations[rollout.RestartedAtAnnotation])
+		}
+	}
+}
+
+func TestDaemonSetRolloutStatus(t *testing.T) {
+	testCases := []struct {
+		complete         bool
+		expectedReplicas map[int64]int32
+	}{
+		{
+			complete:         true,
+			expectedReplicas: map[int64]int32{2: 2},
+		},
+		{
+			complete:         false,
+			expectedReplicas: map[int64]int32{1: 1, 2: 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyRolloutDaemonSet(testCase.complete)}
+		runtimeObjects = append(runtimeObjects, buildDummyRolloutControllerRevisions()...)
+		runtimeObjects = append(runtimeObjects,
+			buildDummyRolloutPod("test-pod-1", "hash-2", true),
+			buildDummyRolloutPod("test-pod-3", "hash-1", false))
+
+		if testCase.complete {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutPod("test-pod-2", "hash-2", true))
+		} else {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutPod("test-pod-2", "hash-1", true))
+		}
+
+		status, err := buildValidTestBuilderWithClient(runtimeObjects).RolloutStatus()
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.complete, status.Complete)
+		assert.Equal(t, int32(2), status.DesiredReplicas)
+		assert.Equal(t, testCase.expectedReplicas, status.RevisionReplicas)
+	}
+}
+
+func TestDaemonSetWaitForRolloutComplete(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		complete      bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			complete:      true,
+			expectedError: nil,
+		},
+		{
+			exists:        true,
+			complete:      false,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf("cannot wait for rollout of daemonset test-name in namespace " +
+				"test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutDaemonSet(testCase.complete))
+		}
+
+		err := buildValidTestBuilderWithClient(runtimeObjects).WaitForRolloutComplete(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestDaemonSetRolloutHistory(t *testing.T) {
+	runtimeObjects := []runtime.Object{buildDummyRolloutDaemonSet(false)}
+	runtimeObjects = append(runtimeObjects, buildDummyRolloutControllerRevisions()...)
+
+	history, err := buildValidTestBuilderWithClient(runtimeObjects).RolloutHistory()
+	assert.Nil(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, "first", history[0].ChangeCause)
+	assert.Equal(t, "image-1", history[0].Template.Spec.Containers[0].Image)
+	assert.Equal(t, int64(2), history[1].Revision)
+}
+
+func TestDaemonSetRolloutUndo(t *testing.T) {
+	testCases := []struct {
+		toRevision    int64
+		expectedImage string
+		expectedError string
+	}{
+		{
+			toRevision:    0,
+			expectedImage: "image-1",
+			expectedError: "",
+		},
+		{
+			toRevision:    1,
+			expectedImage: "image-1",
+			expectedError: "",
+		},
+		{
+			toRevision: 5,
+			expectedError: "cannot roll back daemonset test-name in namespace test-namespace: " +
+				"revision 5 not found",
+		},
+		{
+			toRevision:    -1,
+			expectedError: "daemonset revision cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyRolloutDaemonSet(false)}
+		runtimeObjects = append(runtimeObjects, buildDummyRolloutControllerRevisions()...)
+
+		testBuilder, err := buildValidTestBuilderWithClient(runtimeObjects).RolloutUndo(testCase.toRevision)
+		if testCase.expectedError == "" {
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedImage, testBuilder.Object.Spec.Template.Spec.Containers[0].Image)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError)
+		}
+	}
+}
+
+// buildDummyRolloutDaemonSet returns a daemonset scheduled on two nodes whose status is either fully rolled out or
+// has one pod on each of the two revisions.
+func buildDummyRolloutDaemonSet(complete bool) *appsv1.DaemonSet {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-name",
+			Namespace:  "test-namespace",
+			UID:        defaultDaemonSetUID,
+			Generation: 2,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"test-key": "test-value"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"test-key": "test-value"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "image-2"}},
+				},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 2,
+			CurrentNumberScheduled: 2,
+			UpdatedNumberScheduled: 2,
+			NumberReady:            2,
+			NumberAvailable:        2,
+		},
+	}
+
+	if !complete {
+		daemonSet.Status.UpdatedNumberScheduled = 1
+	}
+
+	return daemonSet
+}
+
+// buildDummyRolloutPod returns a pod with the provided controller-revision-hash label that is optionally controlled
+// by the dummy rollout daemonset.
+func buildDummyRolloutPod(name, hash string, owned bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				"test-key":                            "test-value",
+				appsv1.ControllerRevisionHashLabelKey: hash,
+			},
+		},
+	}
+
+	if owned {
+		pod.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+			Name:       "test-name",
+			UID:        defaultDaemonSetUID,
+			Controller: ptr.To(true),
+		}}
+	}
+
+	return pod
+}
+
+// buildDummyRolloutControllerRevisions returns ControllerRevisions for revisions 1 and 2 owned by the dummy rollout
+// daemonset, along with a ControllerRevision that matches the selector but is not owned by it.
+func buildDummyRolloutControllerRevisions() []runtime.Object {
+	return []runtime.Object{
+		buildDummyRolloutControllerRevision("test-name-2", 2, "second", "image-2", true),
+		buildDummyRolloutControllerRevision("test-name-1", 1, "first", "image-1", true),
+		buildDummyRolloutControllerRevision("other", 3, "", "image-3", false),
+	}
+}
+
+func buildDummyRolloutControllerRevision(
+	name string, revision int64, changeCause, image string, owned bool) *appsv1.ControllerRevision {
+	controllerRevision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				"test-key":                            "test-value",
+				appsv1.ControllerRevisionHashLabelKey: fmt.Sprintf("hash-%d", revision),
+			},
+			Annotations: map[string]string{rollout.ChangeCauseAnnotation: changeCause},
+		},
+		Revision: revision,
+		Data: runtime.RawExtension{Raw: fmt.Appendf(nil,
+			`{"spec":{"template":{"$patch":"replace","metadata":{"labels":{"test-key":"test-value"}},`+
+				`"spec":{"containers":[{"name":"test","image":"%s"}]}}}}`, image)},
+	}
+
+	if owned {
+		controllerRevision.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+			Name:       "test-name",
+			UID:        defaultDaemonSetUID,
+			Controller: ptr.To(true),
+		}}
+	}
+
+	return controllerRevision
+}
@@ -19,7 +19,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
-	appsv1Typed "k8s.io/client-go/kubernetes/typed/apps/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -32,7 +31,7 @@ type Builder struct {
 	// Used in functions that define or mutate deployment definition. errorMsg is processed before the deployment
 	// object is created.
 	errorMsg  string
-	apiClient appsv1Typed.AppsV1Interface
+	apiClient *clients.Settings
 }
 
 // AdditionalOptions additional options for deployment object.
@@ -47,7 +46,7 @@ func NewBuilder(
 		name, nsname, labels, containerSpec)
 
 	builder := &Builder{
-		apiClient: apiClient.AppsV1Interface,
+		apiClient: apiClient,
 		Definition: &appsv1.Deployment{
 			Spec: appsv1.DeploymentSpec{
 				Selector: &metav1.LabelSelector{
@@ -107,7 +106,7 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	klog.V(100).Infof("Pulling existing deployment name: %s under namespace: %s", name, nsname)
 
 	builder := &Builder{
-		apiClient: apiClient.AppsV1Interface,
+		apiClient: apiClient,
 		Definition: &appsv1.Deployment{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
@@ -137,20 +136,6 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	return builder, nil
 }
 
-// WithNodeSelector applies a nodeSelector to the deployment definition.
-func (builder *Builder) WithNodeSelector(selector map[string]string) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying nodeSelector %s to deployment %s in namespace %s",
-		selector, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.NodeSelector = selector
-
-	return builder
-}
-
 // WithReplicas sets the desired number of replicas in the deployment definition.
 func (builder *Builder) WithReplicas(replicas int32) *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -214,52 +199,6 @@ func (builder *Builder) WithSecondaryNetwork(networks []*multus.NetworkSelection
 	return builder
 }
 
-// WithHugePages sets hugePages on all containers inside the deployment.
-func (builder *Builder) WithHugePages() *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying hugePages configuration to all containers in deployment: %s",
-		builder.Definition.Name)
-
-	// Append hugepages volume to the deployment.
-	builder.Definition.Spec.Template.Spec.Volumes = append(builder.Definition.Spec.Template.Spec.Volumes, corev1.Volume{
-		Name: "hugepages", VolumeSource: corev1.VolumeSource{
-			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: "HugePages"}}})
-
-	for idx := range builder.Definition.Spec.Template.Spec.Containers {
-		// Append hugepages volume mount to the deployment.
-		builder.Definition.Spec.Template.Spec.Containers[idx].VolumeMounts = append(
-			builder.Definition.Spec.Template.Spec.Containers[idx].VolumeMounts,
-			corev1.VolumeMount{Name: "hugepages", MountPath: "/mnt/huge"})
-	}
-
-	return builder
-}
-
-// WithSecurityContext sets SecurityContext on deployment definition.
-func (builder *Builder) WithSecurityContext(securityContext *corev1.PodSecurityContext) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying SecurityContext configuration on deployment %s in namespace %s",
-		builder.Definition.Name, builder.Definition.Namespace)
-
-	if securityContext == nil {
-		klog.V(100).Info("The 'securityContext' of the deployment is empty")
-
-		builder.errorMsg = "'securityContext' parameter is empty"
-
-		return builder
-	}
-
-	builder.Definition.Spec.Template.Spec.SecurityContext = securityContext
-
-	return builder
-}
-
 // WithLabel applies label to deployment's definition.
 func (builder *Builder) WithLabel(labelKey, labelValue string) *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -285,96 +224,6 @@ func (builder *Builder) WithLabel(labelKey, labelValue string) *Builder {
 	return builder
 }
 
-// WithServiceAccountName sets the ServiceAccountName on deployment definition.
-func (builder *Builder) WithServiceAccountName(serviceAccountName string) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Setting ServiceAccount %s on deployment %s in namespace %s",
-		serviceAccountName, builder.Definition.Name, builder.Definition.Namespace)
-
-	if serviceAccountName == "" {
-		klog.V(100).Info("The 'serviceAccount' of the deployment is empty")
-
-		builder.errorMsg = "can not apply empty serviceAccount"
-
-		return builder
-	}
-
-	builder.Definition.Spec.Template.Spec.ServiceAccountName = serviceAccountName
-
-	return builder
-}
-
-// WithVolume attaches given volume to the deployment.
-func (builder *Builder) WithVolume(deployVolume corev1.Volume) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if deployVolume.Name == "" {
-		klog.V(100).Info("The volume's name cannot be empty")
-
-		builder.errorMsg = "The volume's name cannot be empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding volume %s to deployment %s in namespace %s",
-		deployVolume.Name, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.Volumes = append(
-		builder.Definition.Spec.Template.Spec.Volumes,
-		deployVolume)
-
-	return builder
-}
-
-// WithSchedulerName configures a scheduler to process pod's scheduling.
-func (builder *Builder) WithSchedulerName(schedulerName string) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if schedulerName == "" {
-		klog.V(100).Info("Scheduler's name cannot be empty")
-
-		builder.errorMsg = "Scheduler's name cannot be empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Setting scheduler %s for deployment %s in namespace %s",
-		schedulerName, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.SchedulerName = schedulerName
-
-	return builder
-}
-
-// WithAffinity applies Affinity to the deployment definition.
-func (builder *Builder) WithAffinity(affinity *corev1.Affinity) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if affinity == nil {
-		klog.V(100).Info("The Affinity parameter is empty")
-
-		builder.errorMsg = "affinity parameter is empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding affinity to deployment %s in namespace %s",
-		builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.Affinity = affinity
-
-	return builder
-}
-
 // WithHostNetwork applies a hostnetwork state to the deployment definition.
 func (builder *Builder) WithHostNetwork(enableHostnetwork bool) *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -728,42 +577,3 @@ func (builder *Builder) validate() (bool, error) {
 
 	return true, nil
 }
-
-// WithToleration applies a toleration to the deployment's definition.
-func (builder *Builder) WithToleration(toleration corev1.Toleration) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if toleration == (corev1.Toleration{}) {
-		klog.V(100).Info("The toleration cannot be empty")
-
-		builder.errorMsg = "The toleration cannot be empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding TaintToleration %v to deployment %s in namespace %s",
-		toleration, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.Tolerations = append(
-		builder.Definition.Spec.Template.Spec.Tolerations,
-		toleration)
-
-	return builder
-}
-
-// WithTerminationGracePeriodSeconds configures TerminationGracePeriodSeconds on the pod template of the deployment.
-func (builder *Builder) WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds int64) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof(
-		"Applying terminationGracePeriodSeconds flag to the configuration of pod template of deployment: %s in namespace: %s",
-		builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
-
-	return builder
-}
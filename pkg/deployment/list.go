@@ -45,7 +45,7 @@ func List(apiClient *clients.Settings, nsname string, options ...metav1.ListOpti
 	for _, runningDeployment := range deploymentList.Items {
 		copiedDeployment := runningDeployment
 		deploymentBuilder := &Builder{
-			apiClient:  apiClient.AppsV1Interface,
+			apiClient:  apiClient,
 			Object:     &copiedDeployment,
 			Definition: &copiedDeployment,
 		}
@@ -86,7 +86,7 @@ func ListInAllNamespaces(apiClient *clients.Settings, options ...metav1.ListOpti
 	for _, runningDeployment := range deploymentList.Items {
 		copiedDeployment := runningDeployment
 		deploymentBuilder := &Builder{
-			apiClient:  apiClient.AppsV1Interface,
+			apiClient:  apiClient,
 			Object:     &copiedDeployment,
 			Definition: &copiedDeployment,
 		}
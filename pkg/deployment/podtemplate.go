@@ -0,0 +1,144 @@
+package deployment
+
+import (
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/podtemplate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// WithNodeSelector applies a nodeSelector to the deployment's pod template.
+func (builder *Builder) WithNodeSelector(nodeSelector map[string]string) *Builder {
+	return builder.withPodTemplateMutation("nodeSelector", podtemplate.WithNodeSelector(nodeSelector))
+}
+
+// WithToleration appends a toleration to the deployment's pod template.
+func (builder *Builder) WithToleration(toleration corev1.Toleration) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if toleration == (corev1.Toleration{}) {
+		klog.V(100).Info("The toleration cannot be empty")
+
+		builder.errorMsg = "The toleration cannot be empty"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("toleration", podtemplate.WithToleration(toleration))
+}
+
+// WithVolume attaches given volume to the deployment's pod template.
+func (builder *Builder) WithVolume(volume corev1.Volume) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if volume.Name == "" {
+		klog.V(100).Info("The volume's name cannot be empty")
+
+		builder.errorMsg = "The volume's name cannot be empty"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("volume", podtemplate.WithVolume(volume))
+}
+
+// WithHugePages adds a hugepages volume to the deployment's pod template and mounts it in all containers.
+func (builder *Builder) WithHugePages() *Builder {
+	return builder.withPodTemplateMutation("hugepages", podtemplate.WithHugePages())
+}
+
+// WithAffinity applies affinity to the deployment's pod template.
+func (builder *Builder) WithAffinity(affinity *corev1.Affinity) *Builder {
+	return builder.withPodTemplateMutation("affinity", podtemplate.WithAffinity(affinity))
+}
+
+// WithTopologySpreadConstraint appends a topology spread constraint to the deployment's pod template.
+func (builder *Builder) WithTopologySpreadConstraint(constraint corev1.TopologySpreadConstraint) *Builder {
+	return builder.withPodTemplateMutation(
+		"topology spread constraint", podtemplate.WithTopologySpreadConstraint(constraint))
+}
+
+// WithPriorityClassName sets the priority class of the deployment's pod template.
+func (builder *Builder) WithPriorityClassName(priorityClassName string) *Builder {
+	return builder.withPodTemplateMutation("priorityClassName", podtemplate.WithPriorityClassName(priorityClassName))
+}
+
+// WithRuntimeClassName sets the runtime class of the deployment's pod template.
+func (builder *Builder) WithRuntimeClassName(runtimeClassName string) *Builder {
+	return builder.withPodTemplateMutation("runtimeClassName", podtemplate.WithRuntimeClassName(runtimeClassName))
+}
+
+// WithDNSConfig sets the DNS policy and DNS config of the deployment's pod template. The dnsConfig must define at
+// least one nameserver when the dnsPolicy is None and may be nil otherwise.
+func (builder *Builder) WithDNSConfig(dnsPolicy corev1.DNSPolicy, dnsConfig *corev1.PodDNSConfig) *Builder {
+	return builder.withPodTemplateMutation("dnsConfig", podtemplate.WithDNSConfig(dnsPolicy, dnsConfig))
+}
+
+// WithSecurityContext sets the pod security context of the deployment's pod template.
+func (builder *Builder) WithSecurityContext(securityContext *corev1.PodSecurityContext) *Builder {
+	return builder.withPodTemplateMutation("securityContext", podtemplate.WithSecurityContext(securityContext))
+}
+
+// WithServiceAccountName sets the service account the deployment's pods run as.
+func (builder *Builder) WithServiceAccountName(serviceAccountName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if serviceAccountName == "" {
+		klog.V(100).Info("The 'serviceAccount' of the deployment is empty")
+
+		builder.errorMsg = "can not apply empty serviceAccount"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation(
+		"serviceAccountName", podtemplate.WithServiceAccountName(serviceAccountName))
+}
+
+// WithSchedulerName configures a scheduler to process the scheduling of the deployment's pods.
+func (builder *Builder) WithSchedulerName(schedulerName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if schedulerName == "" {
+		klog.V(100).Info("Scheduler's name cannot be empty")
+
+		builder.errorMsg = "Scheduler's name cannot be empty"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("schedulerName", podtemplate.WithSchedulerName(schedulerName))
+}
+
+// WithTerminationGracePeriodSeconds configures terminationGracePeriodSeconds on the deployment's pod template.
+func (builder *Builder) WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds int64) *Builder {
+	return builder.withPodTemplateMutation(
+		"terminationGracePeriodSeconds", podtemplate.WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds))
+}
+
+// withPodTemplateMutation validates the builder and applies the mutator to the deployment's pod template, saving any
+// error on the builder.
+func (builder *Builder) withPodTemplateMutation(field string, mutator podtemplate.Mutator) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Applying %s to the pod template of deployment %s in namespace %s",
+		field, builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := mutator(&builder.Definition.Spec.Template); err != nil {
+		klog.V(100).Infof("Failed to apply %s to the pod template of deployment %s in namespace %s: %v",
+			field, builder.Definition.Name, builder.Definition.Namespace, err)
+
+		builder.errorMsg = err.Error()
+	}
+
+	return builder
+}
@@ -0,0 +1,295 @@
+package deployment
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/rollout"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// revisionAnnotation is set by the deployment controller on each ReplicaSet to record its revision.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RolloutStatus provides a summary of the rollout progress of a deployment.
+type RolloutStatus = rollout.Status
+
+// RolloutRevision describes a single revision in the rollout history of a deployment.
+type RolloutRevision = rollout.Revision
+
+// RolloutRestart triggers a rolling restart of the deployment by updating the restartedAt annotation on its pod
+// template, the same way kubectl rollout restart does.
+func (builder *Builder) RolloutRestart() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Restarting rollout of deployment %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot restart deployment %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	patch, restartedAt, err := rollout.RestartPatch()
+	if err != nil {
+		return builder, err
+	}
+
+	builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Patch(
+		logging.DiscardContext(), builder.Definition.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to restart deployment %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, err
+	}
+
+	if builder.Definition.Spec.Template.Annotations == nil {
+		builder.Definition.Spec.Template.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Spec.Template.Annotations[rollout.RestartedAtAnnotation] = restartedAt
+
+	return builder, nil
+}
+
+// RolloutStatus returns the current rollout status of the deployment, including the number of replicas owned by
+// each revision.
+func (builder *Builder) RolloutStatus() (*RolloutStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rollout status of deployment %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get rollout status of deployment %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	replicaSets, err := builder.listOwnedReplicaSets()
+	if err != nil {
+		return nil, err
+	}
+
+	status := getRolloutStatus(builder.Object)
+
+	for _, replicaSet := range replicaSets {
+		if replicaSet.Status.Replicas == 0 {
+			continue
+		}
+
+		revision, err := getReplicaSetRevision(replicaSet)
+		if err != nil {
+			return nil, err
+		}
+
+		status.RevisionReplicas[revision] += replicaSet.Status.Replicas
+	}
+
+	return status, nil
+}
+
+// WaitForRolloutComplete waits for the duration of the defined timeout or until the latest revision of the
+// deployment has been fully rolled out. It returns early with an error if the deployment exceeds its progress
+// deadline.
+func (builder *Builder) WaitForRolloutComplete(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until rollout of deployment %s in namespace %s is complete",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return fmt.Errorf("cannot wait for rollout of deployment %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("Failed to get deployment %s in namespace %s: %v",
+					builder.Definition.Name, builder.Definition.Namespace, err)
+
+				return false, nil
+			}
+
+			for _, condition := range builder.Object.Status.Conditions {
+				if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+					return false, fmt.Errorf("deployment %s in namespace %s exceeded its progress deadline: %s",
+						builder.Definition.Name, builder.Definition.Namespace, condition.Message)
+				}
+			}
+
+			return getRolloutStatus(builder.Object).Complete, nil
+		})
+}
+
+// RolloutHistory returns the revisions of the deployment, ordered from oldest to newest, based on the ReplicaSets
+// owned by the deployment.
+func (builder *Builder) RolloutHistory() ([]RolloutRevision, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rollout history of deployment %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get rollout history of deployment %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	replicaSets, err := builder.listOwnedReplicaSets()
+	if err != nil {
+		return nil, err
+	}
+
+	var history []RolloutRevision
+
+	for _, replicaSet := range replicaSets {
+		revision, err := getReplicaSetRevision(replicaSet)
+		if err != nil {
+			return nil, err
+		}
+
+		template := *replicaSet.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+		history = append(history, RolloutRevision{
+			Revision:    revision,
+			Name:        replicaSet.Name,
+			ChangeCause: replicaSet.Annotations[rollout.ChangeCauseAnnotation],
+			Template:    template,
+		})
+	}
+
+	slices.SortFunc(history, func(a, b RolloutRevision) int {
+		return cmp.Compare(a.Revision, b.Revision)
+	})
+
+	return history, nil
+}
+
+// RolloutUndo rolls the deployment back to the pod template of the provided revision. If toRevision is 0, the
+// deployment is rolled back to the revision immediately preceding the current one.
+func (builder *Builder) RolloutUndo(toRevision int64) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Rolling back deployment %s in namespace %s to revision %d",
+		builder.Definition.Name, builder.Definition.Namespace, toRevision)
+
+	if toRevision < 0 {
+		return builder, fmt.Errorf("deployment revision cannot be negative")
+	}
+
+	history, err := builder.RolloutHistory()
+	if err != nil {
+		return builder, err
+	}
+
+	target, err := rollout.FindUndoRevision(history, toRevision)
+	if err != nil {
+		return builder, fmt.Errorf("cannot roll back deployment %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	updated := builder.Object.DeepCopy()
+	updated.Spec.Template = target.Template
+
+	builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Update(
+		logging.DiscardContext(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to roll back deployment %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, err
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// listOwnedReplicaSets returns the ReplicaSets matching the deployment selector that are controlled by the
+// deployment. It assumes the builder is valid and builder.Object is set.
+func (builder *Builder) listOwnedReplicaSets() ([]*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(builder.Object.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse selector of deployment %s: %w", builder.Definition.Name, err)
+	}
+
+	replicaSetList, err := builder.apiClient.ReplicaSets(builder.Definition.Namespace).List(
+		logging.DiscardContext(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		klog.V(100).Infof("Failed to list replicasets of deployment %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return nil, err
+	}
+
+	var replicaSets []*appsv1.ReplicaSet
+
+	for index := range replicaSetList.Items {
+		if metav1.IsControlledBy(&replicaSetList.Items[index], builder.Object) {
+			replicaSets = append(replicaSets, &replicaSetList.Items[index])
+		}
+	}
+
+	return replicaSets, nil
+}
+
+// getRolloutStatus computes the rollout status of the deployment from its spec and status, using the same
+// completeness checks as kubectl rollout status. RevisionReplicas is initialized but left empty.
+func getRolloutStatus(deployment *appsv1.Deployment) *RolloutStatus {
+	desiredReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desiredReplicas = *deployment.Spec.Replicas
+	}
+
+	status := &RolloutStatus{
+		Generation:         deployment.Generation,
+		ObservedGeneration: deployment.Status.ObservedGeneration,
+		DesiredReplicas:    desiredReplicas,
+		UpdatedReplicas:    deployment.Status.UpdatedReplicas,
+		ReadyReplicas:      deployment.Status.ReadyReplicas,
+		AvailableReplicas:  deployment.Status.AvailableReplicas,
+		RevisionReplicas:   make(map[int64]int32),
+	}
+
+	status.Complete = deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == desiredReplicas &&
+		deployment.Status.Replicas == deployment.Status.UpdatedReplicas &&
+		deployment.Status.AvailableReplicas == deployment.Status.UpdatedReplicas
+
+	return status
+}
+
+// getReplicaSetRevision parses the revision annotation of a ReplicaSet owned by a deployment.
+func getReplicaSetRevision(replicaSet *appsv1.ReplicaSet) (int64, error) {
+	revision, err := strconv.ParseInt(replicaSet.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse revision of replicaset %s: %w", replicaSet.Name, err)
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,281 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/rollout"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const defaultDeploymentUID = types.UID("test-deployment-uid")
+
+func TestDeploymentRolloutRestart(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot restart deployment test-name in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutDeployment(true))
+		}
+
+		testBuilder, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutRestart()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.NotEmpty(t, testBuilder.Object.Spec.Template.Annotations[rollout.RestartedAtAnnotation])
+			assert.Equal(t, testBuilder.Object.Spec.Template.Annotations[rollout.RestartedAtAnnotation],
+				testBuilder.Definition.Spec.Template.Annotations[rollout.RestartedAtAnnotation])
+		}
+	}
+}
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	testCases := []struct {
+		complete         bool
+		expectedReplicas map[int64]int32
+	}{
+		{
+			complete:         true,
+			expectedReplicas: map[int64]int32{2: 2},
+		},
+		{
+			complete:         false,
+			expectedReplicas: map[int64]int32{1: 1, 2: 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyRolloutDeployment(testCase.complete)}
+		runtimeObjects = append(runtimeObjects, buildDummyRolloutReplicaSets(testCase.complete)...)
+
+		status, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutStatus()
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.complete, status.Complete)
+		assert.Equal(t, int32(2), status.DesiredReplicas)
+		assert.Equal(t, testCase.expectedReplicas, status.RevisionReplicas)
+	}
+}
+
+func TestDeploymentWaitForRolloutComplete(t *testing.T) {
+	testCases := []struct {
+		exists           bool
+		complete         bool
+		deadlineExceeded bool
+		expectedError    error
+	}{
+		{
+			exists:        true,
+			complete:      true,
+			expectedError: nil,
+		},
+		{
+			exists:        true,
+			complete:      false,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			exists:           true,
+			complete:         false,
+			deadlineExceeded: true,
+			expectedError: fmt.Errorf(
+				"deployment test-name in namespace test-namespace exceeded its progress deadline: timed out"),
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot wait for rollout of deployment test-name in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			deployment := buildDummyRolloutDeployment(testCase.complete)
+
+			if testCase.deadlineExceeded {
+				deployment.Status.Conditions = append(deployment.Status.Conditions, appsv1.DeploymentCondition{
+					Type:    appsv1.DeploymentProgressing,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ProgressDeadlineExceeded",
+					Message: "timed out",
+				})
+			}
+
+			runtimeObjects = append(runtimeObjects, deployment)
+		}
+
+		err := buildTestBuilderWithFakeObjects(runtimeObjects).WaitForRolloutComplete(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestDeploymentRolloutHistory(t *testing.T) {
+	runtimeObjects := []runtime.Object{buildDummyRolloutDeployment(false)}
+	runtimeObjects = append(runtimeObjects, buildDummyRolloutReplicaSets(false)...)
+
+	history, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutHistory()
+	assert.Nil(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, "first", history[0].ChangeCause)
+	assert.Equal(t, "image-1", history[0].Template.Spec.Containers[0].Image)
+	assert.NotContains(t, history[0].Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	assert.Equal(t, int64(2), history[1].Revision)
+}
+
+func TestDeploymentRolloutUndo(t *testing.T) {
+	testCases := []struct {
+		toRevision    int64
+		expectedImage string
+		expectedError string
+	}{
+		{
+			toRevision:    0,
+			expectedImage: "image-1",
+			expectedError: "",
+		},
+		{
+			toRevision:    1,
+			expectedImage: "image-1",
+			expectedError: "",
+		},
+		{
+			toRevision:    5,
+			expectedError: "cannot roll back deployment test-name in namespace test-namespace: revision 5 not found",
+		},
+		{
+			toRevision:    -1,
+			expectedError: "deployment revision cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyRolloutDeployment(false)}
+		runtimeObjects = append(runtimeObjects, buildDummyRolloutReplicaSets(false)...)
+
+		testBuilder, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutUndo(testCase.toRevision)
+		if testCase.expectedError == "" {
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedImage, testBuilder.Object.Spec.Template.Spec.Containers[0].Image)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError)
+		}
+	}
+}
+
+// buildDummyRolloutDeployment returns a deployment with two desired replicas whose status is either fully rolled out
+// or in the middle of a rollout.
+func buildDummyRolloutDeployment(complete bool) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-name",
+			Namespace:  "test-namespace",
+			UID:        defaultDeploymentUID,
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"test-key": "test-value"}},
+			Template: buildDummyPodTemplate("image-2"),
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+		},
+	}
+
+	if !complete {
+		deployment.Status.UpdatedReplicas = 1
+	}
+
+	return deployment
+}
+
+// buildDummyRolloutReplicaSets returns ReplicaSets for revisions 1 and 2 owned by the dummy rollout deployment, along
+// with a ReplicaSet that matches the selector but is not owned by it.
+func buildDummyRolloutReplicaSets(complete bool) []runtime.Object {
+	oldReplicas := int32(1)
+	newReplicas := int32(1)
+
+	if complete {
+		oldReplicas = 0
+		newReplicas = 2
+	}
+
+	return []runtime.Object{
+		buildDummyRolloutReplicaSet("test-name-1", "1", "first", "image-1", oldReplicas, true),
+		buildDummyRolloutReplicaSet("test-name-2", "2", "second", "image-2", newReplicas, true),
+		buildDummyRolloutReplicaSet("other", "3", "", "image-3", 1, false),
+	}
+}
+
+func buildDummyRolloutReplicaSet(
+	name, revision, changeCause, image string, replicas int32, owned bool) *appsv1.ReplicaSet {
+	template := buildDummyPodTemplate(image)
+	template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = name
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+			Labels:    template.Labels,
+			Annotations: map[string]string{
+				revisionAnnotation:            revision,
+				rollout.ChangeCauseAnnotation: changeCause,
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: ptr.To(replicas),
+			Template: template,
+		},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+	}
+
+	if owned {
+		replicaSet.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "test-name",
+			UID:        defaultDeploymentUID,
+			Controller: ptr.To(true),
+		}}
+	}
+
+	return replicaSet
+}
+
+func buildDummyPodTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"test-key": "test-value"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "test-container", Image: image}},
+		},
+	}
+}
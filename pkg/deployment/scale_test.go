@@ -0,0 +1,148 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeploymentScale(t *testing.T) {
+	testCases := []struct {
+		replicas      int32
+		exists        bool
+		expectedError error
+	}{
+		{
+			replicas:      3,
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			replicas:      0,
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			replicas:      -1,
+			exists:        true,
+			expectedError: fmt.Errorf("deployment 'replicas' cannot be negative"),
+		},
+		{
+			replicas: 3,
+			exists:   false,
+			expectedError: fmt.Errorf(
+				"cannot scale deployment test-name in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutDeployment(true))
+		}
+
+		testBuilder, err := buildScaleTestBuilder(runtimeObjects).Scale(testCase.replicas)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.replicas, *testBuilder.Object.Spec.Replicas)
+			assert.Equal(t, testCase.replicas, *testBuilder.Definition.Spec.Replicas)
+		}
+	}
+}
+
+func TestDeploymentWaitForReplicas(t *testing.T) {
+	testCases := []struct {
+		replicas      int32
+		expectedError error
+	}{
+		{
+			replicas:      2,
+			expectedError: nil,
+		},
+		{
+			replicas:      3,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			replicas:      -1,
+			expectedError: fmt.Errorf("deployment 'replicas' cannot be negative"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildTestBuilderWithFakeObjects([]runtime.Object{buildDummyRolloutDeployment(true)})
+
+		err := testBuilder.WaitForReplicas(testCase.replicas, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+// buildScaleTestBuilder returns a valid Builder whose fake client emulates the deployment scale subresource, which the
+// fake clientset does not support on its own.
+func buildScaleTestBuilder(objects []runtime.Object) *Builder {
+	fakeClient := k8sfake.NewSimpleClientset(objects...)
+	deploymentGVR := appsv1.SchemeGroupVersion.WithResource("deployments")
+
+	fakeClient.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+
+		getAction, _ := action.(k8stesting.GetAction)
+
+		object, err := fakeClient.Tracker().Get(deploymentGVR, getAction.GetNamespace(), getAction.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+
+		deployment, _ := object.(*appsv1.Deployment)
+
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: ptr.Deref(deployment.Spec.Replicas, 1)},
+		}, nil
+	})
+
+	fakeClient.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+
+		updateAction, _ := action.(k8stesting.UpdateAction)
+		scale, _ := updateAction.GetObject().(*autoscalingv1.Scale)
+
+		object, err := fakeClient.Tracker().Get(deploymentGVR, updateAction.GetNamespace(), scale.Name)
+		if err != nil {
+			return true, nil, err
+		}
+
+		deployment, _ := object.(*appsv1.Deployment)
+		deployment.Spec.Replicas = ptr.To(scale.Spec.Replicas)
+
+		return true, scale, fakeClient.Tracker().Update(deploymentGVR, deployment, deployment.Namespace)
+	})
+
+	return NewBuilder(&clients.Settings{
+		K8sClient:       fakeClient,
+		CoreV1Interface: fakeClient.CoreV1(),
+		AppsV1Interface: fakeClient.AppsV1(),
+	}, "test-name", "test-namespace", map[string]string{
+		"test-key": "test-value",
+	}, corev1.Container{
+		Name: "test-container",
+	})
+}
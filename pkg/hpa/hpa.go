@@ -0,0 +1,356 @@
+package hpa
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/common"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// AdditionalOptions are optional mutations applied via WithOptions.
+type AdditionalOptions func(builder *Builder) (*Builder, error)
+
+// Builder provides a HorizontalPodAutoscaler builder backed by the shared common builder framework.
+type Builder struct {
+	common.EmbeddableBuilder[autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscaler]
+	common.EmbeddableWithOptions[autoscalingv2.HorizontalPodAutoscaler, Builder,
+		*autoscalingv2.HorizontalPodAutoscaler, *Builder, AdditionalOptions]
+	common.EmbeddableCreator[autoscalingv2.HorizontalPodAutoscaler, Builder,
+		*autoscalingv2.HorizontalPodAutoscaler, *Builder]
+	common.EmbeddableDeleter[autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscaler]
+	common.EmbeddableUpdater[autoscalingv2.HorizontalPodAutoscaler, Builder,
+		*autoscalingv2.HorizontalPodAutoscaler, *Builder]
+}
+
+// AttachMixins wires the embedded CRUD mixins to this builder instance.
+func (builder *Builder) AttachMixins() {
+	builder.EmbeddableWithOptions.SetBase(builder)
+	builder.EmbeddableCreator.SetBase(builder)
+	builder.EmbeddableDeleter.SetBase(builder)
+	builder.EmbeddableUpdater.SetBase(builder)
+}
+
+// GetGVK returns the HorizontalPodAutoscaler GVK for this builder.
+func (builder *Builder) GetGVK() schema.GroupVersionKind {
+	return autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler")
+}
+
+// NewBuilder creates a new instance of Builder. The scale target is an apps/v1 resource of the provided kind, such as
+// Deployment or StatefulSet, and the autoscaler will never scale it above maxReplicas.
+func NewBuilder(
+	apiClient *clients.Settings, name, nsname, targetKind, targetName string, maxReplicas int32) *Builder {
+	klog.V(100).Infof(
+		"Initializing new horizontalpodautoscaler structure with the following params: name: %s, namespace: %s, "+
+			"targetKind: %s, targetName: %s, maxReplicas: %d", name, nsname, targetKind, targetName, maxReplicas)
+
+	builder := common.NewNamespacedBuilder[autoscalingv2.HorizontalPodAutoscaler, Builder](
+		apiClient, autoscalingv2.AddToScheme, name, nsname)
+	if builder.GetError() != nil {
+		return builder
+	}
+
+	if targetKind == "" {
+		klog.V(100).Info("The targetKind of the horizontalpodautoscaler is empty")
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler 'targetKind' cannot be empty"))
+
+		return builder
+	}
+
+	if targetName == "" {
+		klog.V(100).Info("The targetName of the horizontalpodautoscaler is empty")
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler 'targetName' cannot be empty"))
+
+		return builder
+	}
+
+	if maxReplicas < 1 {
+		klog.V(100).Info("The maxReplicas of the horizontalpodautoscaler is less than 1")
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler 'maxReplicas' must be at least 1"))
+
+		return builder
+	}
+
+	builder.Definition.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       targetKind,
+			Name:       targetName,
+		},
+		MaxReplicas: maxReplicas,
+	}
+
+	return builder
+}
+
+// Pull retrieves an existing horizontalpodautoscaler object from the cluster.
+func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
+	klog.V(100).Infof("Pulling existing horizontalpodautoscaler %s in namespace %s from cluster", name, nsname)
+
+	return common.PullNamespacedBuilder[autoscalingv2.HorizontalPodAutoscaler, Builder](
+		context.TODO(), apiClient, autoscalingv2.AddToScheme, name, nsname)
+}
+
+// WithMinReplicas sets the lower limit for the number of replicas the autoscaler can scale down to. It must be at
+// least 1 and cannot exceed maxReplicas.
+func (builder *Builder) WithMinReplicas(minReplicas int32) *Builder {
+	if err := common.Validate(builder); err != nil {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting minReplicas %d on horizontalpodautoscaler %s in namespace %s",
+		minReplicas, builder.Definition.Name, builder.Definition.Namespace)
+
+	if minReplicas < 1 {
+		klog.V(100).Info("The minReplicas of the horizontalpodautoscaler is less than 1")
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler 'minReplicas' must be at least 1"))
+
+		return builder
+	}
+
+	if minReplicas > builder.Definition.Spec.MaxReplicas {
+		klog.V(100).Info("The minReplicas of the horizontalpodautoscaler is greater than maxReplicas")
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler 'minReplicas' %d cannot be greater than "+
+			"'maxReplicas' %d", minReplicas, builder.Definition.Spec.MaxReplicas))
+
+		return builder
+	}
+
+	builder.Definition.Spec.MinReplicas = ptr.To(minReplicas)
+
+	return builder
+}
+
+// WithCPUUtilization targets the provided average CPU utilization across all pods, as a percentage of the requested
+// CPU. Any CPU resource metric already on the builder is replaced.
+func (builder *Builder) WithCPUUtilization(percent int32) *Builder {
+	return builder.withResourceUtilization(corev1.ResourceCPU, percent)
+}
+
+// WithMemoryUtilization targets the provided average memory utilization across all pods, as a percentage of the
+// requested memory. Any memory resource metric already on the builder is replaced.
+func (builder *Builder) WithMemoryUtilization(percent int32) *Builder {
+	return builder.withResourceUtilization(corev1.ResourceMemory, percent)
+}
+
+// WithMetric appends an arbitrary metric, such as a Pods, Object, External or ContainerResource metric, to the metrics
+// used to calculate the desired replica count.
+func (builder *Builder) WithMetric(metric autoscalingv2.MetricSpec) *Builder {
+	if err := common.Validate(builder); err != nil {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding metric of type %s to horizontalpodautoscaler %s in namespace %s",
+		metric.Type, builder.Definition.Name, builder.Definition.Namespace)
+
+	if metric.Type == "" {
+		klog.V(100).Info("The metric type of the horizontalpodautoscaler is empty")
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler metric 'type' cannot be empty"))
+
+		return builder
+	}
+
+	builder.Definition.Spec.Metrics = append(builder.Definition.Spec.Metrics, metric)
+
+	return builder
+}
+
+// WithScaleUpBehavior sets the scaling policies, stabilization window and policy selection used when scaling up.
+func (builder *Builder) WithScaleUpBehavior(rules autoscalingv2.HPAScalingRules) *Builder {
+	if err := common.Validate(builder); err != nil {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting scale up behavior on horizontalpodautoscaler %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if builder.Definition.Spec.Behavior == nil {
+		builder.Definition.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+	}
+
+	builder.Definition.Spec.Behavior.ScaleUp = &rules
+
+	return builder
+}
+
+// WithScaleDownBehavior sets the scaling policies, stabilization window and policy selection used when scaling down.
+func (builder *Builder) WithScaleDownBehavior(rules autoscalingv2.HPAScalingRules) *Builder {
+	if err := common.Validate(builder); err != nil {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting scale down behavior on horizontalpodautoscaler %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if builder.Definition.Spec.Behavior == nil {
+		builder.Definition.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+	}
+
+	builder.Definition.Spec.Behavior.ScaleDown = &rules
+
+	return builder
+}
+
+// GetCurrentReplicas returns the number of replicas of the scale target, as last seen by the autoscaler.
+func (builder *Builder) GetCurrentReplicas() (int32, error) {
+	status, err := builder.getStatus()
+	if err != nil {
+		return 0, err
+	}
+
+	return status.CurrentReplicas, nil
+}
+
+// GetDesiredReplicas returns the number of replicas the autoscaler last calculated for the scale target.
+func (builder *Builder) GetDesiredReplicas() (int32, error) {
+	status, err := builder.getStatus()
+	if err != nil {
+		return 0, err
+	}
+
+	return status.DesiredReplicas, nil
+}
+
+// GetCurrentMetrics returns the last read values of the metrics used by the autoscaler.
+func (builder *Builder) GetCurrentMetrics() ([]autoscalingv2.MetricStatus, error) {
+	status, err := builder.getStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return status.CurrentMetrics, nil
+}
+
+// WaitForDesiredReplicas waits for the duration of the defined timeout or until the autoscaler reports the provided
+// number of desired replicas.
+func (builder *Builder) WaitForDesiredReplicas(replicas int32, timeout time.Duration) error {
+	if err := common.Validate(builder); err != nil {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting until horizontalpodautoscaler %s in namespace %s desires %d replicas",
+		builder.Definition.Name, builder.Definition.Namespace, replicas)
+
+	return builder.waitForDesiredReplicas(timeout, func(desired int32) bool {
+		return desired == replicas
+	})
+}
+
+// WaitForDesiredReplicasChange waits for the duration of the defined timeout or until the number of desired replicas
+// reported by the autoscaler differs from the number it reports when this method is called. The new number of desired
+// replicas is returned.
+func (builder *Builder) WaitForDesiredReplicasChange(timeout time.Duration) (int32, error) {
+	initialReplicas, err := builder.GetDesiredReplicas()
+	if err != nil {
+		return 0, err
+	}
+
+	klog.V(100).Infof("Waiting until horizontalpodautoscaler %s in namespace %s no longer desires %d replicas",
+		builder.Definition.Name, builder.Definition.Namespace, initialReplicas)
+
+	var currentReplicas int32
+
+	err = builder.waitForDesiredReplicas(timeout, func(desired int32) bool {
+		currentReplicas = desired
+
+		return desired != initialReplicas
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return currentReplicas, nil
+}
+
+// GetGVR returns horizontalpodautoscaler's GroupVersionResource which could be used for Clean function.
+func GetGVR() schema.GroupVersionResource {
+	return autoscalingv2.SchemeGroupVersion.WithResource("horizontalpodautoscalers")
+}
+
+// withResourceUtilization replaces or adds the resource metric for resourceName with an average utilization target.
+func (builder *Builder) withResourceUtilization(resourceName corev1.ResourceName, percent int32) *Builder {
+	if err := common.Validate(builder); err != nil {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting %s utilization target %d%% on horizontalpodautoscaler %s in namespace %s",
+		resourceName, percent, builder.Definition.Name, builder.Definition.Namespace)
+
+	if percent < 1 {
+		klog.V(100).Infof("The %s utilization of the horizontalpodautoscaler is less than 1", resourceName)
+
+		builder.SetError(fmt.Errorf("horizontalpodautoscaler %s utilization must be at least 1", resourceName))
+
+		return builder
+	}
+
+	metric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resourceName,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(percent),
+			},
+		},
+	}
+
+	for index, existing := range builder.Definition.Spec.Metrics {
+		if existing.Type == autoscalingv2.ResourceMetricSourceType &&
+			existing.Resource != nil && existing.Resource.Name == resourceName {
+			builder.Definition.Spec.Metrics[index] = metric
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.Metrics = append(builder.Definition.Spec.Metrics, metric)
+
+	return builder
+}
+
+// getStatus pulls the horizontalpodautoscaler from the cluster, updating the builder's object, and returns its status.
+func (builder *Builder) getStatus() (*autoscalingv2.HorizontalPodAutoscalerStatus, error) {
+	if err := common.Validate(builder); err != nil {
+		return nil, err
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Object = object
+
+	return &object.Status, nil
+}
+
+// waitForDesiredReplicas polls the horizontalpodautoscaler until condition returns true for its desired replicas.
+// Errors getting the horizontalpodautoscaler are logged and retried.
+func (builder *Builder) waitForDesiredReplicas(timeout time.Duration, condition func(desired int32) bool) error {
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			desired, err := builder.GetDesiredReplicas()
+			if err != nil {
+				klog.V(100).Infof("Failed to get horizontalpodautoscaler %s in namespace %s: %v",
+					builder.Definition.Name, builder.Definition.Namespace, err)
+
+				return false, nil
+			}
+
+			return condition(desired), nil
+		})
+}
@@ -0,0 +1,451 @@
+package hpa
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	commonerrors "github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/common/errors"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/common/testhelper"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultHPAName      = "test-hpa"
+	defaultHPANamespace = "test-namespace"
+)
+
+var hpaGVK = autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler")
+
+func TestNewBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("common namespaced builder behavior", func(t *testing.T) {
+		t.Parallel()
+
+		testhelper.NewNamespacedBuilderTestConfig(
+			func(apiClient *clients.Settings, name, nsname string) *Builder {
+				return NewBuilder(apiClient, name, nsname, "Deployment", "test-deployment", 5)
+			},
+			autoscalingv2.AddToScheme,
+			hpaGVK,
+		).ExecuteTests(t)
+	})
+
+	testCases := []struct {
+		name          string
+		targetKind    string
+		targetName    string
+		maxReplicas   int32
+		expectedError error
+	}{
+		{
+			name:          "valid target sets scale target reference",
+			targetKind:    "Deployment",
+			targetName:    "test-deployment",
+			maxReplicas:   5,
+			expectedError: nil,
+		},
+		{
+			name:          "empty target kind returns error",
+			targetKind:    "",
+			targetName:    "test-deployment",
+			maxReplicas:   5,
+			expectedError: fmt.Errorf("horizontalpodautoscaler 'targetKind' cannot be empty"),
+		},
+		{
+			name:          "empty target name returns error",
+			targetKind:    "Deployment",
+			targetName:    "",
+			maxReplicas:   5,
+			expectedError: fmt.Errorf("horizontalpodautoscaler 'targetName' cannot be empty"),
+		},
+		{
+			name:          "zero max replicas returns error",
+			targetKind:    "Deployment",
+			targetName:    "test-deployment",
+			maxReplicas:   0,
+			expectedError: fmt.Errorf("horizontalpodautoscaler 'maxReplicas' must be at least 1"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			testBuilder := NewBuilder(newHPATestClient(), defaultHPAName, defaultHPANamespace,
+				testCase.targetKind, testCase.targetName, testCase.maxReplicas)
+			assert.Equal(t, testCase.expectedError, testBuilder.GetError())
+
+			if testCase.expectedError == nil {
+				assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       testCase.targetKind,
+					Name:       testCase.targetName,
+				}, testBuilder.Definition.Spec.ScaleTargetRef)
+				assert.Equal(t, testCase.maxReplicas, testBuilder.Definition.Spec.MaxReplicas)
+			}
+		})
+	}
+}
+
+func TestPull(t *testing.T) {
+	t.Parallel()
+
+	testhelper.NewNamespacedPullTestConfig(Pull, autoscalingv2.AddToScheme, hpaGVK).ExecuteTests(t)
+}
+
+func TestBuilderMethods(t *testing.T) {
+	t.Parallel()
+
+	commonConfig := newHPACommonTestConfig()
+
+	testhelper.NewTestSuite().
+		With(testhelper.NewGetTestConfig(commonConfig)).
+		With(testhelper.NewExistsTestConfig(commonConfig)).
+		With(testhelper.NewCreateTestConfig(commonConfig)).
+		With(testhelper.NewDeleterTestConfig(commonConfig)).
+		With(testhelper.NewUpdateTestConfig(commonConfig)).
+		Run(t)
+}
+
+func TestWithOptions(t *testing.T) {
+	t.Parallel()
+
+	testhelper.NewWithOptionsTestConfig(newHPACommonTestConfig()).ExecuteTests(t)
+}
+
+func TestWithMinReplicas(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		minReplicas   int32
+		expectedError error
+	}{
+		{
+			name:          "valid min replicas",
+			minReplicas:   2,
+			expectedError: nil,
+		},
+		{
+			name:          "zero min replicas returns error",
+			minReplicas:   0,
+			expectedError: fmt.Errorf("horizontalpodautoscaler 'minReplicas' must be at least 1"),
+		},
+		{
+			name:          "min replicas above max returns error",
+			minReplicas:   6,
+			expectedError: fmt.Errorf("horizontalpodautoscaler 'minReplicas' 6 cannot be greater than 'maxReplicas' 5"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			testBuilder := buildValidHPATestBuilder(newHPATestClient()).WithMinReplicas(testCase.minReplicas)
+			assert.Equal(t, testCase.expectedError, testBuilder.GetError())
+
+			if testCase.expectedError == nil {
+				assert.Equal(t, ptr.To(testCase.minReplicas), testBuilder.Definition.Spec.MinReplicas)
+			} else {
+				assert.Nil(t, testBuilder.Definition.Spec.MinReplicas)
+			}
+		})
+	}
+}
+
+func TestWithResourceUtilization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cpu and memory targets are added once each", func(t *testing.T) {
+		t.Parallel()
+
+		testBuilder := buildValidHPATestBuilder(newHPATestClient()).
+			WithCPUUtilization(50).
+			WithMemoryUtilization(70).
+			WithCPUUtilization(80)
+		assert.Nil(t, testBuilder.GetError())
+		assert.Len(t, testBuilder.Definition.Spec.Metrics, 2)
+
+		cpuMetric := testBuilder.Definition.Spec.Metrics[0]
+		assert.Equal(t, autoscalingv2.ResourceMetricSourceType, cpuMetric.Type)
+		assert.Equal(t, corev1.ResourceCPU, cpuMetric.Resource.Name)
+		assert.Equal(t, autoscalingv2.UtilizationMetricType, cpuMetric.Resource.Target.Type)
+		assert.Equal(t, ptr.To[int32](80), cpuMetric.Resource.Target.AverageUtilization)
+
+		memoryMetric := testBuilder.Definition.Spec.Metrics[1]
+		assert.Equal(t, corev1.ResourceMemory, memoryMetric.Resource.Name)
+		assert.Equal(t, ptr.To[int32](70), memoryMetric.Resource.Target.AverageUtilization)
+	})
+
+	t.Run("zero utilization returns error", func(t *testing.T) {
+		t.Parallel()
+
+		testBuilder := buildValidHPATestBuilder(newHPATestClient()).WithCPUUtilization(0)
+		assert.Equal(t, fmt.Errorf("horizontalpodautoscaler cpu utilization must be at least 1"), testBuilder.GetError())
+		assert.Empty(t, testBuilder.Definition.Spec.Metrics)
+	})
+
+	t.Run("invalid builder short circuits", func(t *testing.T) {
+		t.Parallel()
+
+		testBuilder := NewBuilder(newHPATestClient(), "", defaultHPANamespace, "Deployment", "test-deployment", 5).
+			WithMemoryUtilization(50)
+		assert.True(t, commonerrors.IsBuilderNameEmpty(testBuilder.GetError()))
+		assert.Empty(t, testBuilder.Definition.Spec.Metrics)
+	})
+}
+
+func TestWithMetric(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		metric        autoscalingv2.MetricSpec
+		expectedError error
+	}{
+		{
+			name: "valid pods metric",
+			metric: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "empty metric type returns error",
+			metric:        autoscalingv2.MetricSpec{},
+			expectedError: fmt.Errorf("horizontalpodautoscaler metric 'type' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			testBuilder := buildValidHPATestBuilder(newHPATestClient()).WithMetric(testCase.metric)
+			assert.Equal(t, testCase.expectedError, testBuilder.GetError())
+
+			if testCase.expectedError == nil {
+				assert.Equal(t, []autoscalingv2.MetricSpec{testCase.metric}, testBuilder.Definition.Spec.Metrics)
+			} else {
+				assert.Empty(t, testBuilder.Definition.Spec.Metrics)
+			}
+		})
+	}
+}
+
+func TestWithScaleBehavior(t *testing.T) {
+	t.Parallel()
+
+	scaleUpRules := autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: ptr.To[int32](0),
+		Policies: []autoscalingv2.HPAScalingPolicy{{
+			Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15,
+		}},
+	}
+	scaleDownRules := autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](300)}
+
+	testBuilder := buildValidHPATestBuilder(newHPATestClient()).
+		WithScaleUpBehavior(scaleUpRules).
+		WithScaleDownBehavior(scaleDownRules)
+	assert.Nil(t, testBuilder.GetError())
+	assert.Equal(t, &scaleUpRules, testBuilder.Definition.Spec.Behavior.ScaleUp)
+	assert.Equal(t, &scaleDownRules, testBuilder.Definition.Spec.Behavior.ScaleDown)
+}
+
+func TestStatusAccessors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("existing autoscaler returns status", func(t *testing.T) {
+		t.Parallel()
+
+		testBuilder := buildValidHPATestBuilder(newHPATestClient(buildDummyHPA(2, 4)))
+
+		currentReplicas, err := testBuilder.GetCurrentReplicas()
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), currentReplicas)
+
+		desiredReplicas, err := testBuilder.GetDesiredReplicas()
+		assert.Nil(t, err)
+		assert.Equal(t, int32(4), desiredReplicas)
+
+		currentMetrics, err := testBuilder.GetCurrentMetrics()
+		assert.Nil(t, err)
+		assert.Len(t, currentMetrics, 1)
+		assert.Equal(t, corev1.ResourceCPU, currentMetrics[0].Resource.Name)
+	})
+
+	t.Run("missing autoscaler returns error", func(t *testing.T) {
+		t.Parallel()
+
+		testBuilder := buildValidHPATestBuilder(newHPATestClient())
+
+		_, err := testBuilder.GetDesiredReplicas()
+		assert.True(t, commonerrors.IsAPICallFailedWithVerb(err, "get"))
+	})
+}
+
+func TestWaitForDesiredReplicas(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		replicas      int32
+		exists        bool
+		expectedError error
+	}{
+		{
+			name:          "desired replicas reached",
+			replicas:      4,
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			name:          "desired replicas not reached",
+			replicas:      3,
+			exists:        true,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			name:          "autoscaler does not exist",
+			replicas:      4,
+			exists:        false,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var runtimeObjects []runtime.Object
+
+			if testCase.exists {
+				runtimeObjects = append(runtimeObjects, buildDummyHPA(2, 4))
+			}
+
+			err := buildValidHPATestBuilder(newHPATestClient(runtimeObjects...)).
+				WaitForDesiredReplicas(testCase.replicas, time.Second)
+			assert.Equal(t, testCase.expectedError, err)
+		})
+	}
+}
+
+func TestWaitForDesiredReplicasChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unchanged desired replicas times out", func(t *testing.T) {
+		t.Parallel()
+
+		replicas, err := buildValidHPATestBuilder(newHPATestClient(buildDummyHPA(2, 4))).
+			WaitForDesiredReplicasChange(time.Second)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, int32(0), replicas)
+	})
+
+	t.Run("changed desired replicas are returned", func(t *testing.T) {
+		t.Parallel()
+
+		apiClient := newHPATestClient(buildDummyHPA(2, 4))
+		testBuilder := buildValidHPATestBuilder(apiClient)
+
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+
+			err := apiClient.Get(context.TODO(), runtimeclient.ObjectKey{
+				Name: defaultHPAName, Namespace: defaultHPANamespace}, hpa)
+			if err != nil {
+				return
+			}
+
+			hpa.Status.DesiredReplicas = 6
+
+			_ = apiClient.Update(context.TODO(), hpa)
+		}()
+
+		replicas, err := testBuilder.WaitForDesiredReplicasChange(5 * time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(6), replicas)
+	})
+
+	t.Run("missing autoscaler returns error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := buildValidHPATestBuilder(newHPATestClient()).WaitForDesiredReplicasChange(time.Second)
+		assert.True(t, commonerrors.IsAPICallFailedWithVerb(err, "get"))
+	})
+}
+
+func TestGetGVR(t *testing.T) {
+	t.Parallel()
+
+	testGVR := GetGVR()
+	assert.Equal(t, "horizontalpodautoscalers", testGVR.Resource)
+	assert.Equal(t, "v2", testGVR.Version)
+	assert.Equal(t, "autoscaling", testGVR.Group)
+}
+
+// newHPACommonTestConfig returns the shared testhelper configuration for horizontalpodautoscaler builder tests.
+func newHPACommonTestConfig() testhelper.CommonTestConfig[
+	autoscalingv2.HorizontalPodAutoscaler, Builder, *autoscalingv2.HorizontalPodAutoscaler, *Builder] {
+	return testhelper.NewCommonTestConfig[autoscalingv2.HorizontalPodAutoscaler, Builder](
+		autoscalingv2.AddToScheme, hpaGVK, testhelper.ResourceScopeNamespaced)
+}
+
+// newHPATestClient returns a fake client configured with the autoscaling/v2 scheme and the provided objects.
+func newHPATestClient(objects ...runtime.Object) *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects:  objects,
+		SchemeAttachers: []clients.SchemeAttacher{autoscalingv2.AddToScheme},
+	})
+}
+
+func buildValidHPATestBuilder(apiClient *clients.Settings) *Builder {
+	return NewBuilder(apiClient, defaultHPAName, defaultHPANamespace, "Deployment", "test-deployment", 5)
+}
+
+// buildDummyHPA returns a horizontalpodautoscaler with the provided current and desired replicas in its status and a
+// single CPU metric.
+func buildDummyHPA(currentReplicas, desiredReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultHPAName,
+			Namespace: defaultHPANamespace,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "test-deployment",
+			},
+			MaxReplicas: 5,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: currentReplicas,
+			DesiredReplicas: desiredReplicas,
+			CurrentMetrics: []autoscalingv2.MetricStatus{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name:    corev1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{AverageUtilization: ptr.To[int32](90)},
+				},
+			}},
+		},
+	}
+}
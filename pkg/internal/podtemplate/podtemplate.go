@@ -0,0 +1,335 @@
+// Package podtemplate provides mutators for corev1.PodTemplateSpec that are shared by the workload builders. Each
+// builder exposes the same set of methods and delegates to the mutators in this package. Builders whose methods
+// predate this package validate the arguments themselves before delegating so they keep their existing error
+// messages.
+package podtemplate
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// hugePagesVolumeName is the name of the volume added by WithHugePages.
+	hugePagesVolumeName = "hugepages"
+	// hugePagesMountPath is the path where WithHugePages mounts the hugepages volume in every container.
+	hugePagesMountPath = "/mnt/huge"
+)
+
+// Mutator updates the provided pod template in place. It returns an error without modifying the template if the
+// update cannot be applied.
+type Mutator func(template *corev1.PodTemplateSpec) error
+
+// WithNodeSelector returns a mutator that replaces the nodeSelector of the pod template.
+func WithNodeSelector(nodeSelector map[string]string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		template.Spec.NodeSelector = nodeSelector
+
+		return nil
+	}
+}
+
+// WithToleration returns a mutator that appends the toleration to the pod template.
+func WithToleration(toleration corev1.Toleration) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		template.Spec.Tolerations = append(template.Spec.Tolerations, toleration)
+
+		return nil
+	}
+}
+
+// WithVolume returns a mutator that appends the volume to the pod template.
+func WithVolume(volume corev1.Volume) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if volume.Name == "" {
+			return fmt.Errorf("the volume's name cannot be empty")
+		}
+
+		template.Spec.Volumes = append(template.Spec.Volumes, volume)
+
+		return nil
+	}
+}
+
+// WithHugePages returns a mutator that adds a HugePages backed emptyDir volume to the pod template and mounts it at
+// /mnt/huge in every container.
+func WithHugePages() Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: hugePagesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumHugePages},
+			},
+		})
+
+		for index := range template.Spec.Containers {
+			template.Spec.Containers[index].VolumeMounts = append(template.Spec.Containers[index].VolumeMounts,
+				corev1.VolumeMount{Name: hugePagesVolumeName, MountPath: hugePagesMountPath})
+		}
+
+		return nil
+	}
+}
+
+// WithAffinity returns a mutator that replaces the affinity of the pod template.
+func WithAffinity(affinity *corev1.Affinity) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if affinity == nil {
+			return fmt.Errorf("affinity parameter is empty")
+		}
+
+		template.Spec.Affinity = affinity
+
+		return nil
+	}
+}
+
+// WithTopologySpreadConstraint returns a mutator that appends the topology spread constraint to the pod template.
+func WithTopologySpreadConstraint(constraint corev1.TopologySpreadConstraint) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if constraint.TopologyKey == "" {
+			return fmt.Errorf("topology spread constraint topologyKey cannot be empty")
+		}
+
+		if constraint.MaxSkew < 1 {
+			return fmt.Errorf("topology spread constraint maxSkew must be greater than zero")
+		}
+
+		if constraint.WhenUnsatisfiable == "" {
+			return fmt.Errorf("topology spread constraint whenUnsatisfiable cannot be empty")
+		}
+
+		template.Spec.TopologySpreadConstraints = append(template.Spec.TopologySpreadConstraints, constraint)
+
+		return nil
+	}
+}
+
+// WithPriorityClassName returns a mutator that sets the priorityClassName of the pod template.
+func WithPriorityClassName(priorityClassName string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if priorityClassName == "" {
+			return fmt.Errorf("priorityClassName cannot be empty")
+		}
+
+		template.Spec.PriorityClassName = priorityClassName
+
+		return nil
+	}
+}
+
+// WithRuntimeClassName returns a mutator that sets the runtimeClassName of the pod template.
+func WithRuntimeClassName(runtimeClassName string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if runtimeClassName == "" {
+			return fmt.Errorf("runtimeClassName cannot be empty")
+		}
+
+		template.Spec.RuntimeClassName = ptr.To(runtimeClassName)
+
+		return nil
+	}
+}
+
+// WithDNSConfig returns a mutator that sets the dnsPolicy and dnsConfig of the pod template. The dnsConfig may be nil
+// unless the policy is None, in which case it must provide at least one nameserver.
+func WithDNSConfig(dnsPolicy corev1.DNSPolicy, dnsConfig *corev1.PodDNSConfig) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		switch dnsPolicy {
+		case corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+		case corev1.DNSNone:
+			if dnsConfig == nil || len(dnsConfig.Nameservers) == 0 {
+				return fmt.Errorf("dnsConfig must define at least one nameserver when dnsPolicy is %s", corev1.DNSNone)
+			}
+		default:
+			return fmt.Errorf("invalid dnsPolicy %q", dnsPolicy)
+		}
+
+		template.Spec.DNSPolicy = dnsPolicy
+		template.Spec.DNSConfig = dnsConfig
+
+		return nil
+	}
+}
+
+// WithSecurityContext returns a mutator that replaces the pod security context of the pod template.
+func WithSecurityContext(securityContext *corev1.PodSecurityContext) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if securityContext == nil {
+			return fmt.Errorf("'securityContext' parameter is empty")
+		}
+
+		template.Spec.SecurityContext = securityContext
+
+		return nil
+	}
+}
+
+// WithServiceAccountName returns a mutator that sets the serviceAccountName of the pod template.
+func WithServiceAccountName(serviceAccountName string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if serviceAccountName == "" {
+			return fmt.Errorf("serviceAccountName cannot be empty")
+		}
+
+		template.Spec.ServiceAccountName = serviceAccountName
+
+		return nil
+	}
+}
+
+// WithSchedulerName returns a mutator that sets the schedulerName of the pod template.
+func WithSchedulerName(schedulerName string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if schedulerName == "" {
+			return fmt.Errorf("schedulerName cannot be empty")
+		}
+
+		template.Spec.SchedulerName = schedulerName
+
+		return nil
+	}
+}
+
+// WithTerminationGracePeriodSeconds returns a mutator that sets the terminationGracePeriodSeconds of the pod
+// template.
+func WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds int64) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		template.Spec.TerminationGracePeriodSeconds = ptr.To(terminationGracePeriodSeconds)
+
+		return nil
+	}
+}
+
+// WithNodeName returns a mutator that schedules the pod template on the named node.
+func WithNodeName(nodeName string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if nodeName == "" {
+			return fmt.Errorf("nodeName cannot be empty")
+		}
+
+		template.Spec.NodeName = nodeName
+
+		return nil
+	}
+}
+
+// WithDefaultCommand returns a mutator that replaces the command of the first container of the pod template.
+func WithDefaultCommand(command []string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if len(command) == 0 {
+			return fmt.Errorf("command cannot be empty")
+		}
+
+		if len(template.Spec.Containers) == 0 {
+			return fmt.Errorf("pod template has no default container")
+		}
+
+		template.Spec.Containers[0].Command = command
+
+		return nil
+	}
+}
+
+// WithDefaultContainer returns a mutator that replaces the first container of the pod template.
+func WithDefaultContainer(container corev1.Container) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if len(template.Spec.Containers) == 0 {
+			return fmt.Errorf("pod template has no default container")
+		}
+
+		template.Spec.Containers[0] = container
+
+		return nil
+	}
+}
+
+// WithRestartPolicy returns a mutator that sets the restartPolicy of the pod template.
+func WithRestartPolicy(restartPolicy corev1.RestartPolicy) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		switch restartPolicy {
+		case corev1.RestartPolicyAlways, corev1.RestartPolicyOnFailure, corev1.RestartPolicyNever:
+		default:
+			return fmt.Errorf("invalid restartPolicy %q", restartPolicy)
+		}
+
+		template.Spec.RestartPolicy = restartPolicy
+
+		return nil
+	}
+}
+
+// WithPrivilegedFlag returns a mutator that replaces the security context of every container of the pod template
+// with a privileged one.
+func WithPrivilegedFlag() Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		for index := range template.Spec.Containers {
+			template.Spec.Containers[index].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+		}
+
+		return nil
+	}
+}
+
+// WithAdditionalContainer returns a mutator that appends the container to the pod template.
+func WithAdditionalContainer(container *corev1.Container) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if container == nil {
+			return fmt.Errorf("'container' parameter cannot be empty")
+		}
+
+		template.Spec.Containers = append(template.Spec.Containers, *container)
+
+		return nil
+	}
+}
+
+// WithAdditionalInitContainer returns a mutator that appends the init container to the pod template.
+func WithAdditionalInitContainer(container *corev1.Container) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if container == nil {
+			return fmt.Errorf("'container' parameter cannot be empty")
+		}
+
+		template.Spec.InitContainers = append(template.Spec.InitContainers, *container)
+
+		return nil
+	}
+}
+
+// WithHostNetwork returns a mutator that sets the hostNetwork flag of the pod template.
+func WithHostNetwork(hostNetwork bool) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		template.Spec.HostNetwork = hostNetwork
+
+		return nil
+	}
+}
+
+// WithHostPID returns a mutator that sets the hostPID flag of the pod template.
+func WithHostPID(hostPID bool) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		template.Spec.HostPID = hostPID
+
+		return nil
+	}
+}
+
+// WithLabel returns a mutator that adds the label to the metadata of the pod template.
+func WithLabel(labelKey, labelValue string) Mutator {
+	return func(template *corev1.PodTemplateSpec) error {
+		if labelKey == "" {
+			return fmt.Errorf("can not apply empty labelKey")
+		}
+
+		if template.Labels == nil {
+			template.Labels = make(map[string]string)
+		}
+
+		template.Labels[labelKey] = labelValue
+
+		return nil
+	}
+}
@@ -0,0 +1,360 @@
+package podtemplate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestMutators(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		mutator       Mutator
+		expectedError error
+		verify        func(t *testing.T, template *corev1.PodTemplateSpec)
+	}{
+		{
+			name:    "node selector",
+			mutator: WithNodeSelector(map[string]string{"a": "b"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, map[string]string{"a": "b"}, template.Spec.NodeSelector)
+			},
+		},
+		{
+			name:    "toleration",
+			mutator: WithToleration(corev1.Toleration{Key: "key", Operator: corev1.TolerationOpExists}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, []corev1.Toleration{{Key: "key", Operator: corev1.TolerationOpExists}},
+					template.Spec.Tolerations)
+			},
+		},
+		{
+			name:    "volume",
+			mutator: WithVolume(corev1.Volume{Name: "new-volume"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Len(t, template.Spec.Volumes, 2)
+				assert.Equal(t, "new-volume", template.Spec.Volumes[1].Name)
+			},
+		},
+		{
+			name:          "volume without name",
+			mutator:       WithVolume(corev1.Volume{}),
+			expectedError: fmt.Errorf("the volume's name cannot be empty"),
+		},
+		{
+			name:    "volume with existing name",
+			mutator: WithVolume(corev1.Volume{Name: "existing-volume"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Len(t, template.Spec.Volumes, 2)
+			},
+		},
+		{
+			name:    "hugepages",
+			mutator: WithHugePages(),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.StorageMediumHugePages, template.Spec.Volumes[1].EmptyDir.Medium)
+
+				for _, container := range template.Spec.Containers {
+					assert.Contains(t, container.VolumeMounts,
+						corev1.VolumeMount{Name: hugePagesVolumeName, MountPath: hugePagesMountPath})
+				}
+			},
+		},
+		{
+			name:    "affinity",
+			mutator: WithAffinity(&corev1.Affinity{PodAffinity: &corev1.PodAffinity{}}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.NotNil(t, template.Spec.Affinity.PodAffinity)
+			},
+		},
+		{
+			name:          "nil affinity",
+			mutator:       WithAffinity(nil),
+			expectedError: fmt.Errorf("affinity parameter is empty"),
+		},
+		{
+			name: "topology spread constraint",
+			mutator: WithTopologySpreadConstraint(corev1.TopologySpreadConstraint{
+				MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.DoNotSchedule}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Len(t, template.Spec.TopologySpreadConstraints, 1)
+			},
+		},
+		{
+			name: "topology spread constraint without key",
+			mutator: WithTopologySpreadConstraint(corev1.TopologySpreadConstraint{
+				MaxSkew: 1, WhenUnsatisfiable: corev1.DoNotSchedule}),
+			expectedError: fmt.Errorf("topology spread constraint topologyKey cannot be empty"),
+		},
+		{
+			name: "topology spread constraint with zero skew",
+			mutator: WithTopologySpreadConstraint(corev1.TopologySpreadConstraint{
+				TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.DoNotSchedule}),
+			expectedError: fmt.Errorf("topology spread constraint maxSkew must be greater than zero"),
+		},
+		{
+			name: "topology spread constraint without whenUnsatisfiable",
+			mutator: WithTopologySpreadConstraint(corev1.TopologySpreadConstraint{
+				MaxSkew: 1, TopologyKey: corev1.LabelHostname}),
+			expectedError: fmt.Errorf("topology spread constraint whenUnsatisfiable cannot be empty"),
+		},
+		{
+			name:    "priority class",
+			mutator: WithPriorityClassName("high"),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, "high", template.Spec.PriorityClassName)
+			},
+		},
+		{
+			name:          "empty priority class",
+			mutator:       WithPriorityClassName(""),
+			expectedError: fmt.Errorf("priorityClassName cannot be empty"),
+		},
+		{
+			name:    "runtime class",
+			mutator: WithRuntimeClassName("performance"),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To("performance"), template.Spec.RuntimeClassName)
+			},
+		},
+		{
+			name:          "empty runtime class",
+			mutator:       WithRuntimeClassName(""),
+			expectedError: fmt.Errorf("runtimeClassName cannot be empty"),
+		},
+		{
+			name:    "dns config",
+			mutator: WithDNSConfig(corev1.DNSNone, &corev1.PodDNSConfig{Nameservers: []string{"1.1.1.1"}}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.DNSNone, template.Spec.DNSPolicy)
+				assert.Equal(t, []string{"1.1.1.1"}, template.Spec.DNSConfig.Nameservers)
+			},
+		},
+		{
+			name:    "dns policy without config",
+			mutator: WithDNSConfig(corev1.DNSClusterFirstWithHostNet, nil),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.DNSClusterFirstWithHostNet, template.Spec.DNSPolicy)
+				assert.Nil(t, template.Spec.DNSConfig)
+			},
+		},
+		{
+			name:          "dns policy none without nameservers",
+			mutator:       WithDNSConfig(corev1.DNSNone, nil),
+			expectedError: fmt.Errorf("dnsConfig must define at least one nameserver when dnsPolicy is None"),
+		},
+		{
+			name:          "invalid dns policy",
+			mutator:       WithDNSConfig("invalid", nil),
+			expectedError: fmt.Errorf("invalid dnsPolicy \"invalid\""),
+		},
+		{
+			name:    "security context",
+			mutator: WithSecurityContext(&corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1000)}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int64](1000), template.Spec.SecurityContext.RunAsUser)
+			},
+		},
+		{
+			name:          "nil security context",
+			mutator:       WithSecurityContext(nil),
+			expectedError: fmt.Errorf("'securityContext' parameter is empty"),
+		},
+		{
+			name:    "service account",
+			mutator: WithServiceAccountName("test-sa"),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, "test-sa", template.Spec.ServiceAccountName)
+			},
+		},
+		{
+			name:          "empty service account",
+			mutator:       WithServiceAccountName(""),
+			expectedError: fmt.Errorf("serviceAccountName cannot be empty"),
+		},
+		{
+			name:    "scheduler name",
+			mutator: WithSchedulerName("test-scheduler"),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, "test-scheduler", template.Spec.SchedulerName)
+			},
+		},
+		{
+			name:          "empty scheduler name",
+			mutator:       WithSchedulerName(""),
+			expectedError: fmt.Errorf("schedulerName cannot be empty"),
+		},
+		{
+			name:    "termination grace period",
+			mutator: WithTerminationGracePeriodSeconds(5),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int64](5), template.Spec.TerminationGracePeriodSeconds)
+			},
+		},
+		{
+			name:    "node name",
+			mutator: WithNodeName("test-node"),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, "test-node", template.Spec.NodeName)
+			},
+		},
+		{
+			name:          "empty node name",
+			mutator:       WithNodeName(""),
+			expectedError: fmt.Errorf("nodeName cannot be empty"),
+		},
+		{
+			name:    "default command",
+			mutator: WithDefaultCommand([]string{"fio"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, []string{"fio"}, template.Spec.Containers[0].Command)
+				assert.Empty(t, template.Spec.Containers[1].Command)
+			},
+		},
+		{
+			name:          "empty default command",
+			mutator:       WithDefaultCommand(nil),
+			expectedError: fmt.Errorf("command cannot be empty"),
+		},
+		{
+			name:    "default container",
+			mutator: WithDefaultContainer(corev1.Container{Name: "iperf", Image: "iperf-image"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.Container{Name: "iperf", Image: "iperf-image"}, template.Spec.Containers[0])
+				assert.Equal(t, "second", template.Spec.Containers[1].Name)
+			},
+		},
+		{
+			name:    "restart policy",
+			mutator: WithRestartPolicy(corev1.RestartPolicyOnFailure),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.RestartPolicyOnFailure, template.Spec.RestartPolicy)
+			},
+		},
+		{
+			name:          "invalid restart policy",
+			mutator:       WithRestartPolicy("Sometimes"),
+			expectedError: fmt.Errorf("invalid restartPolicy \"Sometimes\""),
+		},
+		{
+			name:    "privileged flag",
+			mutator: WithPrivilegedFlag(),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+
+				for _, container := range template.Spec.Containers {
+					assert.Equal(t, ptr.To(true), container.SecurityContext.Privileged)
+				}
+			},
+		},
+		{
+			name:    "additional container",
+			mutator: WithAdditionalContainer(&corev1.Container{Name: "sidecar"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Len(t, template.Spec.Containers, 3)
+				assert.Equal(t, "sidecar", template.Spec.Containers[2].Name)
+			},
+		},
+		{
+			name:          "nil additional container",
+			mutator:       WithAdditionalContainer(nil),
+			expectedError: fmt.Errorf("'container' parameter cannot be empty"),
+		},
+		{
+			name:    "additional init container",
+			mutator: WithAdditionalInitContainer(&corev1.Container{Name: "init"}),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, []corev1.Container{{Name: "init"}}, template.Spec.InitContainers)
+			},
+		},
+		{
+			name:          "nil additional init container",
+			mutator:       WithAdditionalInitContainer(nil),
+			expectedError: fmt.Errorf("'container' parameter cannot be empty"),
+		},
+		{
+			name:    "host network",
+			mutator: WithHostNetwork(true),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.True(t, template.Spec.HostNetwork)
+			},
+		},
+		{
+			name:    "host pid",
+			mutator: WithHostPID(true),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.True(t, template.Spec.HostPID)
+			},
+		},
+		{
+			name:    "label",
+			mutator: WithLabel("app", "fio"),
+			verify: func(t *testing.T, template *corev1.PodTemplateSpec) {
+				t.Helper()
+				assert.Equal(t, map[string]string{"app": "fio"}, template.Labels)
+			},
+		},
+		{
+			name:          "empty label key",
+			mutator:       WithLabel("", "fio"),
+			expectedError: fmt.Errorf("can not apply empty labelKey"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			template := buildTestTemplate()
+			original := template.DeepCopy()
+
+			err := testCase.mutator(template)
+			assert.Equal(t, testCase.expectedError, err)
+
+			if testCase.expectedError != nil {
+				assert.Equal(t, original, template)
+
+				return
+			}
+
+			testCase.verify(t, template)
+		})
+	}
+}
+
+func buildTestTemplate() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "first"}, {Name: "second"}},
+			Volumes:    []corev1.Volume{{Name: "existing-volume"}},
+		},
+	}
+}
@@ -0,0 +1,198 @@
+// Package rollout provides the rollout status and revision types and the restart patch shared by the deployment,
+// daemonset and statefulset builders, along with the ControllerRevision based history and undo logic used by
+// daemonsets and statefulsets.
+package rollout
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// RestartedAtAnnotation is the pod template annotation used by kubectl to trigger a rollout restart.
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// ChangeCauseAnnotation records the reason for a change to a workload.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// Status provides a summary of the rollout progress of a workload.
+type Status struct {
+	// Generation is the generation of the workload spec.
+	Generation int64
+	// ObservedGeneration is the most recent generation observed by the workload controller.
+	ObservedGeneration int64
+	// DesiredReplicas is the number of replicas requested by the workload. For daemonsets, it is the number of nodes
+	// that should be running the daemon pod.
+	DesiredReplicas int32
+	// UpdatedReplicas is the number of replicas running the latest pod template.
+	UpdatedReplicas int32
+	// ReadyReplicas is the number of ready replicas across all revisions.
+	ReadyReplicas int32
+	// AvailableReplicas is the number of available replicas across all revisions.
+	AvailableReplicas int32
+	// RevisionReplicas maps each revision that still owns pods to the number of replicas it owns.
+	RevisionReplicas map[int64]int32
+	// Complete is true when the latest generation has been observed and all replicas are updated and available. For
+	// partitioned statefulset rolling updates only the replicas above the partition need to be updated.
+	Complete bool
+}
+
+// Revision describes a single revision in the rollout history of a workload.
+type Revision struct {
+	// Revision is the revision number assigned by the workload controller.
+	Revision int64
+	// Name is the name of the object backing this revision: the ReplicaSet for deployments and the
+	// ControllerRevision for daemonsets and statefulsets.
+	Name string
+	// ChangeCause is the value of the kubernetes.io/change-cause annotation, if any.
+	ChangeCause string
+	// Template is the pod template used by this revision.
+	Template corev1.PodTemplateSpec
+}
+
+// RestartPatch returns the strategic merge patch that sets the restartedAt annotation on the pod template of a
+// workload, the same way kubectl rollout restart does, along with the timestamp it sets.
+func RestartPatch() ([]byte, string, error) {
+	restartedAt := time.Now().Format(time.RFC3339)
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{RestartedAtAnnotation: restartedAt},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return patch, restartedAt, nil
+}
+
+// ListControllerRevisions returns the ControllerRevisions matching the selector that are controlled by owner,
+// ordered from oldest to newest. The kind of the owner is only used in errors and logs.
+func ListControllerRevisions(
+	apiClient *clients.Settings,
+	kind string,
+	owner metav1.Object,
+	selector *metav1.LabelSelector) ([]*appsv1.ControllerRevision, error) {
+	parsedSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse selector of %s %s: %w", kind, owner.GetName(), err)
+	}
+
+	revisionList, err := apiClient.ControllerRevisions(owner.GetNamespace()).List(
+		logging.DiscardContext(), metav1.ListOptions{LabelSelector: parsedSelector.String()})
+	if err != nil {
+		klog.V(100).Infof("Failed to list controllerrevisions of %s %s in namespace %s: %v",
+			kind, owner.GetName(), owner.GetNamespace(), err)
+
+		return nil, err
+	}
+
+	var revisions []*appsv1.ControllerRevision
+
+	for index := range revisionList.Items {
+		if metav1.IsControlledBy(&revisionList.Items[index], owner) {
+			revisions = append(revisions, &revisionList.Items[index])
+		}
+	}
+
+	slices.SortFunc(revisions, func(a, b *appsv1.ControllerRevision) int {
+		return cmp.Compare(a.Revision, b.Revision)
+	})
+
+	return revisions, nil
+}
+
+// ControllerRevisionHistory converts ControllerRevisions sorted from oldest to newest into the rollout history,
+// decoding the pod template stored in each of them.
+func ControllerRevisionHistory(revisions []*appsv1.ControllerRevision) ([]Revision, error) {
+	var history []Revision
+
+	for _, revision := range revisions {
+		template, err := getControllerRevisionTemplate(revision.Name, revision.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, Revision{
+			Revision:    revision.Revision,
+			Name:        revision.Name,
+			ChangeCause: revision.Annotations[ChangeCauseAnnotation],
+			Template:    template,
+		})
+	}
+
+	return history, nil
+}
+
+// FindUndoRevision returns the revision to roll back to from a history sorted from oldest to newest. A toRevision of
+// 0 selects the revision preceding the newest one.
+func FindUndoRevision(history []Revision, toRevision int64) (*Revision, error) {
+	return findUndo(history, toRevision, func(revision *Revision) int64 { return revision.Revision })
+}
+
+// FindUndoControllerRevision returns the ControllerRevision to roll back to from a list sorted from oldest to newest.
+// A toRevision of 0 selects the revision preceding the newest one.
+func FindUndoControllerRevision(
+	revisions []*appsv1.ControllerRevision, toRevision int64) (*appsv1.ControllerRevision, error) {
+	revision, err := findUndo(revisions, toRevision, func(revision **appsv1.ControllerRevision) int64 {
+		return (*revision).Revision
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return *revision, nil
+}
+
+// findUndo returns a pointer to the element to roll back to from a list sorted from oldest to newest, using
+// revisionOf to get the revision number of an element.
+func findUndo[T any](items []T, toRevision int64, revisionOf func(*T) int64) (*T, error) {
+	if toRevision == 0 {
+		if len(items) < 2 {
+			return nil, fmt.Errorf("no previous revision found")
+		}
+
+		return &items[len(items)-2], nil
+	}
+
+	for index := range items {
+		if revisionOf(&items[index]) == toRevision {
+			return &items[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("revision %d not found", toRevision)
+}
+
+// getControllerRevisionTemplate decodes the pod template stored in the data of a daemonset or statefulset
+// ControllerRevision.
+func getControllerRevisionTemplate(name string, revisionData runtime.RawExtension) (corev1.PodTemplateSpec, error) {
+	var data struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+
+	err := json.Unmarshal(revisionData.Raw, &data)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, fmt.Errorf("failed to decode controllerrevision %s: %w", name, err)
+	}
+
+	return data.Spec.Template, nil
+}
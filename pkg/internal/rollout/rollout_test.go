@@ -0,0 +1,109 @@
+package rollout
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestFindUndoControllerRevision(t *testing.T) {
+	revisions := []*appsv1.ControllerRevision{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-1"}, Revision: 1},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-2"}, Revision: 2},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-3"}, Revision: 3},
+	}
+
+	testCases := []struct {
+		revisions         []*appsv1.ControllerRevision
+		toRevision        int64
+		expectedName      string
+		expectedErrorText string
+	}{
+		{
+			revisions:    revisions,
+			toRevision:   0,
+			expectedName: "test-2",
+		},
+		{
+			revisions:    revisions,
+			toRevision:   1,
+			expectedName: "test-1",
+		},
+		{
+			revisions:         revisions,
+			toRevision:        4,
+			expectedErrorText: "revision 4 not found",
+		},
+		{
+			revisions:         revisions[:1],
+			toRevision:        0,
+			expectedErrorText: "no previous revision found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		revision, err := FindUndoControllerRevision(testCase.revisions, testCase.toRevision)
+		if testCase.expectedErrorText != "" {
+			assert.EqualError(t, err, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedName, revision.Name)
+	}
+
+	history := []Revision{{Revision: 1, Name: "test-1"}, {Revision: 2, Name: "test-2"}}
+
+	revision, err := FindUndoRevision(history, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, &history[0], revision)
+}
+
+func TestControllerRevisionHistory(t *testing.T) {
+	history, err := ControllerRevisionHistory([]*appsv1.ControllerRevision{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-1",
+			Annotations: map[string]string{ChangeCauseAnnotation: "initial"},
+		},
+		Revision: 1,
+		Data: runtime.RawExtension{
+			Raw: []byte(`{"spec":{"template":{"metadata":{"labels":{"app":"test"}},"$patch":"replace"}}}`),
+		},
+	}})
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, "initial", history[0].ChangeCause)
+	assert.Equal(t, map[string]string{"app": "test"}, history[0].Template.Labels)
+
+	_, err = ControllerRevisionHistory([]*appsv1.ControllerRevision{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-2"},
+		Data:       runtime.RawExtension{Raw: []byte("{")},
+	}})
+	assert.ErrorContains(t, err, "failed to decode controllerrevision test-2: ")
+}
+
+func TestRestartPatch(t *testing.T) {
+	patch, restartedAt, err := RestartPatch()
+	assert.Nil(t, err)
+
+	_, err = time.Parse(time.RFC3339, restartedAt)
+	assert.Nil(t, err)
+
+	var patchedObject struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec
+		}
+	}
+
+	err = json.Unmarshal(patch, &patchedObject)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{RestartedAtAnnotation: restartedAt}, patchedObject.Spec.Template.Annotations)
+}
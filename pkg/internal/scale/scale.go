@@ -0,0 +1,89 @@
+// Package scale provides the scale subresource update and replica wait shared by the deployment, replicaset and
+// statefulset builders. The builders pass their typed client functions so the logic is identical regardless of the
+// workload kind.
+package scale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// GetScaleFunc is the GetScale function of a typed workload client.
+type GetScaleFunc func(ctx context.Context, name string, options metav1.GetOptions) (*autoscalingv1.Scale, error)
+
+// UpdateScaleFunc is the UpdateScale function of a typed workload client.
+type UpdateScaleFunc func(
+	ctx context.Context, name string, scale *autoscalingv1.Scale, options metav1.UpdateOptions) (*autoscalingv1.Scale, error)
+
+// ReplicaStatus holds the fields of a workload that WaitForReplicas checks.
+type ReplicaStatus struct {
+	// Generation is the generation of the workload spec.
+	Generation int64
+	// ObservedGeneration is the most recent generation observed by the workload controller.
+	ObservedGeneration int64
+	// Replicas is the number of replicas in the workload status.
+	Replicas int32
+	// ReadyReplicas is the number of ready replicas in the workload status.
+	ReadyReplicas int32
+}
+
+// ValidateReplicas returns an error if replicas is negative. The kind is only used in the error.
+func ValidateReplicas(kind string, replicas int32) error {
+	if replicas < 0 {
+		klog.V(100).Info("The number of replicas cannot be negative")
+
+		return fmt.Errorf("%s 'replicas' cannot be negative", kind)
+	}
+
+	return nil
+}
+
+// Update sets the replicas of the workload called name in nsname through its scale subresource, using getScale and
+// updateScale from the typed client of the workload. The kind is only used in logs.
+func Update(
+	kind, name, nsname string, replicas int32, getScale GetScaleFunc, updateScale UpdateScaleFunc) error {
+	scale, err := getScale(logging.DiscardContext(), name, metav1.GetOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to get scale of %s %s in namespace %s: %v", kind, name, nsname, err)
+
+		return err
+	}
+
+	scale.Spec.Replicas = replicas
+
+	_, err = updateScale(logging.DiscardContext(), name, scale, metav1.UpdateOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to update scale of %s %s in namespace %s: %v", kind, name, nsname, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// WaitForReplicas waits for the duration of the defined timeout or until the status returned by getStatus shows the
+// latest generation was observed and there are exactly the provided number of replicas, all of which are ready.
+// Errors from getStatus are logged and retried. The kind is only used in logs.
+func WaitForReplicas(
+	kind, name, nsname string, replicas int32, timeout time.Duration, getStatus func() (ReplicaStatus, error)) error {
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			status, err := getStatus()
+			if err != nil {
+				klog.V(100).Infof("Failed to get %s %s in namespace %s: %v", kind, name, nsname, err)
+
+				return false, nil
+			}
+
+			return status.ObservedGeneration >= status.Generation &&
+				status.Replicas == replicas &&
+				status.ReadyReplicas == replicas, nil
+		})
+}
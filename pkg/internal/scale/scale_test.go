@@ -0,0 +1,67 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdate(t *testing.T) {
+	var updatedScale *autoscalingv1.Scale
+
+	getScale := func(ctx context.Context, name string, options metav1.GetOptions) (*autoscalingv1.Scale, error) {
+		return &autoscalingv1.Scale{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: autoscalingv1.ScaleSpec{Replicas: 1}}, nil
+	}
+
+	updateScale := func(
+		ctx context.Context, name string, scale *autoscalingv1.Scale, options metav1.UpdateOptions) (*autoscalingv1.Scale, error) {
+		updatedScale = scale
+
+		return scale, nil
+	}
+
+	err := Update("deployment", "test", "test-ns", 3, getScale, updateScale)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), updatedScale.Spec.Replicas)
+
+	err = Update("deployment", "test", "test-ns", 3,
+		func(ctx context.Context, name string, options metav1.GetOptions) (*autoscalingv1.Scale, error) {
+			return nil, errors.New("not found")
+		}, updateScale)
+	assert.EqualError(t, err, "not found")
+
+	assert.EqualError(t, ValidateReplicas("deployment", -1), "deployment 'replicas' cannot be negative")
+	assert.Nil(t, ValidateReplicas("deployment", 0))
+}
+
+func TestWaitForReplicas(t *testing.T) {
+	testCases := []struct {
+		status        ReplicaStatus
+		expectedError error
+	}{
+		{
+			status:        ReplicaStatus{Generation: 2, ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3},
+			expectedError: nil,
+		},
+		{
+			status:        ReplicaStatus{Generation: 2, ObservedGeneration: 1, Replicas: 3, ReadyReplicas: 3},
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			status:        ReplicaStatus{Generation: 2, ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 2},
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := WaitForReplicas("deployment", "test", "test-ns", 3, time.Second, func() (ReplicaStatus, error) {
+			return testCase.status, nil
+		})
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
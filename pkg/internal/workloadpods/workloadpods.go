@@ -0,0 +1,96 @@
+// Package workloadpods resolves the pods managed by a workload, such as a deployment or daemonset, using the
+// workload's label selector and the controller references of the pods.
+package workloadpods
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// List returns the pods in nsname that match the selector and are controlled by an object with one of the ownerUIDs.
+// At most one ListOptions may be provided to narrow the result further. Its label selector is combined with the
+// selector and its remaining fields are passed through unchanged.
+func List(
+	apiClient *clients.Settings,
+	nsname string,
+	selector *metav1.LabelSelector,
+	ownerUIDs []types.UID,
+	options ...metav1.ListOptions) ([]*pod.Builder, error) {
+	if len(options) > 1 {
+		klog.V(100).Info("'options' parameter must be empty or single-valued")
+
+		return nil, fmt.Errorf("error: more than one ListOptions was passed")
+	}
+
+	parsedSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workload selector: %w", err)
+	}
+
+	listOptions := metav1.ListOptions{}
+	if len(options) == 1 {
+		listOptions = options[0]
+	}
+
+	labelSelectors := []string{parsedSelector.String()}
+	if listOptions.LabelSelector != "" {
+		labelSelectors = append(labelSelectors, listOptions.LabelSelector)
+	}
+
+	listOptions.LabelSelector = strings.Join(labelSelectors, ",")
+
+	pods, err := pod.List(apiClient, nsname, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[types.UID]bool, len(ownerUIDs))
+	for _, ownerUID := range ownerUIDs {
+		owners[ownerUID] = true
+	}
+
+	var ownedPods []*pod.Builder
+
+	for _, podBuilder := range pods {
+		controllerRef := metav1.GetControllerOf(podBuilder.Object)
+		if controllerRef != nil && owners[controllerRef.UID] {
+			ownedPods = append(ownedPods, podBuilder)
+		}
+	}
+
+	return ownedPods, nil
+}
+
+// FirstReady returns the first of the pods that is running and has the Ready condition set to true. The kind, name
+// and nsname of the workload are only used to build the error when none of the pods is ready.
+func FirstReady(pods []*pod.Builder, kind, name, nsname string) (*pod.Builder, error) {
+	for _, podBuilder := range pods {
+		if isReady(podBuilder.Object) {
+			return podBuilder, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no ready pod found for %s %s in namespace %s", kind, name, nsname)
+}
+
+// isReady returns true if the pod is running and has the Ready condition set to true.
+func isReady(podObject *corev1.Pod) bool {
+	if podObject == nil || podObject.DeletionTimestamp != nil || podObject.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for _, condition := range podObject.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package workloadpods
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testNamespace = "test-namespace"
+	ownerUID      = types.UID("owner-uid")
+	otherUID      = types.UID("other-uid")
+)
+
+var testSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		options       []metav1.ListOptions
+		expectedPods  []string
+		expectedError error
+	}{
+		{
+			name:         "owned pods only",
+			expectedPods: []string{"owned-ready", "owned-pending"},
+		},
+		{
+			name:         "additional label selector",
+			options:      []metav1.ListOptions{{LabelSelector: "tier=backend"}},
+			expectedPods: []string{"owned-ready"},
+		},
+		{
+			name:          "too many options",
+			options:       []metav1.ListOptions{{}, {}},
+			expectedError: fmt.Errorf("error: more than one ListOptions was passed"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildTestPods()})
+
+			pods, err := List(testSettings, testNamespace, testSelector, []types.UID{ownerUID}, testCase.options...)
+			assert.Equal(t, testCase.expectedError, err)
+
+			if testCase.expectedError == nil {
+				assert.ElementsMatch(t, testCase.expectedPods, getPodNames(pods))
+			}
+		})
+	}
+}
+
+func TestFirstReady(t *testing.T) {
+	t.Parallel()
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildTestPods()})
+
+	pods, err := List(testSettings, testNamespace, testSelector, []types.UID{ownerUID})
+	assert.Nil(t, err)
+
+	readyPod, err := FirstReady(pods, "deployment", "test", testNamespace)
+	assert.Nil(t, err)
+	assert.Equal(t, "owned-ready", readyPod.Definition.Name)
+
+	pods, err = List(testSettings, testNamespace, testSelector, []types.UID{otherUID})
+	assert.Nil(t, err)
+
+	readyPod, err = FirstReady(pods, "deployment", "test", testNamespace)
+	assert.Nil(t, readyPod)
+	assert.Equal(t, fmt.Errorf("no ready pod found for deployment test in namespace test-namespace"), err)
+}
+
+func getPodNames(pods []*pod.Builder) []string {
+	var names []string
+
+	for _, podBuilder := range pods {
+		names = append(names, podBuilder.Definition.Name)
+	}
+
+	return names
+}
+
+func buildTestPods() []runtime.Object {
+	return []runtime.Object{
+		buildTestPod("owned-ready", ownerUID, map[string]string{"app": "test", "tier": "backend"}, true),
+		buildTestPod("owned-pending", ownerUID, map[string]string{"app": "test"}, false),
+		buildTestPod("other-owner", otherUID, map[string]string{"app": "test"}, false),
+		buildTestPod("other-labels", ownerUID, map[string]string{"app": "other"}, true),
+	}
+}
+
+func buildTestPod(name string, controllerUID types.UID, labels map[string]string, ready bool) *corev1.Pod {
+	testPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "owner",
+				UID:        controllerUID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	if ready {
+		testPod.Status.Phase = corev1.PodRunning
+		testPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+
+	return testPod
+}
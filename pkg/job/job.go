@@ -0,0 +1,509 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/msg"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// Builder provides struct for job object containing connection to the cluster and the job definitions.
+type Builder struct {
+	// Job definition. Used to create the job object.
+	Definition *batchv1.Job
+	// Created job object
+	Object *batchv1.Job
+	// Used in functions that define or mutate job definition. errorMsg is processed before the job object is created.
+	errorMsg  string
+	apiClient *clients.Settings
+}
+
+// AdditionalOptions additional options for job object.
+type AdditionalOptions func(builder *Builder) (*Builder, error)
+
+// NewBuilder creates a new instance of Builder. The job runs a single container with the provided image and command,
+// and its pods are never restarted in place; failed pods are replaced up to the backoff limit instead.
+func NewBuilder(apiClient *clients.Settings, name, nsname, image string, command []string) *Builder {
+	klog.V(100).Infof(
+		"Initializing new job structure with the following params: name: %s, namespace: %s, image: %s, command: %v",
+		name, nsname, image, command)
+
+	if apiClient == nil {
+		klog.V(100).Info("The apiClient is empty, job 'apiClient' cannot be empty")
+
+		return nil
+	}
+
+	builder := &Builder{
+		apiClient: apiClient,
+		Definition: &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						RestartPolicy: corev1.RestartPolicyNever,
+					},
+				},
+			},
+		},
+	}
+
+	if name == "" {
+		klog.V(100).Info("The name of the job is empty")
+
+		builder.errorMsg = "job 'name' cannot be empty"
+
+		return builder
+	}
+
+	if nsname == "" {
+		klog.V(100).Info("The namespace of the job is empty")
+
+		builder.errorMsg = "job 'namespace' cannot be empty"
+
+		return builder
+	}
+
+	if image == "" {
+		klog.V(100).Info("The image of the job is empty")
+
+		builder.errorMsg = "job 'image' cannot be empty"
+
+		return builder
+	}
+
+	defaultContainer, err := pod.NewContainerBuilder("job", image, command).GetContainerCfg()
+	if err != nil {
+		klog.V(100).Info("Failed to define the default container settings")
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.Template.Spec.Containers = []corev1.Container{*defaultContainer}
+
+	return builder
+}
+
+// Pull loads an existing job into the Builder struct.
+func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
+	klog.V(100).Infof("Pulling existing job name: %s under namespace: %s", name, nsname)
+
+	if apiClient == nil {
+		klog.V(100).Info("The apiClient is empty")
+
+		return nil, fmt.Errorf("job 'apiClient' cannot be empty")
+	}
+
+	builder := &Builder{
+		apiClient: apiClient,
+		Definition: &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		klog.V(100).Info("The name of the job is empty")
+
+		return nil, fmt.Errorf("job 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		klog.V(100).Info("The namespace of the job is empty")
+
+		return nil, fmt.Errorf("job 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("job object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Create makes a job according to the job definition and stores the created object in the job builder.
+func (builder *Builder) Create() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Creating job %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	if !builder.Exists() {
+		builder.Object, err = builder.apiClient.Jobs(builder.Definition.Namespace).Create(
+			logging.DiscardContext(), builder.Definition, metav1.CreateOptions{})
+	}
+
+	return builder, err
+}
+
+// Exists checks whether the given job exists.
+func (builder *Builder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	klog.V(100).Infof("Checking if job %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+
+	builder.Object, err = builder.apiClient.Jobs(builder.Definition.Namespace).Get(
+		logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Delete removes the job from the cluster. The job's pods are deleted in the background, unlike the API default of
+// orphaning them.
+func (builder *Builder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Deleting job %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		klog.V(100).Infof("Job %s in namespace %s does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		builder.Object = nil
+
+		return nil
+	}
+
+	err := builder.apiClient.Jobs(builder.Definition.Namespace).Delete(
+		logging.DiscardContext(), builder.Definition.Name, metav1.DeleteOptions{
+			PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+		})
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// DeleteAndWait deletes the job and waits until it is removed from the cluster.
+func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Deleting job %s in namespace %s and waiting for the defined period until it is removed",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := builder.Delete(); err != nil {
+		return err
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			_, err := builder.apiClient.Jobs(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				return true, nil
+			}
+
+			return false, nil
+		})
+}
+
+// WaitUntilComplete waits for the duration of the defined timeout or until the job has the Complete condition. If the
+// job fails instead, an error is returned immediately.
+func (builder *Builder) WaitUntilComplete(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until job %s in namespace %s is complete",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return builder.waitUntilFinished(timeout, batchv1.JobComplete, batchv1.JobFailed)
+}
+
+// WaitUntilFailed waits for the duration of the defined timeout or until the job has the Failed condition. If the job
+// completes successfully instead, an error is returned immediately.
+func (builder *Builder) WaitUntilFailed(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until job %s in namespace %s has failed",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return builder.waitUntilFinished(timeout, batchv1.JobFailed, batchv1.JobComplete)
+}
+
+// GetPods returns the pods created by the job. The job must exist on the cluster.
+func (builder *Builder) GetPods() ([]*pod.Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting pods of job %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get pods of job %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return pod.List(builder.apiClient, builder.Definition.Namespace, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", batchv1.ControllerUidLabel, builder.Object.UID),
+	})
+}
+
+// GetLogs returns the full logs of the provided container in each of the job's pods, keyed by pod name. If
+// containerName is empty, the logs of the only container in each pod are returned.
+func (builder *Builder) GetLogs(containerName string) (map[string]string, error) {
+	jobPods, err := builder.GetPods()
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(map[string]string, len(jobPods))
+
+	for _, jobPod := range jobPods {
+		podLogs, err := jobPod.GetFullLog(containerName)
+		if err != nil {
+			klog.V(100).Infof("Failed to get logs of pod %s of job %s in namespace %s: %v",
+				jobPod.Definition.Name, builder.Definition.Name, builder.Definition.Namespace, err)
+
+			return nil, err
+		}
+
+		logs[jobPod.Definition.Name] = podLogs
+	}
+
+	return logs, nil
+}
+
+// WithOptions creates job with generic mutation options.
+func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Info("Setting job additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+			if err != nil {
+				klog.V(100).Info("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// WithBackoffLimit sets the number of retries before the job is marked as failed.
+func (builder *Builder) WithBackoffLimit(backoffLimit int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting backoffLimit %d on job %s in namespace %s",
+		backoffLimit, builder.Definition.Name, builder.Definition.Namespace)
+
+	if backoffLimit < 0 {
+		klog.V(100).Info("The backoffLimit of the job is negative")
+
+		builder.errorMsg = "job 'backoffLimit' cannot be negative"
+
+		return builder
+	}
+
+	builder.Definition.Spec.BackoffLimit = ptr.To(backoffLimit)
+
+	return builder
+}
+
+// WithTTLSecondsAfterFinished sets how long the job and its pods are kept after the job finishes before they are
+// deleted automatically. Zero makes the job eligible for deletion as soon as it finishes.
+func (builder *Builder) WithTTLSecondsAfterFinished(ttlSeconds int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting ttlSecondsAfterFinished %d on job %s in namespace %s",
+		ttlSeconds, builder.Definition.Name, builder.Definition.Namespace)
+
+	if ttlSeconds < 0 {
+		klog.V(100).Info("The ttlSecondsAfterFinished of the job is negative")
+
+		builder.errorMsg = "job 'ttlSecondsAfterFinished' cannot be negative"
+
+		return builder
+	}
+
+	builder.Definition.Spec.TTLSecondsAfterFinished = ptr.To(ttlSeconds)
+
+	return builder
+}
+
+// WithActiveDeadlineSeconds sets how long the job may be active before the system tries to terminate it.
+func (builder *Builder) WithActiveDeadlineSeconds(deadlineSeconds int64) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting activeDeadlineSeconds %d on job %s in namespace %s",
+		deadlineSeconds, builder.Definition.Name, builder.Definition.Namespace)
+
+	if deadlineSeconds <= 0 {
+		klog.V(100).Info("The activeDeadlineSeconds of the job is not positive")
+
+		builder.errorMsg = "job 'activeDeadlineSeconds' must be positive"
+
+		return builder
+	}
+
+	builder.Definition.Spec.ActiveDeadlineSeconds = ptr.To(deadlineSeconds)
+
+	return builder
+}
+
+// WithCompletions sets the number of pods that must finish successfully for the job to complete.
+func (builder *Builder) WithCompletions(completions int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting completions %d on job %s in namespace %s",
+		completions, builder.Definition.Name, builder.Definition.Namespace)
+
+	if completions < 1 {
+		klog.V(100).Info("The completions of the job is less than 1")
+
+		builder.errorMsg = "job 'completions' must be at least 1"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Completions = ptr.To(completions)
+
+	return builder
+}
+
+// WithParallelism sets the maximum number of pods the job runs at the same time.
+func (builder *Builder) WithParallelism(parallelism int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting parallelism %d on job %s in namespace %s",
+		parallelism, builder.Definition.Name, builder.Definition.Namespace)
+
+	if parallelism < 0 {
+		klog.V(100).Info("The parallelism of the job is negative")
+
+		builder.errorMsg = "job 'parallelism' cannot be negative"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Parallelism = ptr.To(parallelism)
+
+	return builder
+}
+
+// GetGVR returns job's GroupVersionResource which could be used for Clean function.
+func GetGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+}
+
+// waitUntilFinished polls the job until it has the desired condition. If the job has the opposing condition instead,
+// polling stops with an error since the job will not run again.
+func (builder *Builder) waitUntilFinished(
+	timeout time.Duration, desired, opposing batchv1.JobConditionType) error {
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.Jobs(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("Failed to get job %s in namespace %s: %v",
+					builder.Definition.Name, builder.Definition.Namespace, err)
+
+				return false, nil
+			}
+
+			for _, condition := range builder.Object.Status.Conditions {
+				if condition.Status != corev1.ConditionTrue {
+					continue
+				}
+
+				switch condition.Type {
+				case desired:
+					return true, nil
+				case opposing:
+					return false, fmt.Errorf("job %s in namespace %s has condition %s instead of %s: %s",
+						builder.Definition.Name, builder.Definition.Namespace, opposing, desired, condition.Message)
+				}
+			}
+
+			return false, nil
+		})
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *Builder) validate() (bool, error) {
+	resourceCRD := "Job"
+
+	if builder == nil {
+		klog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		klog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		return false, fmt.Errorf("%s", msg.UndefinedCrdObjectErrString(resourceCRD))
+	}
+
+	if builder.apiClient == nil {
+		klog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		return false, fmt.Errorf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		klog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf("%s", builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,470 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	defaultJobName      = "test-job"
+	defaultJobNamespace = "test-namespace"
+	defaultJobUID       = types.UID("test-job-uid")
+)
+
+var defaultJobCommand = []string{"/bin/sh", "-c", "true"}
+
+func TestJobNewBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     string
+		image         string
+		command       []string
+		expectedError string
+	}{
+		{
+			name:          defaultJobName,
+			namespace:     defaultJobNamespace,
+			image:         "test-image",
+			command:       defaultJobCommand,
+			expectedError: "",
+		},
+		{
+			name:          "",
+			namespace:     defaultJobNamespace,
+			image:         "test-image",
+			command:       defaultJobCommand,
+			expectedError: "job 'name' cannot be empty",
+		},
+		{
+			name:          defaultJobName,
+			namespace:     "",
+			image:         "test-image",
+			command:       defaultJobCommand,
+			expectedError: "job 'namespace' cannot be empty",
+		},
+		{
+			name:          defaultJobName,
+			namespace:     defaultJobNamespace,
+			image:         "",
+			command:       defaultJobCommand,
+			expectedError: "job 'image' cannot be empty",
+		},
+		{
+			name:          defaultJobName,
+			namespace:     defaultJobNamespace,
+			image:         "test-image",
+			command:       nil,
+			expectedError: "container's cmd is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewBuilder(
+			clients.GetTestClients(clients.TestClientParams{}),
+			testCase.name, testCase.namespace, testCase.image, testCase.command)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, corev1.RestartPolicyNever, testBuilder.Definition.Spec.Template.Spec.RestartPolicy)
+			assert.Len(t, testBuilder.Definition.Spec.Template.Spec.Containers, 1)
+			assert.Equal(t, testCase.image, testBuilder.Definition.Spec.Template.Spec.Containers[0].Image)
+			assert.Equal(t, testCase.command, testBuilder.Definition.Spec.Template.Spec.Containers[0].Command)
+		}
+	}
+
+	assert.Nil(t, NewBuilder(nil, defaultJobName, defaultJobNamespace, "test-image", defaultJobCommand))
+}
+
+func TestJobPull(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     string
+		exists        bool
+		client        bool
+		expectedError error
+	}{
+		{
+			name:          defaultJobName,
+			namespace:     defaultJobNamespace,
+			exists:        true,
+			client:        true,
+			expectedError: nil,
+		},
+		{
+			name:          defaultJobName,
+			namespace:     defaultJobNamespace,
+			exists:        false,
+			client:        true,
+			expectedError: fmt.Errorf("job object test-job does not exist in namespace test-namespace"),
+		},
+		{
+			name:          "",
+			namespace:     defaultJobNamespace,
+			exists:        true,
+			client:        true,
+			expectedError: fmt.Errorf("job 'name' cannot be empty"),
+		},
+		{
+			name:          defaultJobName,
+			namespace:     "",
+			exists:        true,
+			client:        true,
+			expectedError: fmt.Errorf("job 'namespace' cannot be empty"),
+		},
+		{
+			name:          defaultJobName,
+			namespace:     defaultJobNamespace,
+			exists:        true,
+			client:        false,
+			expectedError: fmt.Errorf("job 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyJob())
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := Pull(testSettings, testCase.name, testCase.namespace)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.namespace, testBuilder.Definition.Namespace)
+		}
+	}
+}
+
+func TestJobCreate(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *Builder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildValidJobTestBuilder(buildJobTestClientWithDummyObjects()),
+			expectedError: nil,
+		},
+		{
+			testBuilder: NewBuilder(
+				clients.GetTestClients(clients.TestClientParams{}), "", defaultJobNamespace, "test-image", defaultJobCommand),
+			expectedError: fmt.Errorf("job 'name' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.testBuilder.Create()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testBuilder.Definition.Name, testBuilder.Object.Name)
+		}
+	}
+}
+
+func TestJobDelete(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *Builder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidJobTestBuilder(buildJobTestClientWithDummyObjects()),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildValidJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.testBuilder.Delete()
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Nil(t, testCase.testBuilder.Object)
+	}
+}
+
+func TestJobDeleteAndWait(t *testing.T) {
+	testBuilder := buildValidJobTestBuilder(buildJobTestClientWithDummyObjects())
+
+	err := testBuilder.DeleteAndWait(time.Second)
+	assert.Nil(t, err)
+	assert.False(t, testBuilder.Exists())
+}
+
+func TestJobWaitUntilComplete(t *testing.T) {
+	testCases := []struct {
+		conditionType batchv1.JobConditionType
+		expectedError error
+	}{
+		{
+			conditionType: batchv1.JobComplete,
+			expectedError: nil,
+		},
+		{
+			conditionType: batchv1.JobFailed,
+			expectedError: fmt.Errorf(
+				"job test-job in namespace test-namespace has condition Failed instead of Complete: test message"),
+		},
+		{
+			conditionType: "",
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidJobTestBuilder(buildJobTestClientWithConditions(testCase.conditionType))
+
+		err := testBuilder.WaitUntilComplete(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestJobWaitUntilFailed(t *testing.T) {
+	testCases := []struct {
+		conditionType batchv1.JobConditionType
+		expectedError error
+	}{
+		{
+			conditionType: batchv1.JobFailed,
+			expectedError: nil,
+		},
+		{
+			conditionType: batchv1.JobComplete,
+			expectedError: fmt.Errorf(
+				"job test-job in namespace test-namespace has condition Complete instead of Failed: test message"),
+		},
+		{
+			conditionType: "",
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidJobTestBuilder(buildJobTestClientWithConditions(testCase.conditionType))
+
+		err := testBuilder.WaitUntilFailed(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestJobGetPods(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedPods  []string
+		expectedError error
+	}{
+		{
+			exists:        true,
+			expectedPods:  []string{"test-job-pod"},
+			expectedError: nil,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot get pods of job test-job in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyJob())
+		}
+
+		runtimeObjects = append(runtimeObjects, buildDummyJobPods()...)
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		jobPods, err := buildValidJobTestBuilder(testSettings).GetPods()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			var podNames []string
+
+			for _, jobPod := range jobPods {
+				podNames = append(podNames, jobPod.Definition.Name)
+			}
+
+			assert.Equal(t, testCase.expectedPods, podNames)
+		}
+	}
+}
+
+func TestJobGetLogs(t *testing.T) {
+	runtimeObjects := []runtime.Object{buildDummyJob()}
+	runtimeObjects = append(runtimeObjects, buildDummyJobPods()...)
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+	logs, err := buildValidJobTestBuilder(testSettings).GetLogs("job")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"test-job-pod": "fake logs"}, logs)
+}
+
+func TestJobWithSettings(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *Builder) *Builder
+		verify        func(t *testing.T, spec batchv1.JobSpec)
+		expectedError string
+	}{
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithBackoffLimit(2) },
+			verify: func(t *testing.T, spec batchv1.JobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int32](2), spec.BackoffLimit)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithBackoffLimit(-1) },
+			expectedError: "job 'backoffLimit' cannot be negative",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithTTLSecondsAfterFinished(0) },
+			verify: func(t *testing.T, spec batchv1.JobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int32](0), spec.TTLSecondsAfterFinished)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithTTLSecondsAfterFinished(-1) },
+			expectedError: "job 'ttlSecondsAfterFinished' cannot be negative",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithActiveDeadlineSeconds(60) },
+			verify: func(t *testing.T, spec batchv1.JobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int64](60), spec.ActiveDeadlineSeconds)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithActiveDeadlineSeconds(0) },
+			expectedError: "job 'activeDeadlineSeconds' must be positive",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithCompletions(3).WithParallelism(2) },
+			verify: func(t *testing.T, spec batchv1.JobSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int32](3), spec.Completions)
+				assert.Equal(t, ptr.To[int32](2), spec.Parallelism)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithCompletions(0) },
+			expectedError: "job 'completions' must be at least 1",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithParallelism(-1) },
+			expectedError: "job 'parallelism' cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(buildValidJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			testCase.verify(t, testBuilder.Definition.Spec)
+		}
+	}
+}
+
+func TestJobWithOptions(t *testing.T) {
+	testBuilder := buildValidJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithOptions(func(builder *Builder) (*Builder, error) {
+			builder.Definition.Spec.Suspend = ptr.To(true)
+
+			return builder, nil
+		})
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.Equal(t, ptr.To(true), testBuilder.Definition.Spec.Suspend)
+
+	testBuilder = buildValidJobTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithOptions(func(builder *Builder) (*Builder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestJobGetGVR(t *testing.T) {
+	testGVR := GetGVR()
+	assert.Equal(t, "jobs", testGVR.Resource)
+	assert.Equal(t, "v1", testGVR.Version)
+	assert.Equal(t, "batch", testGVR.Group)
+}
+
+func buildValidJobTestBuilder(apiClient *clients.Settings) *Builder {
+	return NewBuilder(apiClient, defaultJobName, defaultJobNamespace, "test-image", defaultJobCommand)
+}
+
+func buildJobTestClientWithDummyObjects() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{buildDummyJob()}})
+}
+
+// buildJobTestClientWithConditions returns a client with the dummy job having a true condition of the provided type.
+// If conditionType is empty, the job has no conditions.
+func buildJobTestClientWithConditions(conditionType batchv1.JobConditionType) *clients.Settings {
+	job := buildDummyJob()
+
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:    conditionType,
+			Status:  corev1.ConditionTrue,
+			Message: "test message",
+		}}
+	}
+
+	return clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{job}})
+}
+
+func buildDummyJob() *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultJobName,
+			Namespace: defaultJobNamespace,
+			UID:       defaultJobUID,
+		},
+	}
+}
+
+// buildDummyJobPods returns a pod controlled by the dummy job and a pod in the same namespace that is not.
+func buildDummyJobPods() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-job-pod",
+				Namespace: defaultJobNamespace,
+				Labels:    map[string]string{batchv1.ControllerUidLabel: string(defaultJobUID)},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-pod",
+				Namespace: defaultJobNamespace,
+				Labels:    map[string]string{batchv1.ControllerUidLabel: "other-uid"},
+			},
+		},
+	}
+}
@@ -0,0 +1,224 @@
+package mco
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	ignv34 "github.com/coreos/ignition/v2/config/v3_4"
+	igntypes "github.com/coreos/ignition/v2/config/v3_4/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// sshKeyUser is the only user whose SSH keys the machine-config-operator manages.
+const sshKeyUser = "core"
+
+// WithFile adds a file with the given contents and mode, such as 0644, to the Ignition config of the MachineConfig.
+// A file already defined at the same path is replaced.
+func (builder *MCBuilder) WithFile(path string, contents []byte, mode int) *MCBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding file %s with mode %#o to MachineConfig %s", path, mode, builder.Definition.Name)
+
+	if path == "" {
+		klog.V(100).Info("The file path cannot be empty")
+
+		builder.errorMsg = "ignition file 'path' cannot be empty"
+
+		return builder
+	}
+
+	return builder.updateIgnitionConfig(func(config *igntypes.Config) {
+		file := igntypes.File{
+			Node: igntypes.Node{Path: path, Overwrite: ptr.To(true)},
+			FileEmbedded1: igntypes.FileEmbedded1{
+				Contents: igntypes.Resource{
+					Source: ptr.To("data:;base64," + base64.StdEncoding.EncodeToString(contents)),
+				},
+				Mode: ptr.To(mode),
+			},
+		}
+
+		config.Storage.Files = slices.DeleteFunc(config.Storage.Files, func(existing igntypes.File) bool {
+			return existing.Path == path
+		})
+		config.Storage.Files = append(config.Storage.Files, file)
+	})
+}
+
+// WithSystemdUnit adds a systemd unit with the given contents to the Ignition config of the MachineConfig. A unit
+// already defined with the same name has its contents and enablement replaced, keeping its drop-ins.
+func (builder *MCBuilder) WithSystemdUnit(name, contents string, enabled bool) *MCBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding systemd unit %s with enabled %t to MachineConfig %s",
+		name, enabled, builder.Definition.Name)
+
+	if name == "" {
+		klog.V(100).Info("The systemd unit name cannot be empty")
+
+		builder.errorMsg = "systemd unit 'name' cannot be empty"
+
+		return builder
+	}
+
+	return builder.updateIgnitionConfig(func(config *igntypes.Config) {
+		unit := getOrAddUnit(config, name)
+		unit.Contents = ptr.To(contents)
+		unit.Enabled = ptr.To(enabled)
+	})
+}
+
+// WithSystemdDropin adds a drop-in with the given contents to a systemd unit in the Ignition config of the
+// MachineConfig. The unit is added without contents if it is not already defined, which extends a unit shipped with
+// the OS. A drop-in already defined with the same name for the unit is replaced.
+func (builder *MCBuilder) WithSystemdDropin(unitName, dropinName, contents string) *MCBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding drop-in %s for systemd unit %s to MachineConfig %s",
+		dropinName, unitName, builder.Definition.Name)
+
+	if unitName == "" {
+		klog.V(100).Info("The systemd unit name cannot be empty")
+
+		builder.errorMsg = "systemd unit 'name' cannot be empty"
+
+		return builder
+	}
+
+	if dropinName == "" {
+		klog.V(100).Info("The systemd drop-in name cannot be empty")
+
+		builder.errorMsg = "systemd drop-in 'name' cannot be empty"
+
+		return builder
+	}
+
+	return builder.updateIgnitionConfig(func(config *igntypes.Config) {
+		unit := getOrAddUnit(config, unitName)
+		unit.Dropins = slices.DeleteFunc(unit.Dropins, func(existing igntypes.Dropin) bool {
+			return existing.Name == dropinName
+		})
+		unit.Dropins = append(unit.Dropins, igntypes.Dropin{Name: dropinName, Contents: ptr.To(contents)})
+	})
+}
+
+// WithSSHKey authorizes the public SSH key for the core user in the Ignition config of the MachineConfig. Keys that
+// are already authorized are not added again.
+func (builder *MCBuilder) WithSSHKey(key string) *MCBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding SSH key for user %s to MachineConfig %s", sshKeyUser, builder.Definition.Name)
+
+	if key == "" {
+		klog.V(100).Info("The SSH key cannot be empty")
+
+		builder.errorMsg = "ssh 'key' cannot be empty"
+
+		return builder
+	}
+
+	return builder.updateIgnitionConfig(func(config *igntypes.Config) {
+		userIndex := slices.IndexFunc(config.Passwd.Users, func(user igntypes.PasswdUser) bool {
+			return user.Name == sshKeyUser
+		})
+
+		if userIndex == -1 {
+			config.Passwd.Users = append(config.Passwd.Users, igntypes.PasswdUser{Name: sshKeyUser})
+			userIndex = len(config.Passwd.Users) - 1
+		}
+
+		user := &config.Passwd.Users[userIndex]
+		if !slices.Contains(user.SSHAuthorizedKeys, igntypes.SSHAuthorizedKey(key)) {
+			user.SSHAuthorizedKeys = append(user.SSHAuthorizedKeys, igntypes.SSHAuthorizedKey(key))
+		}
+	})
+}
+
+// GetIgnitionConfig returns the Ignition config of the MachineConfig definition, translated to Ignition 3.4. An empty
+// config is returned if the definition has no raw config.
+func (builder *MCBuilder) GetIgnitionConfig() (*igntypes.Config, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting Ignition config of MachineConfig %s", builder.Definition.Name)
+
+	return parseIgnitionConfig(builder.Definition.Spec.Config.Raw)
+}
+
+// updateIgnitionConfig parses the raw config of the definition, applies mutate to it and stores the result back as
+// raw config after validating it against the Ignition schema.
+func (builder *MCBuilder) updateIgnitionConfig(mutate func(config *igntypes.Config)) *MCBuilder {
+	config, err := parseIgnitionConfig(builder.Definition.Spec.Config.Raw)
+	if err != nil {
+		klog.V(100).Infof("Failed to parse Ignition config of MachineConfig %s: %v", builder.Definition.Name, err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	mutate(config)
+
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		klog.V(100).Infof("Failed to marshal Ignition config of MachineConfig %s: %v", builder.Definition.Name, err)
+
+		builder.errorMsg = fmt.Sprintf("failed to marshal ignition config: %v", err)
+
+		return builder
+	}
+
+	_, report, err := ignv34.Parse(rawConfig)
+	if err != nil {
+		klog.V(100).Infof("Ignition config of MachineConfig %s is invalid: %s", builder.Definition.Name, report.String())
+
+		builder.errorMsg = fmt.Sprintf("invalid ignition config: %v: %s", err, report.String())
+
+		return builder
+	}
+
+	builder.Definition.Spec.Config.Raw = rawConfig
+
+	return builder
+}
+
+// parseIgnitionConfig parses rawConfig of any Ignition 3.x version into an Ignition 3.4 config. An empty config with
+// the 3.4 version is returned if rawConfig is empty.
+func parseIgnitionConfig(rawConfig []byte) (*igntypes.Config, error) {
+	if len(rawConfig) == 0 {
+		return &igntypes.Config{Ignition: igntypes.Ignition{Version: igntypes.MaxVersion.String()}}, nil
+	}
+
+	config, report, err := ignv34.ParseCompatibleVersion(rawConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ignition config: %w: %s", err, report.String())
+	}
+
+	return &config, nil
+}
+
+// getOrAddUnit returns the unit with the given name from config, adding an empty one if it is not defined.
+func getOrAddUnit(config *igntypes.Config, name string) *igntypes.Unit {
+	unitIndex := slices.IndexFunc(config.Systemd.Units, func(unit igntypes.Unit) bool {
+		return unit.Name == name
+	})
+
+	if unitIndex == -1 {
+		config.Systemd.Units = append(config.Systemd.Units, igntypes.Unit{Name: name})
+		unitIndex = len(config.Systemd.Units) - 1
+	}
+
+	return &config.Systemd.Units[unitIndex]
+}
@@ -0,0 +1,221 @@
+package mco
+
+import (
+	"encoding/base64"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_4/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	defaultUnitName     = "test.service"
+	defaultUnitContents = "[Unit]\nDescription=test\n[Service]\nExecStart=/bin/true\n[Install]\nWantedBy=multi-user.target\n"
+	defaultSSHKey       = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHtest test@example.com"
+)
+
+func TestMachineConfigWithFile(t *testing.T) {
+	testCases := []struct {
+		path              string
+		mode              int
+		expectedErrorText string
+	}{
+		{
+			path:              "/etc/test.conf",
+			mode:              0644,
+			expectedErrorText: "",
+		},
+		{
+			path:              "",
+			mode:              0644,
+			expectedErrorText: "ignition file 'path' cannot be empty",
+		},
+		{
+			path:              "relative/test.conf",
+			mode:              0644,
+			expectedErrorText: "invalid ignition config",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidMachineConfigTestBuilder(buildTestClientWithDummyMachineConfig()).
+			WithFile(testCase.path, []byte("old"), testCase.mode).
+			WithFile(testCase.path, []byte("test contents"), testCase.mode)
+
+		if testCase.expectedErrorText != "" {
+			assert.Contains(t, testBuilder.errorMsg, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Empty(t, testBuilder.errorMsg)
+
+		config, err := testBuilder.GetIgnitionConfig()
+		assert.Nil(t, err)
+		assert.Equal(t, "3.4.0", config.Ignition.Version)
+		assert.Len(t, config.Storage.Files, 1)
+		assert.Equal(t, testCase.path, config.Storage.Files[0].Path)
+		assert.Equal(t, testCase.mode, *config.Storage.Files[0].Mode)
+		assert.Equal(t, "data:;base64,"+base64.StdEncoding.EncodeToString([]byte("test contents")),
+			*config.Storage.Files[0].Contents.Source)
+	}
+}
+
+func TestMachineConfigWithSystemdUnit(t *testing.T) {
+	testCases := []struct {
+		name              string
+		expectedErrorText string
+	}{
+		{
+			name:              defaultUnitName,
+			expectedErrorText: "",
+		},
+		{
+			name:              "",
+			expectedErrorText: "systemd unit 'name' cannot be empty",
+		},
+		{
+			name:              "test",
+			expectedErrorText: "invalid ignition config",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidMachineConfigTestBuilder(buildTestClientWithDummyMachineConfig()).
+			WithSystemdDropin(testCase.name, "10-test.conf", "[Service]\nEnvironment=TEST=1\n").
+			WithSystemdUnit(testCase.name, defaultUnitContents, true)
+
+		if testCase.expectedErrorText != "" {
+			assert.Contains(t, testBuilder.errorMsg, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Empty(t, testBuilder.errorMsg)
+
+		config, err := testBuilder.GetIgnitionConfig()
+		assert.Nil(t, err)
+		assert.Len(t, config.Systemd.Units, 1)
+		assert.Equal(t, defaultUnitContents, *config.Systemd.Units[0].Contents)
+		assert.True(t, *config.Systemd.Units[0].Enabled)
+		assert.Len(t, config.Systemd.Units[0].Dropins, 1)
+	}
+}
+
+func TestMachineConfigWithSystemdDropin(t *testing.T) {
+	testCases := []struct {
+		unitName          string
+		dropinName        string
+		expectedErrorText string
+	}{
+		{
+			unitName:          "kubelet.service",
+			dropinName:        "10-test.conf",
+			expectedErrorText: "",
+		},
+		{
+			unitName:          "kubelet.service",
+			dropinName:        "",
+			expectedErrorText: "systemd drop-in 'name' cannot be empty",
+		},
+		{
+			unitName:          "",
+			dropinName:        "10-test.conf",
+			expectedErrorText: "systemd unit 'name' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidMachineConfigTestBuilder(buildTestClientWithDummyMachineConfig()).
+			WithSystemdDropin(testCase.unitName, testCase.dropinName, "[Service]\nEnvironment=TEST=0\n").
+			WithSystemdDropin(testCase.unitName, testCase.dropinName, "[Service]\nEnvironment=TEST=1\n")
+
+		if testCase.expectedErrorText != "" {
+			assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+			continue
+		}
+
+		assert.Empty(t, testBuilder.errorMsg)
+
+		config, err := testBuilder.GetIgnitionConfig()
+		assert.Nil(t, err)
+		assert.Len(t, config.Systemd.Units, 1)
+		assert.Nil(t, config.Systemd.Units[0].Contents)
+		assert.Equal(t, []igntypes.Dropin{{Name: testCase.dropinName, Contents: ptr.To("[Service]\nEnvironment=TEST=1\n")}},
+			config.Systemd.Units[0].Dropins)
+	}
+}
+
+func TestMachineConfigWithSSHKey(t *testing.T) {
+	testCases := []struct {
+		key               string
+		expectedErrorText string
+	}{
+		{
+			key:               defaultSSHKey,
+			expectedErrorText: "",
+		},
+		{
+			key:               "",
+			expectedErrorText: "ssh 'key' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidMachineConfigTestBuilder(buildTestClientWithDummyMachineConfig()).
+			WithSSHKey(testCase.key).
+			WithSSHKey(testCase.key)
+
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText != "" {
+			continue
+		}
+
+		config, err := testBuilder.GetIgnitionConfig()
+		assert.Nil(t, err)
+		assert.Len(t, config.Passwd.Users, 1)
+		assert.Equal(t, "core", config.Passwd.Users[0].Name)
+		assert.Equal(t, []igntypes.SSHAuthorizedKey{defaultSSHKey}, config.Passwd.Users[0].SSHAuthorizedKeys)
+	}
+}
+
+func TestMachineConfigIgnitionMergesRawConfig(t *testing.T) {
+	testCases := []struct {
+		rawConfig         string
+		expectedErrorText string
+	}{
+		{
+			rawConfig: `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/existing",` +
+				`"contents":{"source":"data:,existing"},"mode":420}]}}`,
+			expectedErrorText: "",
+		},
+		{
+			rawConfig:         `{"ignition":{"version":"2.2.0"}}`,
+			expectedErrorText: "failed to parse ignition config",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidMachineConfigTestBuilder(buildTestClientWithDummyMachineConfig()).
+			WithRawConfig([]byte(testCase.rawConfig)).
+			WithSystemdUnit(defaultUnitName, defaultUnitContents, true)
+
+		if testCase.expectedErrorText != "" {
+			assert.Contains(t, testBuilder.errorMsg, testCase.expectedErrorText)
+
+			continue
+		}
+
+		assert.Empty(t, testBuilder.errorMsg)
+
+		config, err := testBuilder.GetIgnitionConfig()
+		assert.Nil(t, err)
+		assert.Equal(t, "3.4.0", config.Ignition.Version)
+		assert.Len(t, config.Storage.Files, 1)
+		assert.Equal(t, "/etc/existing", config.Storage.Files[0].Path)
+		assert.Len(t, config.Systemd.Units, 1)
+	}
+}
@@ -84,8 +84,14 @@ func PullMachineConfig(apiClient *clients.Settings, name string) (*MCBuilder, er
 		return nil, err
 	}
 
+	return pullMachineConfig(apiClient.Client, name)
+}
+
+// pullMachineConfig fetches the existing machineconfig using an already configured runtime client. It is shared by
+// PullMachineConfig and the builders in the package that look up machineconfigs by name.
+func pullMachineConfig(apiClient runtimeclient.Client, name string) (*MCBuilder, error) {
 	builder := &MCBuilder{
-		apiClient: apiClient.Client,
+		apiClient: apiClient,
 		Definition: &mcv1.MachineConfig{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: name,
@@ -157,6 +157,22 @@ func (builder *MCPBuilder) Create() (*MCPBuilder, error) {
 	return builder, err
 }
 
+// Update renovates the existing MachineConfigPool object with the MachineConfigPool definition in builder.
+func (builder *MCPBuilder) Update() (*MCPBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Updating MachineConfigPool %s", builder.Definition.Name)
+
+	err := builder.apiClient.Update(logging.DiscardContext(), builder.Definition)
+	if err == nil {
+		builder.Object = builder.Definition
+	}
+
+	return builder, err
+}
+
 // Delete removes a MachineConfigPool object from a cluster.
 func (builder *MCPBuilder) Delete() error {
 	if valid, err := builder.validate(); !valid {
@@ -188,6 +188,42 @@ func TestMachineConfigPoolCreate(t *testing.T) {
 	}
 }
 
+func TestMachineConfigPoolUpdate(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *MCPBuilder
+		expectedError string
+	}{
+		{
+			testBuilder:   buildValidMCPTestBuilder(buildTestClientWithDummyMCP()),
+			expectedError: "",
+		},
+		{
+			testBuilder:   buildInvalidMCPTestBuilder(buildTestClientWithDummyMCP()),
+			expectedError: "machineconfigpool 'name' cannot be empty",
+		},
+		{
+			testBuilder:   buildValidMCPTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: "machineconfigpools.machineconfiguration.openshift.io \"test-machine-config-pool\" not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.False(t, testCase.testBuilder.Definition.Spec.Paused)
+
+		testCase.testBuilder.Definition.ResourceVersion = "999"
+		testCase.testBuilder.Definition.Spec.Paused = true
+
+		_, err := testCase.testBuilder.Update()
+
+		if testCase.expectedError == "" {
+			assert.Nil(t, err)
+			assert.True(t, testCase.testBuilder.Object.Spec.Paused)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError)
+		}
+	}
+}
+
 func TestMachineConfigPoolDelete(t *testing.T) {
 	testCases := []struct {
 		testBuilder   *MCPBuilder
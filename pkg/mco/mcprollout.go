@@ -0,0 +1,288 @@
+package mco
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mcv1 "github.com/openshift/api/machineconfiguration/v1"
+	daemonconsts "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MCPNodeState is the machine config state of a single node in a MachineConfigPool, as reported by the
+// machine-config-daemon through the node's annotations.
+type MCPNodeState struct {
+	NodeName      string
+	CurrentConfig string
+	DesiredConfig string
+	// State is the daemon state, such as Done, Working, Rebooting, Degraded or Unreconcilable.
+	State string
+	// Reason is the human readable reason the daemon reports for Degraded and Unreconcilable states.
+	Reason string
+}
+
+// IsUpdatedTo returns true if the node has finished applying the rendered config.
+func (state MCPNodeState) IsUpdatedTo(renderedConfig string) bool {
+	return state.CurrentConfig == renderedConfig && state.DesiredConfig == renderedConfig &&
+		state.State == daemonconsts.MachineConfigDaemonStateDone
+}
+
+// IsDegraded returns true if the daemon on the node failed to apply its desired config.
+func (state MCPNodeState) IsDegraded() bool {
+	return state.State == daemonconsts.MachineConfigDaemonStateDegraded ||
+		state.State == daemonconsts.MachineConfigDaemonStateUnreconcilable
+}
+
+// String returns a short summary of the node state.
+func (state MCPNodeState) String() string {
+	summary := fmt.Sprintf("%s (state: %s, current: %s, desired: %s", state.NodeName, state.State,
+		state.CurrentConfig, state.DesiredConfig)
+
+	if state.Reason != "" {
+		summary += fmt.Sprintf(", reason: %s", state.Reason)
+	}
+
+	return summary + ")"
+}
+
+// MCPRolloutProgressFunc receives the state of every node in the pool each time WaitForRollout checks them.
+type MCPRolloutProgressFunc func(renderedConfig string, nodeStates []MCPNodeState)
+
+// mcpDegradedNodeTolerance is how long WaitForRollout lets a node stay degraded before failing, since the
+// machine-config-daemon retries applying the config of degraded nodes.
+const mcpDegradedNodeTolerance = 5 * time.Minute
+
+// WithPaused sets whether the MachineConfigPool is paused. A paused pool does not roll out new rendered configs to
+// its nodes.
+func (builder *MCPBuilder) WithPaused(paused bool) *MCPBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting paused to %t in MachineConfigPool %s", paused, builder.Definition.Name)
+
+	builder.Definition.Spec.Paused = paused
+
+	return builder
+}
+
+// WithMaxUnavailable sets how many nodes of the MachineConfigPool, as a number or a percentage, may be updating at
+// the same time.
+func (builder *MCPBuilder) WithMaxUnavailable(maxUnavailable intstr.IntOrString) *MCPBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Setting maxUnavailable to %s in MachineConfigPool %s",
+		maxUnavailable.String(), builder.Definition.Name)
+
+	if maxUnavailable.Type == intstr.Int && maxUnavailable.IntVal < 1 {
+		klog.V(100).Infof("The maxUnavailable %d of MachineConfigPool %s is not positive",
+			maxUnavailable.IntVal, builder.Definition.Name)
+
+		builder.errorMsg = "machineconfigpool 'maxUnavailable' must be greater than zero"
+
+		return builder
+	}
+
+	if maxUnavailable.Type == intstr.String && !strings.HasSuffix(maxUnavailable.StrVal, "%") {
+		klog.V(100).Infof("The maxUnavailable %s of MachineConfigPool %s is not a percentage",
+			maxUnavailable.StrVal, builder.Definition.Name)
+
+		builder.errorMsg = "machineconfigpool 'maxUnavailable' string must be a percentage"
+
+		return builder
+	}
+
+	builder.Definition.Spec.MaxUnavailable = &maxUnavailable
+
+	return builder
+}
+
+// Pause pauses the MachineConfigPool on the cluster.
+func (builder *MCPBuilder) Pause() error {
+	return builder.setPaused(true)
+}
+
+// Unpause unpauses the MachineConfigPool on the cluster, letting it roll out any pending rendered config.
+func (builder *MCPBuilder) Unpause() error {
+	return builder.setPaused(false)
+}
+
+// GetNodeStates returns the machine config state of each node selected by the MachineConfigPool's node selector.
+func (builder *MCPBuilder) GetNodeStates() ([]MCPNodeState, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting node states of MachineConfigPool %s", builder.Definition.Name)
+
+	mcp, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.getNodeStates(mcp)
+}
+
+// WaitForRollout waits for up to timeout until every node in the MachineConfigPool is running the rendered config
+// the pool targets. It stops early with an error if a node is unreconcilable or stays degraded for 5 minutes, since
+// the machine-config-daemon retries degraded nodes. If progress is not nil, it is called with the state of the nodes
+// on every check. On timeout, the returned error lists the nodes that did not update.
+func (builder *MCPBuilder) WaitForRollout(timeout time.Duration, progress MCPRolloutProgressFunc) error {
+	return builder.waitForRollout(timeout, mcpDegradedNodeTolerance, progress)
+}
+
+// waitForRollout implements WaitForRollout, failing once a node has been degraded for degradedTolerance.
+func (builder *MCPBuilder) waitForRollout(
+	timeout, degradedTolerance time.Duration, progress MCPRolloutProgressFunc) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for up to %s until MachineConfigPool %s rolls out to all nodes",
+		timeout, builder.Definition.Name)
+
+	var (
+		renderedConfig string
+		pendingNodes   []MCPNodeState
+		degradedSince  = make(map[string]time.Time)
+	)
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), fiveScds, timeout, true, func(ctx context.Context) (bool, error) {
+			mcp, err := builder.Get()
+			if err != nil {
+				klog.V(100).Infof("Failed to get MachineConfigPool %s, retrying: %v", builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			renderedConfig = mcp.Spec.Configuration.Name
+			if renderedConfig == "" {
+				klog.V(100).Infof("MachineConfigPool %s has no rendered config yet", builder.Definition.Name)
+
+				return false, nil
+			}
+
+			nodeStates, err := builder.getNodeStates(mcp)
+			if err != nil {
+				klog.V(100).Infof("Failed to get node states of MachineConfigPool %s, retrying: %v",
+					builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			if progress != nil {
+				progress(renderedConfig, nodeStates)
+			}
+
+			pendingNodes = nil
+
+			for _, nodeState := range nodeStates {
+				if nodeState.State == daemonconsts.MachineConfigDaemonStateUnreconcilable {
+					return false, fmt.Errorf("node %s in MachineConfigPool %s is unreconcilable", nodeState, mcp.Name)
+				}
+
+				if nodeState.IsDegraded() {
+					if _, found := degradedSince[nodeState.NodeName]; !found {
+						degradedSince[nodeState.NodeName] = time.Now()
+					}
+
+					if time.Since(degradedSince[nodeState.NodeName]) >= degradedTolerance {
+						return false, fmt.Errorf("node %s in MachineConfigPool %s has been degraded for %s",
+							nodeState, mcp.Name, degradedTolerance)
+					}
+
+					klog.V(100).Infof("Node %s in MachineConfigPool %s is degraded, waiting for it to recover",
+						nodeState, mcp.Name)
+				} else {
+					delete(degradedSince, nodeState.NodeName)
+				}
+
+				if !nodeState.IsUpdatedTo(renderedConfig) {
+					pendingNodes = append(pendingNodes, nodeState)
+				}
+			}
+
+			return len(pendingNodes) == 0, nil
+		})
+
+	if err == nil || !wait.Interrupted(err) {
+		return err
+	}
+
+	if len(pendingNodes) == 0 {
+		return fmt.Errorf("MachineConfigPool %s did not roll out within %s: %w", builder.Definition.Name, timeout, err)
+	}
+
+	var pendingSummaries []string
+
+	for _, nodeState := range pendingNodes {
+		pendingSummaries = append(pendingSummaries, nodeState.String())
+	}
+
+	return fmt.Errorf("MachineConfigPool %s did not roll out %s within %s, nodes not updated: %s",
+		builder.Definition.Name, renderedConfig, timeout, strings.Join(pendingSummaries, ", "))
+}
+
+// setPaused refreshes the MachineConfigPool from the cluster and updates it with the given paused value.
+func (builder *MCPBuilder) setPaused(paused bool) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	if !builder.Exists() {
+		return fmt.Errorf("cannot set paused for MachineConfigPool %s because it does not exist", builder.Definition.Name)
+	}
+
+	builder.Definition = builder.Object
+
+	_, err := builder.WithPaused(paused).Update()
+
+	return err
+}
+
+// getNodeStates lists the nodes selected by the node selector of mcp and reads their machine config annotations.
+func (builder *MCPBuilder) getNodeStates(mcp *mcv1.MachineConfigPool) ([]MCPNodeState, error) {
+	if mcp.Spec.NodeSelector == nil {
+		return nil, fmt.Errorf("MachineConfigPool %s has no node selector", mcp.Name)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(mcp.Spec.NodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("MachineConfigPool %s has an invalid node selector: %w", mcp.Name, err)
+	}
+
+	nodeList := &corev1.NodeList{}
+
+	err = builder.apiClient.List(
+		logging.DiscardContext(), nodeList, runtimeclient.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		klog.V(100).Infof("Failed to list nodes of MachineConfigPool %s: %v", mcp.Name, err)
+
+		return nil, fmt.Errorf("failed to list nodes of MachineConfigPool %s: %w", mcp.Name, err)
+	}
+
+	var nodeStates []MCPNodeState
+
+	for _, node := range nodeList.Items {
+		nodeStates = append(nodeStates, MCPNodeState{
+			NodeName:      node.Name,
+			CurrentConfig: node.Annotations[daemonconsts.CurrentMachineConfigAnnotationKey],
+			DesiredConfig: node.Annotations[daemonconsts.DesiredMachineConfigAnnotationKey],
+			State:         node.Annotations[daemonconsts.MachineConfigDaemonStateAnnotationKey],
+			Reason:        node.Annotations[daemonconsts.MachineConfigDaemonReasonAnnotationKey],
+		})
+	}
+
+	return nodeStates, nil
+}
@@ -0,0 +1,251 @@
+package mco
+
+import (
+	"testing"
+	"time"
+
+	mcv1 "github.com/openshift/api/machineconfiguration/v1"
+	daemonconsts "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	defaultRenderedConfig = "rendered-test-new"
+	previousRendered      = "rendered-test-old"
+	defaultMCPNodeRole    = "node-role.kubernetes.io/test"
+)
+
+func TestMachineConfigPoolWithPaused(t *testing.T) {
+	testBuilder := buildValidMCPTestBuilder(buildTestClientWithDummyMCP())
+
+	testBuilder = testBuilder.WithPaused(true)
+	assert.True(t, testBuilder.Definition.Spec.Paused)
+
+	testBuilder = testBuilder.WithPaused(false)
+	assert.False(t, testBuilder.Definition.Spec.Paused)
+}
+
+func TestMachineConfigPoolWithMaxUnavailable(t *testing.T) {
+	testCases := []struct {
+		maxUnavailable    intstr.IntOrString
+		expectedErrorText string
+	}{
+		{
+			maxUnavailable:    intstr.FromInt32(2),
+			expectedErrorText: "",
+		},
+		{
+			maxUnavailable:    intstr.FromString("50%"),
+			expectedErrorText: "",
+		},
+		{
+			maxUnavailable:    intstr.FromInt32(0),
+			expectedErrorText: "machineconfigpool 'maxUnavailable' must be greater than zero",
+		},
+		{
+			maxUnavailable:    intstr.FromString("two"),
+			expectedErrorText: "machineconfigpool 'maxUnavailable' string must be a percentage",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidMCPTestBuilder(buildTestClientWithDummyMCP()).WithMaxUnavailable(testCase.maxUnavailable)
+
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.maxUnavailable, *testBuilder.Definition.Spec.MaxUnavailable)
+		}
+	}
+}
+
+func TestMachineConfigPoolPause(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError string
+	}{
+		{
+			exists:        true,
+			expectedError: "",
+		},
+		{
+			exists:        false,
+			expectedError: "cannot set paused for MachineConfigPool test-machine-config-pool because it does not exist",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyMCP(defaultMCPName))
+		}
+
+		testBuilder := buildValidMCPTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects:  runtimeObjects,
+			SchemeAttachers: testSchemes,
+		}))
+
+		err := testBuilder.Pause()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+
+		mcp, err := testBuilder.Get()
+		assert.Nil(t, err)
+		assert.True(t, mcp.Spec.Paused)
+
+		err = testBuilder.Unpause()
+		assert.Nil(t, err)
+
+		mcp, err = testBuilder.Get()
+		assert.Nil(t, err)
+		assert.False(t, mcp.Spec.Paused)
+	}
+}
+
+func TestMachineConfigPoolGetNodeStates(t *testing.T) {
+	testBuilder := buildValidMCPTestBuilder(buildTestClientWithRolloutNodes(
+		buildRolloutTestNode("node-0", defaultRenderedConfig, defaultRenderedConfig, daemonconsts.MachineConfigDaemonStateDone, ""),
+		buildRolloutTestNode("node-1", previousRendered, defaultRenderedConfig, daemonconsts.MachineConfigDaemonStateWorking, ""),
+	))
+
+	nodeStates, err := testBuilder.GetNodeStates()
+	assert.Nil(t, err)
+	assert.Len(t, nodeStates, 2)
+
+	for _, nodeState := range nodeStates {
+		switch nodeState.NodeName {
+		case "node-0":
+			assert.True(t, nodeState.IsUpdatedTo(defaultRenderedConfig))
+		case "node-1":
+			assert.False(t, nodeState.IsUpdatedTo(defaultRenderedConfig))
+			assert.Equal(t, previousRendered, nodeState.CurrentConfig)
+			assert.Equal(t, daemonconsts.MachineConfigDaemonStateWorking, nodeState.State)
+		default:
+			t.Errorf("unexpected node %s in pool", nodeState.NodeName)
+		}
+	}
+}
+
+func TestMachineConfigPoolWaitForRollout(t *testing.T) {
+	testCases := []struct {
+		secondNodeState   string
+		secondNodeReason  string
+		degradedTolerance time.Duration
+		expectedError     string
+	}{
+		{
+			secondNodeState:   daemonconsts.MachineConfigDaemonStateDone,
+			degradedTolerance: mcpDegradedNodeTolerance,
+			expectedError:     "",
+		},
+		{
+			secondNodeState:   daemonconsts.MachineConfigDaemonStateDegraded,
+			secondNodeReason:  "failed to drain node",
+			degradedTolerance: mcpDegradedNodeTolerance,
+			expectedError: "MachineConfigPool test-machine-config-pool did not roll out rendered-test-new within 1s, " +
+				"nodes not updated: node-1 (state: Degraded, current: rendered-test-old, desired: rendered-test-new, " +
+				"reason: failed to drain node)",
+		},
+		{
+			secondNodeState:   daemonconsts.MachineConfigDaemonStateDegraded,
+			secondNodeReason:  "failed to drain node",
+			degradedTolerance: 0,
+			expectedError: "node node-1 (state: Degraded, current: rendered-test-old, desired: rendered-test-new, " +
+				"reason: failed to drain node) in MachineConfigPool test-machine-config-pool has been degraded for 0s",
+		},
+		{
+			secondNodeState:   daemonconsts.MachineConfigDaemonStateUnreconcilable,
+			secondNodeReason:  "invalid ignition config",
+			degradedTolerance: mcpDegradedNodeTolerance,
+			expectedError: "node node-1 (state: Unreconcilable, current: rendered-test-old, desired: rendered-test-new, " +
+				"reason: invalid ignition config) in MachineConfigPool test-machine-config-pool is unreconcilable",
+		},
+		{
+			secondNodeState:   daemonconsts.MachineConfigDaemonStateWorking,
+			degradedTolerance: mcpDegradedNodeTolerance,
+			expectedError: "MachineConfigPool test-machine-config-pool did not roll out rendered-test-new within 1s, " +
+				"nodes not updated: node-1 (state: Working, current: rendered-test-old, desired: rendered-test-new)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		secondCurrent := previousRendered
+		if testCase.secondNodeState == daemonconsts.MachineConfigDaemonStateDone {
+			secondCurrent = defaultRenderedConfig
+		}
+
+		testBuilder := buildValidMCPTestBuilder(buildTestClientWithRolloutNodes(
+			buildRolloutTestNode(
+				"node-0", defaultRenderedConfig, defaultRenderedConfig, daemonconsts.MachineConfigDaemonStateDone, ""),
+			buildRolloutTestNode(
+				"node-1", secondCurrent, defaultRenderedConfig, testCase.secondNodeState, testCase.secondNodeReason),
+		))
+
+		progressCalls := 0
+
+		err := testBuilder.waitForRollout(time.Second, testCase.degradedTolerance,
+			func(renderedConfig string, nodeStates []MCPNodeState) {
+				progressCalls++
+
+				assert.Equal(t, defaultRenderedConfig, renderedConfig)
+				assert.Len(t, nodeStates, 2)
+			})
+
+		assert.Equal(t, 1, progressCalls)
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
+
+// buildRolloutTestNode returns a node in the test pool with the provided machine config annotations.
+func buildRolloutTestNode(name, currentConfig, desiredConfig, state, reason string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{defaultMCPNodeRole: ""},
+			Annotations: map[string]string{
+				daemonconsts.CurrentMachineConfigAnnotationKey:      currentConfig,
+				daemonconsts.DesiredMachineConfigAnnotationKey:      desiredConfig,
+				daemonconsts.MachineConfigDaemonStateAnnotationKey:  state,
+				daemonconsts.MachineConfigDaemonReasonAnnotationKey: reason,
+			},
+		},
+	}
+}
+
+// buildTestClientWithRolloutNodes returns a client with a pool targeting defaultRenderedConfig, the provided nodes
+// in it and one node outside of it.
+func buildTestClientWithRolloutNodes(nodes ...*corev1.Node) *clients.Settings {
+	mcp := buildDummyMCP(defaultMCPName)
+	mcp.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{defaultMCPNodeRole: ""}}
+	mcp.Spec.Configuration = mcv1.MachineConfigPoolStatusConfiguration{
+		ObjectReference: corev1.ObjectReference{Name: defaultRenderedConfig},
+	}
+
+	runtimeObjects := []runtime.Object{mcp, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "other-node"}}}
+
+	for _, node := range nodes {
+		runtimeObjects = append(runtimeObjects, node)
+	}
+
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects:  runtimeObjects,
+		SchemeAttachers: testSchemes,
+	})
+}
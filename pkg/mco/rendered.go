@@ -0,0 +1,261 @@
+package mco
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_4/types"
+	mcv1 "github.com/openshift/api/machineconfiguration/v1"
+	daemonconsts "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// MachineConfigDiff lists what changed between two rendered MachineConfigs. Files are identified by path, units by
+// name and kernel arguments by their full value.
+type MachineConfigDiff struct {
+	AddedFiles   []string
+	RemovedFiles []string
+	ChangedFiles []string
+
+	AddedUnits   []string
+	RemovedUnits []string
+	ChangedUnits []string
+
+	AddedKernelArguments   []string
+	RemovedKernelArguments []string
+
+	AddedExtensions   []string
+	RemovedExtensions []string
+
+	// ChangedFields lists the other spec fields that differ, such as osImageURL, kernelType and fips.
+	ChangedFields []string
+}
+
+// IsEmpty returns true if the two MachineConfigs have no differences.
+func (diff *MachineConfigDiff) IsEmpty() bool {
+	return reflect.ValueOf(*diff).IsZero()
+}
+
+// String returns a summary of the differences with one line per kind of change.
+func (diff *MachineConfigDiff) String() string {
+	var lines []string
+
+	appendLine := func(kind string, values []string) {
+		if len(values) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", kind, strings.Join(values, ", ")))
+		}
+	}
+
+	appendLine("added files", diff.AddedFiles)
+	appendLine("removed files", diff.RemovedFiles)
+	appendLine("changed files", diff.ChangedFiles)
+	appendLine("added units", diff.AddedUnits)
+	appendLine("removed units", diff.RemovedUnits)
+	appendLine("changed units", diff.ChangedUnits)
+	appendLine("added kernel arguments", diff.AddedKernelArguments)
+	appendLine("removed kernel arguments", diff.RemovedKernelArguments)
+	appendLine("added extensions", diff.AddedExtensions)
+	appendLine("removed extensions", diff.RemovedExtensions)
+	appendLine("changed fields", diff.ChangedFields)
+
+	if len(lines) == 0 {
+		return "no differences"
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// GetRenderedMachineConfig returns the rendered MachineConfig the MachineConfigPool currently reports in its status.
+// While the pool is updating, this may differ from the rendered MachineConfig it targets.
+func (builder *MCPBuilder) GetRenderedMachineConfig() (*MCBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rendered MachineConfig of MachineConfigPool %s", builder.Definition.Name)
+
+	mcp, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if mcp.Status.Configuration.Name == "" {
+		return nil, fmt.Errorf("machineconfigpool %s has no rendered machineconfig in its status", mcp.Name)
+	}
+
+	return pullMachineConfig(builder.apiClient, mcp.Status.Configuration.Name)
+}
+
+// GetRenderedSources returns the names of the MachineConfigs that were merged into the rendered MachineConfig the
+// MachineConfigPool currently reports in its status.
+func (builder *MCPBuilder) GetRenderedSources() ([]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting source MachineConfigs of MachineConfigPool %s", builder.Definition.Name)
+
+	mcp, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+
+	for _, source := range mcp.Status.Configuration.Source {
+		sources = append(sources, source.Name)
+	}
+
+	return sources, nil
+}
+
+// PullRenderedMachineConfigForNode returns the rendered MachineConfig the node currently runs, as reported by the
+// machine-config-daemon in the node's currentConfig annotation.
+func PullRenderedMachineConfigForNode(apiClient *clients.Settings, nodeName string) (*MCBuilder, error) {
+	klog.V(100).Infof("Pulling rendered machineconfig of node %s", nodeName)
+
+	if apiClient == nil {
+		klog.V(100).Info("The apiClient of the MachineConfig is nil")
+
+		return nil, fmt.Errorf("machineconfig 'apiClient' cannot be nil")
+	}
+
+	if nodeName == "" {
+		klog.V(100).Info("The node name is empty")
+
+		return nil, fmt.Errorf("node 'name' cannot be empty")
+	}
+
+	node, err := apiClient.CoreV1Interface.Nodes().Get(logging.DiscardContext(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to get node %s: %v", nodeName, err)
+
+		return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	currentConfig := node.Annotations[daemonconsts.CurrentMachineConfigAnnotationKey]
+	if currentConfig == "" {
+		return nil, fmt.Errorf("node %s has no current machineconfig annotation", nodeName)
+	}
+
+	return PullMachineConfig(apiClient, currentConfig)
+}
+
+// DiffMachineConfigs compares the definitions of two MachineConfigs, usually two rendered ones, and returns what
+// changed from oldConfig to newConfig.
+func DiffMachineConfigs(oldConfig, newConfig *MCBuilder) (*MachineConfigDiff, error) {
+	if valid, err := oldConfig.validate(); !valid {
+		return nil, err
+	}
+
+	if valid, err := newConfig.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Comparing MachineConfig %s to %s", oldConfig.Definition.Name, newConfig.Definition.Name)
+
+	oldIgnition, err := parseIgnitionConfig(oldConfig.Definition.Spec.Config.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse machineconfig %s: %w", oldConfig.Definition.Name, err)
+	}
+
+	newIgnition, err := parseIgnitionConfig(newConfig.Definition.Spec.Config.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse machineconfig %s: %w", newConfig.Definition.Name, err)
+	}
+
+	diff := &MachineConfigDiff{}
+
+	diff.AddedFiles, diff.RemovedFiles, diff.ChangedFiles = diffKeyed(
+		oldIgnition.Storage.Files, newIgnition.Storage.Files, func(file igntypes.File) string { return file.Path })
+	diff.AddedUnits, diff.RemovedUnits, diff.ChangedUnits = diffKeyed(
+		oldIgnition.Systemd.Units, newIgnition.Systemd.Units, func(unit igntypes.Unit) string { return unit.Name })
+
+	identity := func(value string) string { return value }
+
+	diff.AddedKernelArguments, diff.RemovedKernelArguments, _ = diffKeyed(
+		getKernelArguments(oldConfig.Definition, oldIgnition), getKernelArguments(newConfig.Definition, newIgnition),
+		identity)
+	diff.AddedExtensions, diff.RemovedExtensions, _ = diffKeyed(
+		oldConfig.Definition.Spec.Extensions, newConfig.Definition.Spec.Extensions, identity)
+
+	oldSpec, newSpec := oldConfig.Definition.Spec, newConfig.Definition.Spec
+
+	if oldSpec.OSImageURL != newSpec.OSImageURL {
+		diff.ChangedFields = append(diff.ChangedFields, "osImageURL")
+	}
+
+	if oldSpec.BaseOSExtensionsContainerImage != newSpec.BaseOSExtensionsContainerImage {
+		diff.ChangedFields = append(diff.ChangedFields, "baseOSExtensionsContainerImage")
+	}
+
+	if oldSpec.KernelType != newSpec.KernelType {
+		diff.ChangedFields = append(diff.ChangedFields, "kernelType")
+	}
+
+	if oldSpec.FIPS != newSpec.FIPS {
+		diff.ChangedFields = append(diff.ChangedFields, "fips")
+	}
+
+	return diff, nil
+}
+
+// getKernelArguments returns the kernel arguments set by the MachineConfig spec and its Ignition config. Spec
+// entries may hold several space separated arguments, so they are split.
+func getKernelArguments(machineConfig *mcv1.MachineConfig, config *igntypes.Config) []string {
+	var kernelArgs []string
+
+	for _, kernelArg := range machineConfig.Spec.KernelArguments {
+		kernelArgs = append(kernelArgs, strings.Fields(kernelArg)...)
+	}
+
+	for _, kernelArg := range config.KernelArguments.ShouldExist {
+		kernelArgs = append(kernelArgs, string(kernelArg))
+	}
+
+	return kernelArgs
+}
+
+// diffKeyed compares two lists of elements identified by key and returns the sorted keys that were added, removed
+// and, for keys in both lists, changed.
+func diffKeyed[T any](oldElements, newElements []T, key func(T) string) ([]string, []string, []string) {
+	oldByKey := make(map[string]T, len(oldElements))
+	for _, element := range oldElements {
+		oldByKey[key(element)] = element
+	}
+
+	newByKey := make(map[string]T, len(newElements))
+	for _, element := range newElements {
+		newByKey[key(element)] = element
+	}
+
+	var added, removed, changed []string
+
+	for elementKey, newElement := range newByKey {
+		oldElement, found := oldByKey[elementKey]
+
+		switch {
+		case !found:
+			added = append(added, elementKey)
+		case !reflect.DeepEqual(oldElement, newElement):
+			changed = append(changed, elementKey)
+		}
+	}
+
+	for elementKey := range oldByKey {
+		if _, found := newByKey[elementKey]; !found {
+			removed = append(removed, elementKey)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(removed)
+	slices.Sort(changed)
+
+	return added, removed, changed
+}
@@ -0,0 +1,159 @@
+package mco
+
+import (
+	"testing"
+
+	mcv1 "github.com/openshift/api/machineconfiguration/v1"
+	daemonconsts "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestMachineConfigPoolGetRenderedMachineConfig(t *testing.T) {
+	testCases := []struct {
+		renderedConfig string
+		expectedError  string
+	}{
+		{
+			renderedConfig: previousRendered,
+			expectedError:  "",
+		},
+		{
+			renderedConfig: "",
+			expectedError:  "machineconfigpool test-machine-config-pool has no rendered machineconfig in its status",
+		},
+		{
+			renderedConfig: "rendered-missing",
+			expectedError:  "machineconfig object rendered-missing does not exist",
+		},
+	}
+
+	for _, testCase := range testCases {
+		mcp := buildDummyMCP(defaultMCPName)
+		mcp.Status.Configuration = mcv1.MachineConfigPoolStatusConfiguration{
+			ObjectReference: corev1.ObjectReference{Name: testCase.renderedConfig},
+			Source:          []corev1.ObjectReference{{Name: "00-worker"}, {Name: "99-test"}},
+		}
+
+		testBuilder := buildValidMCPTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects:  []runtime.Object{mcp, buildDummyMachineConfig(previousRendered)},
+			SchemeAttachers: testSchemes,
+		}))
+
+		renderedBuilder, err := testBuilder.GetRenderedMachineConfig()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, previousRendered, renderedBuilder.Definition.Name)
+
+		sources, err := testBuilder.GetRenderedSources()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"00-worker", "99-test"}, sources)
+	}
+}
+
+func TestPullRenderedMachineConfigForNode(t *testing.T) {
+	testCases := []struct {
+		nodeName      string
+		currentConfig string
+		expectedError string
+	}{
+		{
+			nodeName:      "node-0",
+			currentConfig: previousRendered,
+			expectedError: "",
+		},
+		{
+			nodeName:      "",
+			currentConfig: previousRendered,
+			expectedError: "node 'name' cannot be empty",
+		},
+		{
+			nodeName:      "node-0",
+			currentConfig: "",
+			expectedError: "node node-0 has no current machineconfig annotation",
+		},
+		{
+			nodeName:      "missing-node",
+			currentConfig: previousRendered,
+			expectedError: "failed to get node missing-node",
+		},
+	}
+
+	for _, testCase := range testCases {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        "node-0",
+			Annotations: map[string]string{daemonconsts.CurrentMachineConfigAnnotationKey: testCase.currentConfig},
+		}}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects:  []runtime.Object{node, buildDummyMachineConfig(previousRendered)},
+			SchemeAttachers: testSchemes,
+		})
+
+		renderedBuilder, err := PullRenderedMachineConfigForNode(testSettings, testCase.nodeName)
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, previousRendered, renderedBuilder.Definition.Name)
+	}
+}
+
+func TestDiffMachineConfigs(t *testing.T) {
+	testSettings := buildTestClientWithDummyMachineConfig()
+
+	oldBuilder := NewMCBuilder(testSettings, previousRendered).
+		WithFile("/etc/removed", []byte("removed"), 0644).
+		WithFile("/etc/changed", []byte("old"), 0644).
+		WithFile("/etc/same", []byte("same"), 0644).
+		WithSystemdUnit(defaultUnitName, defaultUnitContents, true).
+		WithKernelArguments([]string{"nosmt", "hugepages=1 default_hugepagesz=1G"})
+	oldBuilder.Definition.Spec.OSImageURL = "quay.io/test/os@sha256:old"
+
+	newBuilder := NewMCBuilder(testSettings, defaultRenderedConfig).
+		WithFile("/etc/changed", []byte("new"), 0644).
+		WithFile("/etc/same", []byte("same"), 0644).
+		WithFile("/etc/added", []byte("added"), 0600).
+		WithSystemdUnit(defaultUnitName, defaultUnitContents, false).
+		WithSystemdDropin("kubelet.service", "10-test.conf", "[Service]\n").
+		WithKernelArguments([]string{"hugepages=2 default_hugepagesz=1G"}).
+		WithExtensions([]string{"usbguard"})
+	newBuilder.Definition.Spec.OSImageURL = "quay.io/test/os@sha256:new"
+
+	diff, err := DiffMachineConfigs(oldBuilder, newBuilder)
+	assert.Nil(t, err)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, &MachineConfigDiff{
+		AddedFiles:             []string{"/etc/added"},
+		RemovedFiles:           []string{"/etc/removed"},
+		ChangedFiles:           []string{"/etc/changed"},
+		AddedUnits:             []string{"kubelet.service"},
+		ChangedUnits:           []string{defaultUnitName},
+		AddedKernelArguments:   []string{"hugepages=2"},
+		RemovedKernelArguments: []string{"hugepages=1", "nosmt"},
+		AddedExtensions:        []string{"usbguard"},
+		ChangedFields:          []string{"osImageURL"},
+	}, diff)
+	assert.Contains(t, diff.String(), "added files: /etc/added\n")
+
+	diff, err = DiffMachineConfigs(oldBuilder, oldBuilder)
+	assert.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Equal(t, "no differences", diff.String())
+
+	_, err = DiffMachineConfigs(oldBuilder, buildInvalidMachineConfigTestBuilder(testSettings))
+	assert.EqualError(t, err, "machineconfig 'name' cannot be empty")
+}
@@ -0,0 +1,342 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/poddisruptionbudget"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/drain"
+)
+
+// DrainEventType is the stage of a pod eviction reported to a DrainProgressFunc.
+type DrainEventType string
+
+const (
+	// DrainEventEvicting is reported when the eviction of a pod is first requested.
+	DrainEventEvicting DrainEventType = "Evicting"
+	// DrainEventBlocked is reported every time the eviction of a pod is rejected because of a PodDisruptionBudget.
+	DrainEventBlocked DrainEventType = "Blocked"
+	// DrainEventEvicted is reported once an evicted pod has been removed from the node.
+	DrainEventEvicted DrainEventType = "Evicted"
+	// DrainEventFailed is reported when the eviction of a pod fails with an error that stops the drain.
+	DrainEventFailed DrainEventType = "Failed"
+)
+
+// DrainEvent describes the progress of the eviction of a single pod.
+type DrainEvent struct {
+	Type DrainEventType
+	Pod  *corev1.Pod
+	// PodDisruptionBudget is the budget that rejected the eviction for Blocked events. It is nil if the budget could
+	// not be determined.
+	PodDisruptionBudget *poddisruptionbudget.Builder
+	// Err is the error that caused Failed events.
+	Err error
+}
+
+// DrainProgressFunc receives the progress of a drain, one event at a time.
+type DrainProgressFunc func(event DrainEvent)
+
+// DrainOptions controls which pods are evicted by DrainWithOptions and how long it waits for them.
+type DrainOptions struct {
+	// Force allows evicting pods that are not managed by a controller.
+	Force bool
+	// IgnoreDaemonSets skips pods managed by a DaemonSet instead of failing the drain.
+	IgnoreDaemonSets bool
+	// DeleteEmptyDirData allows evicting pods with emptyDir volumes, whose data is lost.
+	DeleteEmptyDirData bool
+	// GracePeriodSeconds overrides the termination grace period of evicted pods. If nil, each pod's own grace period
+	// is used.
+	GracePeriodSeconds *int64
+	// SkipWaitForDeleteTimeoutSeconds, if not zero, skips pods that have been terminating for longer than this.
+	SkipWaitForDeleteTimeoutSeconds int
+	// Timeout is how long to wait for all pods to be evicted and removed. Zero means no timeout.
+	Timeout time.Duration
+	// Progress, if not nil, is called for every DrainEvent.
+	Progress DrainProgressFunc
+}
+
+// DrainWithOptions cordons the node and evicts its pods according to options, retrying evictions rejected by a
+// PodDisruptionBudget until options.Timeout. If the timeout is reached while evictions are blocked, the returned error
+// names the blocking budgets.
+func (builder *Builder) DrainWithOptions(options DrainOptions) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Draining node %s with timeout %s", builder.Definition.Name, options.Timeout)
+
+	err := builder.Cordon()
+	if err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", builder.Definition.Name, err)
+	}
+
+	podsToEvict, err := builder.getPodsToDrain(options)
+	if err != nil {
+		return err
+	}
+
+	return builder.evictPods(podsToEvict, options)
+}
+
+// DrainDryRun returns the pods that DrainWithOptions would evict with the same options, without cordoning the node or
+// evicting anything. It returns an error if some pods would prevent the drain.
+func (builder *Builder) DrainDryRun(options DrainOptions) ([]*corev1.Pod, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Listing pods that would be evicted by draining node %s", builder.Definition.Name)
+
+	return builder.getPodsToDrain(options)
+}
+
+// getPodsToDrain lists the pods on the node and returns the ones to evict. Mirror pods, DaemonSet pods when ignored
+// and pods that have been terminating for too long are skipped. Pods that cannot be evicted with the given options
+// are collected into the returned error.
+func (builder *Builder) getPodsToDrain(options DrainOptions) ([]*corev1.Pod, error) {
+	nodePods, err := pod.ListInAllNamespaces(builder.apiClient, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", builder.Definition.Name),
+	})
+	if err != nil {
+		klog.V(100).Infof("Failed to list pods on node %s: %v", builder.Definition.Name, err)
+
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", builder.Definition.Name, err)
+	}
+
+	var (
+		podsToEvict []*corev1.Pod
+		podErrors   []string
+	)
+
+	for _, nodePod := range nodePods {
+		podObject := nodePod.Object
+		if podObject.Spec.NodeName != builder.Definition.Name {
+			continue
+		}
+
+		skip, reason := shouldSkipDrainingPod(podObject, options)
+		if skip {
+			continue
+		}
+
+		if reason != "" {
+			podErrors = append(podErrors, fmt.Sprintf("%s/%s %s", podObject.Namespace, podObject.Name, reason))
+
+			continue
+		}
+
+		podsToEvict = append(podsToEvict, podObject)
+	}
+
+	if len(podErrors) > 0 {
+		return nil, fmt.Errorf("cannot drain node %s: %s", builder.Definition.Name, strings.Join(podErrors, "; "))
+	}
+
+	return podsToEvict, nil
+}
+
+// evictPods evicts podsToEvict and waits until they are removed, retrying evictions blocked by a PodDisruptionBudget.
+//
+//nolint:gocognit,funlen
+func (builder *Builder) evictPods(podsToEvict []*corev1.Pod, options DrainOptions) error {
+	ctx := context.TODO()
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	pending := podsToEvict
+	requested := make(map[string]bool)
+	blocked := make(map[string]string)
+
+	var terminating []*corev1.Pod
+
+	err := wait.PollUntilContextCancel(ctx, 3*time.Second, true, func(ctx context.Context) (bool, error) {
+		var stillPending []*corev1.Pod
+
+		for _, podObject := range pending {
+			podKey := podObject.Namespace + "/" + podObject.Name
+
+			if !requested[podKey] {
+				requested[podKey] = true
+
+				options.report(DrainEvent{Type: DrainEventEvicting, Pod: podObject})
+			}
+
+			err := builder.apiClient.PolicyV1Interface.Evictions(podObject.Namespace).Evict(
+				logging.DiscardContext(), &policyv1.Eviction{
+					ObjectMeta:    metav1.ObjectMeta{Name: podObject.Name, Namespace: podObject.Namespace},
+					DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: options.GracePeriodSeconds},
+				})
+
+			switch {
+			case err == nil || k8serrors.IsNotFound(err):
+				delete(blocked, podKey)
+
+				terminating = append(terminating, podObject)
+			case k8serrors.IsTooManyRequests(err):
+				pdbBuilder := builder.findBlockingPDB(podObject)
+
+				blocked[podKey] = "unknown PodDisruptionBudget"
+				if pdbBuilder != nil {
+					blocked[podKey] = fmt.Sprintf("PodDisruptionBudget %s/%s",
+						pdbBuilder.Definition.Namespace, pdbBuilder.Definition.Name)
+				}
+
+				klog.V(100).Infof("Eviction of pod %s on node %s is blocked by %s",
+					podKey, builder.Definition.Name, blocked[podKey])
+
+				options.report(DrainEvent{Type: DrainEventBlocked, Pod: podObject, PodDisruptionBudget: pdbBuilder})
+
+				stillPending = append(stillPending, podObject)
+			default:
+				options.report(DrainEvent{Type: DrainEventFailed, Pod: podObject, Err: err})
+
+				return false, fmt.Errorf("failed to evict pod %s from node %s: %w", podKey, builder.Definition.Name, err)
+			}
+		}
+
+		pending = stillPending
+
+		var stillTerminating []*corev1.Pod
+
+		for _, podObject := range terminating {
+			currentPod, err := builder.apiClient.CoreV1Interface.Pods(podObject.Namespace).Get(
+				logging.DiscardContext(), podObject.Name, metav1.GetOptions{})
+
+			if k8serrors.IsNotFound(err) || (err == nil && currentPod.UID != podObject.UID) {
+				options.report(DrainEvent{Type: DrainEventEvicted, Pod: podObject})
+
+				continue
+			}
+
+			stillTerminating = append(stillTerminating, podObject)
+		}
+
+		terminating = stillTerminating
+
+		return len(pending) == 0 && len(terminating) == 0, nil
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if len(blocked) > 0 {
+		var blockedPods []string
+
+		for _, podObject := range pending {
+			podKey := podObject.Namespace + "/" + podObject.Name
+			blockedPods = append(blockedPods, fmt.Sprintf("%s by %s", podKey, blocked[podKey]))
+		}
+
+		return fmt.Errorf("failed to drain node %s, evictions blocked: %s", builder.Definition.Name,
+			strings.Join(blockedPods, ", "))
+	}
+
+	return fmt.Errorf("failed to drain node %s: %w", builder.Definition.Name, err)
+}
+
+// findBlockingPDB returns the PodDisruptionBudget in the pod's namespace that selects the pod, or nil if there is
+// none or it cannot be listed.
+func (builder *Builder) findBlockingPDB(podObject *corev1.Pod) *poddisruptionbudget.Builder {
+	pdbBuilders, err := poddisruptionbudget.List(builder.apiClient, podObject.Namespace)
+	if err != nil {
+		klog.V(100).Infof("Failed to list PodDisruptionBudgets in namespace %s: %v", podObject.Namespace, err)
+
+		return nil
+	}
+
+	for _, pdbBuilder := range pdbBuilders {
+		selector, err := metav1.LabelSelectorAsSelector(pdbBuilder.Object.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+
+		if selector.Matches(labels.Set(podObject.Labels)) {
+			return pdbBuilder
+		}
+	}
+
+	return nil
+}
+
+// report calls the progress function, if set, with event.
+func (options DrainOptions) report(event DrainEvent) {
+	if options.Progress != nil {
+		options.Progress(event)
+	}
+}
+
+// shouldSkipDrainingPod returns true if the pod should be left on the node. Otherwise, it returns a non-empty reason
+// if the pod cannot be evicted with options.
+func shouldSkipDrainingPod(podObject *corev1.Pod, options DrainOptions) (bool, string) {
+	if _, isMirror := podObject.Annotations[corev1.MirrorPodAnnotationKey]; isMirror {
+		return true, ""
+	}
+
+	if podObject.DeletionTimestamp != nil && options.SkipWaitForDeleteTimeoutSeconds > 0 &&
+		time.Since(podObject.DeletionTimestamp.Time) > time.Duration(options.SkipWaitForDeleteTimeoutSeconds)*time.Second {
+		return true, ""
+	}
+
+	if podObject.Status.Phase == corev1.PodSucceeded || podObject.Status.Phase == corev1.PodFailed {
+		return false, ""
+	}
+
+	controllerRef := metav1.GetControllerOf(podObject)
+
+	if controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+		if options.IgnoreDaemonSets {
+			return true, ""
+		}
+
+		return false, "is managed by a DaemonSet"
+	}
+
+	if !options.DeleteEmptyDirData {
+		for _, volume := range podObject.Spec.Volumes {
+			if volume.EmptyDir != nil {
+				return false, "has local storage in an emptyDir volume"
+			}
+		}
+	}
+
+	if controllerRef == nil && !options.Force {
+		return false, "is not managed by a controller"
+	}
+
+	return false, ""
+}
+
+// drainOptionsFromHelper converts the settings of the drain helper into DrainOptions.
+func drainOptionsFromHelper(helper *drain.Helper) DrainOptions {
+	options := DrainOptions{
+		Force:                           helper.Force,
+		IgnoreDaemonSets:                helper.IgnoreAllDaemonSets,
+		DeleteEmptyDirData:              helper.DeleteEmptyDirData,
+		SkipWaitForDeleteTimeoutSeconds: helper.SkipWaitForDeleteTimeoutSeconds,
+		Timeout:                         helper.Timeout,
+	}
+
+	if helper.GracePeriodSeconds >= 0 {
+		gracePeriod := int64(helper.GracePeriodSeconds)
+		options.GracePeriodSeconds = &gracePeriod
+	}
+
+	return options
+}
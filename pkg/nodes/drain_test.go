@@ -0,0 +1,281 @@
+package nodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const drainTestProtectedLabel = "protected"
+
+func TestNodeDrainDryRun(t *testing.T) {
+	testCases := []struct {
+		options        DrainOptions
+		expectedPods   []string
+		expectedErrors []string
+	}{
+		{
+			options:      DrainOptions{Force: true, IgnoreDaemonSets: true, DeleteEmptyDirData: true},
+			expectedPods: []string{"managed", "unmanaged", "emptydir", "completed"},
+		},
+		{
+			options: DrainOptions{},
+			expectedErrors: []string{
+				"cannot drain node test-node: ",
+				"test-namespace/unmanaged is not managed by a controller",
+				"test-namespace/emptydir has local storage in an emptyDir volume",
+				"test-namespace/daemonset is managed by a DaemonSet",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNodeTestBuilder(buildTestClientWithDrainPods())
+
+		podsToEvict, err := testBuilder.DrainDryRun(testCase.options)
+
+		if len(testCase.expectedErrors) > 0 {
+			for _, expectedError := range testCase.expectedErrors {
+				assert.ErrorContains(t, err, expectedError)
+			}
+
+			continue
+		}
+
+		assert.Nil(t, err)
+
+		var podNames []string
+
+		for _, podObject := range podsToEvict {
+			podNames = append(podNames, podObject.Name)
+		}
+
+		assert.ElementsMatch(t, testCase.expectedPods, podNames)
+
+		node, err := Pull(testBuilder.apiClient, defaultNodeName)
+		assert.Nil(t, err)
+		assert.False(t, node.Object.Spec.Unschedulable)
+	}
+}
+
+func TestNodeDrainWithOptions(t *testing.T) {
+	testCases := []struct {
+		protected     bool
+		expectedError string
+	}{
+		{
+			protected:     false,
+			expectedError: "",
+		},
+		{
+			protected: true,
+			expectedError: "failed to drain node test-node, evictions blocked: " +
+				"test-namespace/managed by PodDisruptionBudget test-namespace/test-pdb",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testClient := buildTestClientWithDrainPods()
+		addEvictionReactor(testClient)
+
+		if testCase.protected {
+			managedPod, err := testClient.CoreV1Interface.Pods("test-namespace").Get(t.Context(), "managed", metav1.GetOptions{})
+			assert.Nil(t, err)
+
+			managedPod.Labels = map[string]string{"app": drainTestProtectedLabel}
+
+			_, err = testClient.CoreV1Interface.Pods("test-namespace").Update(t.Context(), managedPod, metav1.UpdateOptions{})
+			assert.Nil(t, err)
+		}
+
+		var events []DrainEvent
+
+		testBuilder := buildValidNodeTestBuilder(testClient)
+		err := testBuilder.DrainWithOptions(DrainOptions{
+			Force:              true,
+			IgnoreDaemonSets:   true,
+			DeleteEmptyDirData: true,
+			Timeout:            time.Second,
+			Progress: func(event DrainEvent) {
+				events = append(events, event)
+			},
+		})
+
+		node, pullErr := Pull(testClient, defaultNodeName)
+		assert.Nil(t, pullErr)
+		assert.True(t, node.Object.Spec.Unschedulable)
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			blockedEvents := filterDrainEvents(events, DrainEventBlocked)
+			assert.NotEmpty(t, blockedEvents)
+			assert.Equal(t, "managed", blockedEvents[0].Pod.Name)
+			assert.NotNil(t, blockedEvents[0].PodDisruptionBudget)
+			assert.Equal(t, "test-pdb", blockedEvents[0].PodDisruptionBudget.Definition.Name)
+			assert.Len(t, filterDrainEvents(events, DrainEventEvicted), 3)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Len(t, filterDrainEvents(events, DrainEventEvicting), 4)
+		assert.Len(t, filterDrainEvents(events, DrainEventEvicted), 4)
+
+		remainingPods, err := testClient.CoreV1Interface.Pods("").List(t.Context(), metav1.ListOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, remainingPods.Items, 3)
+	}
+}
+
+func TestNodeDrainFailure(t *testing.T) {
+	testClient := buildTestClientWithDrainPods()
+	fakeClient, _ := testClient.K8sClient.(*k8sfake.Clientset)
+
+	fakeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		return true, nil, k8serrors.NewForbidden(corev1.Resource("pods"), "managed", nil)
+	})
+
+	var failedEvents []DrainEvent
+
+	err := buildValidNodeTestBuilder(testClient).DrainWithOptions(DrainOptions{
+		Force:              true,
+		IgnoreDaemonSets:   true,
+		DeleteEmptyDirData: true,
+		Timeout:            time.Second,
+		Progress: func(event DrainEvent) {
+			if event.Type == DrainEventFailed {
+				failedEvents = append(failedEvents, event)
+			}
+		},
+	})
+
+	assert.ErrorContains(t, err, "failed to evict pod test-namespace/")
+	assert.Len(t, failedEvents, 1)
+	assert.NotNil(t, failedEvents[0].Err)
+}
+
+func TestDrainOptionsFromHelper(t *testing.T) {
+	testBuilder := buildValidNodeTestBuilder(buildTestClientWithDummyNode())
+
+	testBuilder.SetDrainHelper(true, false, true, 30, 60, time.Minute)
+	options := drainOptionsFromHelper(testBuilder.drainHelper)
+
+	assert.True(t, options.Force)
+	assert.False(t, options.IgnoreDaemonSets)
+	assert.True(t, options.DeleteEmptyDirData)
+	assert.Equal(t, int64(30), *options.GracePeriodSeconds)
+	assert.Equal(t, 60, options.SkipWaitForDeleteTimeoutSeconds)
+	assert.Equal(t, time.Minute, options.Timeout)
+
+	testBuilder.SetDrainHelper(true, false, true, -1, 60, time.Minute)
+	assert.Nil(t, drainOptionsFromHelper(testBuilder.drainHelper).GracePeriodSeconds)
+}
+
+// addEvictionReactor makes the fake client in apiClient emulate the eviction subresource. Evicting a pod labeled
+// app=protected fails as if a PodDisruptionBudget blocked it, while other pods are deleted.
+func addEvictionReactor(apiClient *clients.Settings) {
+	fakeClient, _ := apiClient.K8sClient.(*k8sfake.Clientset)
+	podGVR := corev1.SchemeGroupVersion.WithResource("pods")
+
+	fakeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		createAction, _ := action.(k8stesting.CreateAction)
+		eviction, _ := createAction.GetObject().(*policyv1.Eviction)
+
+		object, err := fakeClient.Tracker().Get(podGVR, eviction.Namespace, eviction.Name)
+		if err != nil {
+			return true, nil, err
+		}
+
+		podObject, _ := object.(*corev1.Pod)
+		if podObject.Labels["app"] == drainTestProtectedLabel {
+			return true, nil, k8serrors.NewTooManyRequests(
+				"Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+
+		return true, nil, fakeClient.Tracker().Delete(podGVR, eviction.Namespace, eviction.Name)
+	})
+}
+
+func filterDrainEvents(events []DrainEvent, eventType DrainEventType) []DrainEvent {
+	var filtered []DrainEvent
+
+	for _, event := range events {
+		if event.Type == eventType {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered
+}
+
+func buildTestClientWithDrainPods() *clients.Settings {
+	isController := true
+	buildPod := func(name string, ownerKind string) *corev1.Pod {
+		podObject := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace", UID: types.UID("uid-" + name)},
+			Spec: corev1.PodSpec{
+				NodeName:   defaultNodeName,
+				Containers: []corev1.Container{{Name: "test"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+
+		if ownerKind != "" {
+			podObject.OwnerReferences = []metav1.OwnerReference{{
+				Kind: ownerKind, Name: "owner", Controller: &isController}}
+		}
+
+		return podObject
+	}
+
+	emptyDirPod := buildPod("emptydir", "ReplicaSet")
+	emptyDirPod.Spec.Volumes = []corev1.Volume{{
+		Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+
+	completedPod := buildPod("completed", "")
+	completedPod.Status.Phase = corev1.PodSucceeded
+
+	mirrorPod := buildPod("mirror", "Node")
+	mirrorPod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "mirror"}
+
+	otherNodePod := buildPod("other-node", "")
+	otherNodePod.Spec.NodeName = "other-node"
+
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{
+			buildDummyNode(defaultNodeName),
+			buildPod("managed", "ReplicaSet"),
+			buildPod("unmanaged", ""),
+			emptyDirPod,
+			buildPod("daemonset", "DaemonSet"),
+			completedPod,
+			mirrorPod,
+			otherNodePod,
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pdb", Namespace: "test-namespace"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": drainTestProtectedLabel}},
+				},
+			},
+		},
+	})
+}
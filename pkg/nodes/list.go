@@ -54,7 +54,7 @@ func List(apiClient *clients.Settings, options ...metav1.ListOptions) ([]*Builde
 	for _, runningNode := range nodeList.Items {
 		copiedNode := runningNode
 		nodeBuilder := &Builder{
-			apiClient:  apiClient.K8sClient,
+			apiClient:  apiClient,
 			Object:     &copiedNode,
 			Definition: &copiedNode,
 		}
@@ -3,14 +3,12 @@ package nodes
 import (
 	"context"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/client-go/kubernetes"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
@@ -30,7 +28,7 @@ const (
 type Builder struct {
 	Definition  *corev1.Node
 	Object      *corev1.Node
-	apiClient   kubernetes.Interface
+	apiClient   *clients.Settings
 	errorMsg    string
 	drainHelper *drain.Helper
 }
@@ -57,7 +55,7 @@ func (builder *Builder) SetDrainHelper(
 
 	builder.drainHelper = &drain.Helper{
 		Ctx:    logging.DiscardContext(),
-		Client: builder.apiClient,
+		Client: builder.apiClient.K8sClient,
 		// Delete pods that do not declare a controller.
 		Force: force,
 		// GracePeriodSeconds is how long to wait for a pod to terminate.
@@ -76,7 +74,8 @@ func (builder *Builder) SetDrainHelper(
 	}
 }
 
-// Drain evicts or deletes all pods.
+// Drain cordons the node and evicts its pods using the settings of the drain helper. Use DrainWithOptions to report
+// progress or to evict pods with other settings.
 func (builder *Builder) Drain() error {
 	if valid, err := builder.validate(); !valid {
 		return err
@@ -85,19 +84,7 @@ func (builder *Builder) Drain() error {
 	builder.ensureDrainHelperIsSet()
 	klog.V(100).Infof("Draining node %s", builder.Definition.Name)
 
-	// Workaround for kubernetes/kubernetes#135512. Set logging verbosity to 0 while draining to avoid logging the
-	// DaemonSet get requests at maximum verbosity.
-	if verbosity := flag.Lookup("v"); verbosity != nil {
-		old := verbosity.Value.String()
-
-		_ = verbosity.Value.Set("0")
-
-		defer func() {
-			_ = verbosity.Value.Set(old)
-		}()
-	}
-
-	return drain.RunNodeDrain(builder.drainHelper, builder.Definition.Name)
+	return builder.DrainWithOptions(drainOptionsFromHelper(builder.drainHelper))
 }
 
 // Cordon marks node as unschedulable.
@@ -138,7 +125,7 @@ func Pull(apiClient *clients.Settings, nodeName string) (*Builder, error) {
 	}
 
 	builder := Builder{
-		apiClient: apiClient.K8sClient,
+		apiClient: apiClient,
 		Definition: &corev1.Node{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: nodeName,
@@ -178,7 +165,7 @@ func (builder *Builder) Update() (*Builder, error) {
 
 	var err error
 
-	builder.Object, err = builder.apiClient.CoreV1().Nodes().Update(
+	builder.Object, err = builder.apiClient.CoreV1Interface.Nodes().Update(
 		logging.DiscardContext(), builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
@@ -194,7 +181,7 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 
-	builder.Object, err = builder.apiClient.CoreV1().Nodes().Get(
+	builder.Object, err = builder.apiClient.CoreV1Interface.Nodes().Get(
 		logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
@@ -216,7 +203,7 @@ func (builder *Builder) Delete() error {
 		return nil
 	}
 
-	err := builder.apiClient.CoreV1().Nodes().Delete(
+	err := builder.apiClient.CoreV1Interface.Nodes().Delete(
 		logging.DiscardContext(),
 		builder.Definition.Name,
 		metav1.DeleteOptions{})
@@ -395,7 +382,7 @@ func (builder *Builder) WaitForCondition(expected corev1.NodeCondition, timeout
 		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 
-			builder.Object, err = builder.apiClient.CoreV1().Nodes().Get(
+			builder.Object, err = builder.apiClient.CoreV1Interface.Nodes().Get(
 				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
 				klog.V(100).Infof("failed to get node %q, retrying: %v", builder.Definition.Name, err)
@@ -442,7 +429,7 @@ func (builder *Builder) WaitUntilNotReady(timeout time.Duration) error {
 		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 
-			builder.Object, err = builder.apiClient.CoreV1().Nodes().Get(
+			builder.Object, err = builder.apiClient.CoreV1Interface.Nodes().Get(
 				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
 				klog.V(100).Infof("failed to get node %q, retrying: %v", builder.Definition.Name, err)
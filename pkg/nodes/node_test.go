@@ -691,7 +691,7 @@ func newNodeBuilder(apiClient *clients.Settings, name string) *Builder {
 	}
 
 	builder := Builder{
-		apiClient:  apiClient.K8sClient,
+		apiClient:  apiClient,
 		Definition: buildDummyNode(name),
 	}
 
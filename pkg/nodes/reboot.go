@@ -0,0 +1,261 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// rebootPodTimeout is how long the host exec trigger waits for its privileged pod to start running.
+	rebootPodTimeout = 2 * time.Minute
+	// rebootDelaySeconds delays the reboot scheduled by the host exec trigger so the exec request can return and the
+	// pod can be removed before the node goes down.
+	rebootDelaySeconds = 5
+)
+
+// RebootTrigger starts the reboot of the node represented by the builder. It should return once the reboot has been
+// requested rather than waiting for it to complete, since Reboot tracks its progress through the node's boot ID.
+type RebootTrigger func(builder *Builder) error
+
+// RebootOptions controls how long Reboot waits for the node, and optionally its pods, to recover.
+type RebootOptions struct {
+	// Timeout is how long to wait after triggering the reboot for the boot ID to change and the node to be Ready.
+	Timeout time.Duration
+	// PodsHealthyTimeout, if not zero, is how long to wait after the node is Ready for all of its pods to be healthy.
+	PodsHealthyTimeout time.Duration
+}
+
+// NewHostExecRebootTrigger returns a RebootTrigger that reboots the node from the host. It runs a privileged pod
+// with the provided image in nsname on the node, uses it to schedule a systemctl reboot on the host a few seconds in
+// the future, and deletes the pod before returning. The image must provide nsenter.
+func NewHostExecRebootTrigger(nsname, image string) RebootTrigger {
+	return func(builder *Builder) error {
+		if valid, err := builder.validate(); !valid {
+			return err
+		}
+
+		nodeName := builder.Definition.Name
+
+		klog.V(100).Infof("Triggering reboot of node %s from a privileged pod in namespace %s", nodeName, nsname)
+
+		rebootPod, err := pod.NewBuilder(builder.apiClient, fmt.Sprintf("reboot-%s", nodeName), nsname, image).
+			DefineOnNode(nodeName).
+			WithPrivilegedFlag().
+			WithHostPid(true).
+			WithToleration(corev1.Toleration{Operator: corev1.TolerationOpExists}).
+			CreateAndWaitUntilRunning(rebootPodTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to run reboot pod on node %s: %w", nodeName, err)
+		}
+
+		_, execErr := rebootPod.ExecCommand([]string{
+			"nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--",
+			"systemd-run", fmt.Sprintf("--on-active=%d", rebootDelaySeconds), "systemctl", "reboot",
+		})
+
+		_, err = rebootPod.DeleteImmediate()
+		if err != nil {
+			klog.V(100).Infof("Failed to delete reboot pod on node %s: %v", nodeName, err)
+		}
+
+		if execErr != nil {
+			return fmt.Errorf("failed to schedule reboot on node %s: %w", nodeName, execErr)
+		}
+
+		return nil
+	}
+}
+
+// NewPowerCycleRebootTrigger returns a RebootTrigger that reboots the node by calling powerCycle, such as the
+// SystemPowerCycle method of a bmc.BMC connected to the node's BMC.
+func NewPowerCycleRebootTrigger(powerCycle func() error) RebootTrigger {
+	return func(builder *Builder) error {
+		if valid, err := builder.validate(); !valid {
+			return err
+		}
+
+		if powerCycle == nil {
+			return fmt.Errorf("cannot power cycle node %s with a nil powerCycle function", builder.Definition.Name)
+		}
+
+		klog.V(100).Infof("Triggering reboot of node %s by power cycling it", builder.Definition.Name)
+
+		return powerCycle()
+	}
+}
+
+// GetBootID returns the boot ID currently reported in the node's status. It changes every time the node reboots.
+func (builder *Builder) GetBootID() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	klog.V(100).Infof("Getting boot ID of node %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return "", fmt.Errorf("cannot get boot ID of node %s because it does not exist", builder.Definition.Name)
+	}
+
+	return builder.Object.Status.NodeInfo.BootID, nil
+}
+
+// Reboot reboots the node using trigger and waits until it reports a new boot ID and is Ready again. If
+// options.PodsHealthyTimeout is set, it then waits for all pods on the node to be healthy.
+func (builder *Builder) Reboot(trigger RebootTrigger, options RebootOptions) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Rebooting node %s with options %+v", builder.Definition.Name, options)
+
+	if trigger == nil {
+		return fmt.Errorf("cannot reboot node %s with a nil trigger", builder.Definition.Name)
+	}
+
+	bootID, err := builder.GetBootID()
+	if err != nil {
+		return err
+	}
+
+	if bootID == "" {
+		return fmt.Errorf("cannot reboot node %s because it does not report a boot ID", builder.Definition.Name)
+	}
+
+	err = trigger(builder)
+	if err != nil {
+		return fmt.Errorf("failed to trigger reboot of node %s: %w", builder.Definition.Name, err)
+	}
+
+	err = builder.WaitUntilRebooted(bootID, options.Timeout)
+	if err != nil {
+		return err
+	}
+
+	if options.PodsHealthyTimeout == 0 {
+		return nil
+	}
+
+	return builder.WaitUntilPodsHealthy(options.PodsHealthyTimeout)
+}
+
+// WaitUntilRebooted waits for up to timeout until the node reports a boot ID other than previousBootID and its Ready
+// condition is True. The boot ID is what proves the reboot happened, so a node that goes NotReady and back too
+// quickly for the transition to be observed still counts as rebooted.
+func (builder *Builder) WaitUntilRebooted(previousBootID string, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for up to %s until node %s reboots from boot ID %s",
+		timeout, builder.Definition.Name, previousBootID)
+
+	notReadySeen := false
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.CoreV1Interface.Nodes().Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("failed to get node %q, retrying: %v", builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			ready := isNodeObjectReady(builder.Object)
+			if !ready && !notReadySeen {
+				klog.V(100).Infof("Node %s is NotReady and is rebooting", builder.Definition.Name)
+
+				notReadySeen = true
+			}
+
+			return ready && builder.Object.Status.NodeInfo.BootID != previousBootID, nil
+		})
+	if err != nil {
+		return fmt.Errorf("node %s did not reboot and become Ready within %s: %w", builder.Definition.Name, timeout, err)
+	}
+
+	if !notReadySeen {
+		klog.V(100).Infof("Node %s rebooted without being observed NotReady", builder.Definition.Name)
+	}
+
+	return nil
+}
+
+// WaitUntilPodsHealthy waits for up to timeout until every pod scheduled to the node has succeeded or is running and
+// ready.
+func (builder *Builder) WaitUntilPodsHealthy(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for up to %s until all pods on node %s are healthy", timeout, builder.Definition.Name)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			nodePods, err := pod.ListInAllNamespaces(builder.apiClient, metav1.ListOptions{
+				FieldSelector: fmt.Sprintf("spec.nodeName=%s", builder.Definition.Name),
+			})
+			if err != nil {
+				klog.V(100).Infof("failed to list pods on node %q, retrying: %v", builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			for _, nodePod := range nodePods {
+				if nodePod.Object.Spec.NodeName != builder.Definition.Name {
+					continue
+				}
+
+				if !nodePod.IsHealthy() {
+					klog.V(100).Infof("Pod %s in namespace %s on node %s is not healthy yet",
+						nodePod.Object.Name, nodePod.Object.Namespace, builder.Definition.Name)
+
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+}
+
+// RollingReboot reboots the nodes one at a time, waiting for each to recover according to options before moving to
+// the next. It stops at the first node that fails to reboot or recover.
+func RollingReboot(nodeBuilders []*Builder, trigger RebootTrigger, options RebootOptions) error {
+	klog.V(100).Infof("Performing a rolling reboot of %d nodes", len(nodeBuilders))
+
+	for index, nodeBuilder := range nodeBuilders {
+		if valid, err := nodeBuilder.validate(); !valid {
+			return fmt.Errorf("cannot reboot node at index %d: %w", index, err)
+		}
+
+		err := nodeBuilder.Reboot(trigger, options)
+		if err != nil {
+			return fmt.Errorf("rolling reboot stopped at node %s: %w", nodeBuilder.Definition.Name, err)
+		}
+
+		klog.V(100).Infof("Node %s rebooted successfully (%d/%d)", nodeBuilder.Definition.Name, index+1, len(nodeBuilders))
+	}
+
+	return nil
+}
+
+// isNodeObjectReady returns true if the node has the Ready condition set to True.
+func isNodeObjectReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,255 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	oldBootID = "old-boot-id"
+	newBootID = "new-boot-id"
+)
+
+func TestNodeGetBootID(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError error
+	}{
+		{
+			exists: true,
+		},
+		{
+			exists:        false,
+			expectedError: fmt.Errorf("cannot get boot ID of node %s because it does not exist", defaultNodeName),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildRebootTestNode(defaultNodeName, oldBootID, true))
+		}
+
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: runtimeObjects,
+		}))
+
+		bootID, err := testBuilder.GetBootID()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, oldBootID, bootID)
+		}
+	}
+}
+
+func TestNodeReboot(t *testing.T) {
+	testCases := []struct {
+		bootID        string
+		trigger       RebootTrigger
+		expectedError error
+	}{
+		{
+			bootID:  oldBootID,
+			trigger: fakeRebootTrigger,
+		},
+		{
+			bootID:        oldBootID,
+			trigger:       nil,
+			expectedError: fmt.Errorf("cannot reboot node %s with a nil trigger", defaultNodeName),
+		},
+		{
+			bootID:        "",
+			trigger:       fakeRebootTrigger,
+			expectedError: fmt.Errorf("cannot reboot node %s because it does not report a boot ID", defaultNodeName),
+		},
+		{
+			bootID: oldBootID,
+			trigger: func(builder *Builder) error {
+				return fmt.Errorf("power cycle failed")
+			},
+			expectedError: fmt.Errorf("failed to trigger reboot of node %s: %w",
+				defaultNodeName, fmt.Errorf("power cycle failed")),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{buildRebootTestNode(defaultNodeName, testCase.bootID, true)},
+		}))
+
+		err := testBuilder.Reboot(testCase.trigger, RebootOptions{Timeout: time.Second})
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, newBootID, testBuilder.Object.Status.NodeInfo.BootID)
+		}
+	}
+}
+
+func TestNodeWaitUntilRebooted(t *testing.T) {
+	testCases := []struct {
+		bootID      string
+		ready       bool
+		expectError bool
+	}{
+		{
+			bootID:      newBootID,
+			ready:       true,
+			expectError: false,
+		},
+		{
+			bootID:      newBootID,
+			ready:       false,
+			expectError: true,
+		},
+		{
+			bootID:      oldBootID,
+			ready:       true,
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{buildRebootTestNode(defaultNodeName, testCase.bootID, testCase.ready)},
+		}))
+
+		err := testBuilder.WaitUntilRebooted(oldBootID, time.Second)
+		assert.Equal(t, testCase.expectError, err != nil)
+	}
+}
+
+func TestNodeWaitUntilPodsHealthy(t *testing.T) {
+	testCases := []struct {
+		podsOnNode  []runtime.Object
+		expectError bool
+	}{
+		{
+			podsOnNode: []runtime.Object{
+				buildRebootTestPod("healthy", defaultNodeName, corev1.PodRunning, true),
+				buildRebootTestPod("completed", defaultNodeName, corev1.PodSucceeded, false),
+				buildRebootTestPod("other-node", "other-node", corev1.PodPending, false),
+			},
+			expectError: false,
+		},
+		{
+			podsOnNode: []runtime.Object{
+				buildRebootTestPod("healthy", defaultNodeName, corev1.PodRunning, true),
+				buildRebootTestPod("not-ready", defaultNodeName, corev1.PodRunning, false),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := append([]runtime.Object{buildRebootTestNode(defaultNodeName, oldBootID, true)},
+			testCase.podsOnNode...)
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: runtimeObjects,
+		}))
+
+		err := testBuilder.WaitUntilPodsHealthy(time.Second)
+		assert.Equal(t, testCase.expectError, err != nil)
+	}
+}
+
+func TestRollingReboot(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{
+			buildRebootTestNode("first", oldBootID, true),
+			buildRebootTestNode("second", oldBootID, true),
+		},
+	})
+
+	var rebootOrder []string
+
+	trigger := func(builder *Builder) error {
+		rebootOrder = append(rebootOrder, builder.Definition.Name)
+
+		return fakeRebootTrigger(builder)
+	}
+
+	err := RollingReboot(
+		[]*Builder{newNodeBuilder(testSettings, "first"), newNodeBuilder(testSettings, "second")},
+		trigger, RebootOptions{Timeout: time.Second})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, rebootOrder)
+
+	rebootOrder = nil
+
+	err = RollingReboot(
+		[]*Builder{newNodeBuilder(testSettings, "missing"), newNodeBuilder(testSettings, "first")},
+		trigger, RebootOptions{Timeout: time.Second})
+	assert.Equal(t, fmt.Errorf("rolling reboot stopped at node missing: %w",
+		fmt.Errorf("cannot get boot ID of node missing because it does not exist")), err)
+	assert.Empty(t, rebootOrder)
+}
+
+func TestNewPowerCycleRebootTrigger(t *testing.T) {
+	testBuilder := buildValidNodeTestBuilder(buildTestClientWithDummyNode())
+
+	called := false
+	err := NewPowerCycleRebootTrigger(func() error {
+		called = true
+
+		return nil
+	})(testBuilder)
+	assert.Nil(t, err)
+	assert.True(t, called)
+
+	err = NewPowerCycleRebootTrigger(nil)(testBuilder)
+	assert.Equal(t, fmt.Errorf("cannot power cycle node %s with a nil powerCycle function", defaultNodeName), err)
+}
+
+// fakeRebootTrigger simulates a completed reboot by updating the boot ID of the node.
+func fakeRebootTrigger(builder *Builder) error {
+	node, err := builder.apiClient.CoreV1Interface.Nodes().Get(
+		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	node.Status.NodeInfo.BootID = newBootID
+
+	_, err = builder.apiClient.CoreV1Interface.Nodes().UpdateStatus(context.TODO(), node, metav1.UpdateOptions{})
+
+	return err
+}
+
+func buildRebootTestNode(name, bootID string, ready bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	node := buildDummyNodeWithCondition(name, corev1.NodeReady, status)
+	node.Status.NodeInfo.BootID = bootID
+
+	return node
+}
+
+func buildRebootTestPod(name, nodeName string, phase corev1.PodPhase, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Phase:      phase,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
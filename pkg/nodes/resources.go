@@ -0,0 +1,323 @@
+package nodes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	nrtv1alpha2 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/noderesourcetopology/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NUMAZoneType is the zone type used in NodeResourceTopology objects for NUMA nodes.
+const NUMAZoneType = "Node"
+
+// GetCapacity returns the total amount of each resource on the node, as reported in its status.
+func (builder *Builder) GetCapacity() (corev1.ResourceList, error) {
+	return builder.getStatusResources("capacity", func(node *corev1.Node) corev1.ResourceList {
+		return node.Status.Capacity
+	})
+}
+
+// GetAllocatable returns the amount of each resource on the node that is available to pods, as reported in its
+// status.
+func (builder *Builder) GetAllocatable() (corev1.ResourceList, error) {
+	return builder.getStatusResources("allocatable", func(node *corev1.Node) corev1.ResourceList {
+		return node.Status.Allocatable
+	})
+}
+
+// GetHugePagesCapacity returns the hugepages capacity of the node keyed by page size, such as 2Mi or 1Gi.
+func (builder *Builder) GetHugePagesCapacity() (map[string]resource.Quantity, error) {
+	capacity, err := builder.GetCapacity()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterHugePages(capacity), nil
+}
+
+// GetHugePagesAllocatable returns the allocatable hugepages of the node keyed by page size, such as 2Mi or 1Gi.
+func (builder *Builder) GetHugePagesAllocatable() (map[string]resource.Quantity, error) {
+	allocatable, err := builder.GetAllocatable()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterHugePages(allocatable), nil
+}
+
+// GetExtendedResourcesCapacity returns the capacity of the extended resources on the node, such as the
+// openshift.io/<resource> devices advertised by the SR-IOV device plugin.
+func (builder *Builder) GetExtendedResourcesCapacity() (corev1.ResourceList, error) {
+	capacity, err := builder.GetCapacity()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterExtendedResources(capacity), nil
+}
+
+// GetExtendedResourcesAllocatable returns the allocatable extended resources on the node, such as the
+// openshift.io/<resource> devices advertised by the SR-IOV device plugin.
+func (builder *Builder) GetExtendedResourcesAllocatable() (corev1.ResourceList, error) {
+	allocatable, err := builder.GetAllocatable()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterExtendedResources(allocatable), nil
+}
+
+// GetAvailable returns the allocatable resources of the node minus the requests of the pods on it that have not
+// terminated. This is the amount the scheduler considers free for new pods.
+func (builder *Builder) GetAvailable() (corev1.ResourceList, error) {
+	allocatable, err := builder.GetAllocatable()
+	if err != nil {
+		return nil, err
+	}
+
+	nodePods, err := pod.ListInAllNamespaces(builder.apiClient, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", builder.Definition.Name),
+	})
+	if err != nil {
+		klog.V(100).Infof("Failed to list pods on node %s: %v", builder.Definition.Name, err)
+
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", builder.Definition.Name, err)
+	}
+
+	available := allocatable.DeepCopy()
+
+	for _, nodePod := range nodePods {
+		if nodePod.Object.Spec.NodeName != builder.Definition.Name ||
+			nodePod.Object.Status.Phase == corev1.PodSucceeded || nodePod.Object.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		for name, quantity := range podRequests(nodePod.Object) {
+			if remaining, ok := available[name]; ok {
+				remaining.Sub(quantity)
+				available[name] = remaining
+			}
+		}
+	}
+
+	return available, nil
+}
+
+// CanFit returns true if every resource in requests is available on the node, as computed by GetAvailable. Resources
+// the node does not advertise never fit.
+func (builder *Builder) CanFit(requests corev1.ResourceList) (bool, error) {
+	available, err := builder.GetAvailable()
+	if err != nil {
+		return false, err
+	}
+
+	return resourcesFit(requests, available), nil
+}
+
+// GetNodeResourceTopology returns the NodeResourceTopology object that the NUMA resources operator publishes for the
+// node. It has the same name as the node.
+func (builder *Builder) GetNodeResourceTopology() (*nrtv1alpha2.NodeResourceTopology, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting NodeResourceTopology of node %s", builder.Definition.Name)
+
+	err := builder.apiClient.AttachScheme(nrtv1alpha2.AddToScheme)
+	if err != nil {
+		klog.V(100).Info("Failed to add NodeResourceTopology scheme to client schemes")
+
+		return nil, err
+	}
+
+	topology := &nrtv1alpha2.NodeResourceTopology{}
+
+	err = builder.apiClient.Client.Get(
+		logging.DiscardContext(), runtimeclient.ObjectKey{Name: builder.Definition.Name}, topology)
+	if err != nil {
+		klog.V(100).Infof("Failed to get NodeResourceTopology of node %s: %v", builder.Definition.Name, err)
+
+		return nil, fmt.Errorf("failed to get NodeResourceTopology of node %s: %w", builder.Definition.Name, err)
+	}
+
+	return topology, nil
+}
+
+// GetNUMAZones returns the NUMA zones of the node from its NodeResourceTopology, including the capacity, allocatable
+// and available amount of each resource per zone.
+func (builder *Builder) GetNUMAZones() ([]nrtv1alpha2.Zone, error) {
+	topology, err := builder.GetNodeResourceTopology()
+	if err != nil {
+		return nil, err
+	}
+
+	var numaZones []nrtv1alpha2.Zone
+
+	for _, zone := range topology.Zones {
+		if zone.Type == NUMAZoneType {
+			numaZones = append(numaZones, zone)
+		}
+	}
+
+	return numaZones, nil
+}
+
+// GetNUMAZonesThatFit returns the names of the NUMA zones whose available resources cover requests, which is where
+// a guaranteed pod can be admitted under the single-numa-node topology manager policy. Resources that zones do not
+// report, such as ephemeral-storage, are not checked here and should be checked with CanFit.
+func (builder *Builder) GetNUMAZonesThatFit(requests corev1.ResourceList) ([]string, error) {
+	numaZones, err := builder.GetNUMAZones()
+	if err != nil {
+		return nil, err
+	}
+
+	var fittingZones []string
+
+	for _, zone := range numaZones {
+		zoneAvailable := corev1.ResourceList{}
+
+		for _, resourceInfo := range zone.Resources {
+			zoneAvailable[corev1.ResourceName(resourceInfo.Name)] = resourceInfo.Available
+		}
+
+		zoneRequests := corev1.ResourceList{}
+
+		for name, quantity := range requests {
+			if _, ok := zoneAvailable[name]; ok {
+				zoneRequests[name] = quantity
+			}
+		}
+
+		if resourcesFit(zoneRequests, zoneAvailable) {
+			fittingZones = append(fittingZones, zone.Name)
+		}
+	}
+
+	return fittingZones, nil
+}
+
+// getStatusResources refreshes the node object and returns the resource list selected from it.
+func (builder *Builder) getStatusResources(
+	kind string, selector func(node *corev1.Node) corev1.ResourceList) (corev1.ResourceList, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting %s of node %s", kind, builder.Definition.Name)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get %s of node %s because it does not exist", kind, builder.Definition.Name)
+	}
+
+	return selector(builder.Object).DeepCopy(), nil
+}
+
+// filterHugePages returns the hugepages resources in resources keyed by page size.
+func filterHugePages(resources corev1.ResourceList) map[string]resource.Quantity {
+	hugePages := make(map[string]resource.Quantity)
+
+	for name, quantity := range resources {
+		if pageSize, found := strings.CutPrefix(string(name), corev1.ResourceHugePagesPrefix); found {
+			hugePages[pageSize] = quantity
+		}
+	}
+
+	return hugePages
+}
+
+// filterExtendedResources returns the resources in resources that are not native to Kubernetes. Native resources
+// either have no domain or use the kubernetes.io domain.
+func filterExtendedResources(resources corev1.ResourceList) corev1.ResourceList {
+	extended := corev1.ResourceList{}
+
+	for name, quantity := range resources {
+		if strings.Contains(string(name), "/") && !strings.Contains(string(name), corev1.ResourceDefaultNamespacePrefix) {
+			extended[name] = quantity
+		}
+	}
+
+	return extended
+}
+
+// resourcesFit returns true if available has at least the requested amount of every resource in requests.
+func resourcesFit(requests, available corev1.ResourceList) bool {
+	for name, quantity := range requests {
+		remaining, ok := available[name]
+		if !ok {
+			if quantity.IsZero() {
+				continue
+			}
+
+			return false
+		}
+
+		if remaining.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// podRequests returns the effective resource requests of the pod the way the scheduler computes them: the sum of
+// the app and sidecar containers, or the largest init container step if that is greater, plus the pod overhead.
+func podRequests(podObject *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+
+	for _, container := range podObject.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+
+	sidecarRequests := corev1.ResourceList{}
+	initRequests := corev1.ResourceList{}
+
+	for _, container := range podObject.Spec.InitContainers {
+		stepRequests := container.Resources.Requests.DeepCopy()
+
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addResources(sidecarRequests, container.Resources.Requests)
+
+			stepRequests = sidecarRequests.DeepCopy()
+		} else {
+			addResources(stepRequests, sidecarRequests)
+		}
+
+		maxResources(initRequests, stepRequests)
+	}
+
+	addResources(requests, sidecarRequests)
+	maxResources(requests, initRequests)
+	addResources(requests, podObject.Spec.Overhead)
+
+	return requests
+}
+
+// addResources adds every quantity in resources to total.
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		if current, ok := total[name]; ok {
+			current.Add(quantity)
+			total[name] = current
+
+			continue
+		}
+
+		total[name] = quantity.DeepCopy()
+	}
+}
+
+// maxResources raises every quantity in total to at least the matching quantity in resources.
+func maxResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		if current, ok := total[name]; !ok || current.Cmp(quantity) < 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package nodes
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	nrtv1alpha2 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/noderesourcetopology/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultSriovResource corev1.ResourceName = "openshift.io/sriovnic"
+
+func TestNodeGetCapacityAndAllocatable(t *testing.T) {
+	testCases := []struct {
+		nodeExists    bool
+		expectedError string
+	}{
+		{
+			nodeExists:    true,
+			expectedError: "",
+		},
+		{
+			nodeExists:    false,
+			expectedError: "cannot get capacity of node test-node because it does not exist",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.nodeExists {
+			runtimeObjects = append(runtimeObjects, buildResourcesTestNode())
+		}
+
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: runtimeObjects,
+		}))
+
+		capacity, err := testBuilder.GetCapacity()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(64), capacity.Cpu().Value())
+
+		allocatable, err := testBuilder.GetAllocatable()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(62), allocatable.Cpu().Value())
+
+		hugePages, err := testBuilder.GetHugePagesCapacity()
+		assert.Nil(t, err)
+		assert.Len(t, hugePages, 2)
+		assert.Equal(t, resource.MustParse("8Gi"), hugePages["1Gi"])
+
+		hugePages, err = testBuilder.GetHugePagesAllocatable()
+		assert.Nil(t, err)
+		assert.Equal(t, resource.MustParse("4Gi"), hugePages["1Gi"])
+
+		extended, err := testBuilder.GetExtendedResourcesAllocatable()
+		assert.Nil(t, err)
+		assert.Equal(t, corev1.ResourceList{defaultSriovResource: resource.MustParse("4")}, extended)
+
+		extended, err = testBuilder.GetExtendedResourcesCapacity()
+		assert.Nil(t, err)
+		assert.Len(t, extended, 1)
+	}
+}
+
+func TestNodeGetAvailable(t *testing.T) {
+	sidecarPolicy := corev1.ContainerRestartPolicyAlways
+
+	runningPod := buildResourcesTestPod("running", defaultNodeName, corev1.PodRunning)
+	runningPod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+		defaultSriovResource:  resource.MustParse("1"),
+	}
+	runningPod.Spec.InitContainers = []corev1.Container{
+		{
+			Name:          "sidecar",
+			Resources:     corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+			RestartPolicy: &sidecarPolicy,
+		},
+		{
+			Name:      "init",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}},
+		},
+	}
+
+	completedPod := buildResourcesTestPod("completed", defaultNodeName, corev1.PodSucceeded)
+	completedPod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")}
+
+	otherNodePod := buildResourcesTestPod("other", "other-node", corev1.PodRunning)
+	otherNodePod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")}
+
+	testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildResourcesTestNode(), runningPod, completedPod, otherNodePod},
+	}))
+
+	available, err := testBuilder.GetAvailable()
+	assert.Nil(t, err)
+	// The init container needs 10 CPUs plus the 1 CPU sidecar started before it, which exceeds the 5 CPUs of the
+	// app and sidecar containers.
+	assert.Equal(t, int64(51), available.Cpu().Value())
+	assert.Equal(t, int64(112<<30), available.Memory().Value())
+	assert.Equal(t, int64(3), available.Name(defaultSriovResource, resource.DecimalSI).Value())
+
+	testCases := []struct {
+		requests corev1.ResourceList
+		fits     bool
+	}{
+		{
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:   resource.MustParse("51"),
+				defaultSriovResource: resource.MustParse("3"),
+			},
+			fits: true,
+		},
+		{
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("52")},
+			fits:     false,
+		},
+		{
+			requests: corev1.ResourceList{"openshift.io/missing": resource.MustParse("1")},
+			fits:     false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fits, err := testBuilder.CanFit(testCase.requests)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.fits, fits)
+	}
+}
+
+func TestNodeGetNUMAZones(t *testing.T) {
+	testCases := []struct {
+		topologyExists bool
+		expectedError  string
+	}{
+		{
+			topologyExists: true,
+			expectedError:  "",
+		},
+		{
+			topologyExists: false,
+			expectedError:  "failed to get NodeResourceTopology of node test-node",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildResourcesTestNode()}
+
+		if testCase.topologyExists {
+			runtimeObjects = append(runtimeObjects, buildDummyNodeResourceTopology())
+		}
+
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects:  runtimeObjects,
+			SchemeAttachers: []clients.SchemeAttacher{nrtv1alpha2.AddToScheme},
+		}))
+
+		numaZones, err := testBuilder.GetNUMAZones()
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Len(t, numaZones, 2)
+		assert.Equal(t, "node-0", numaZones[0].Name)
+	}
+}
+
+func TestNodeGetNUMAZonesThatFit(t *testing.T) {
+	testCases := []struct {
+		requests      corev1.ResourceList
+		expectedZones []string
+	}{
+		{
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("20"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+			},
+			expectedZones: []string{"node-0", "node-1"},
+		},
+		{
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:   resource.MustParse("20"),
+				defaultSriovResource: resource.MustParse("2"),
+			},
+			expectedZones: []string{"node-1"},
+		},
+		{
+			requests:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("40")},
+			expectedZones: nil,
+		},
+	}
+
+	testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects:  []runtime.Object{buildResourcesTestNode(), buildDummyNodeResourceTopology()},
+		SchemeAttachers: []clients.SchemeAttacher{nrtv1alpha2.AddToScheme},
+	}))
+
+	for _, testCase := range testCases {
+		fittingZones, err := testBuilder.GetNUMAZonesThatFit(testCase.requests)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedZones, fittingZones)
+	}
+}
+
+func buildResourcesTestNode() *corev1.Node {
+	node := buildDummyNode(defaultNodeName)
+	node.Status.Capacity = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("64"),
+		corev1.ResourceMemory: resource.MustParse("128Gi"),
+		"hugepages-1Gi":       resource.MustParse("8Gi"),
+		"hugepages-2Mi":       resource.MustParse("0"),
+		defaultSriovResource:  resource.MustParse("4"),
+	}
+	node.Status.Allocatable = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("62"),
+		corev1.ResourceMemory: resource.MustParse("120Gi"),
+		"hugepages-1Gi":       resource.MustParse("4Gi"),
+		"hugepages-2Mi":       resource.MustParse("0"),
+		defaultSriovResource:  resource.MustParse("4"),
+	}
+
+	return node
+}
+
+func buildResourcesTestPod(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []corev1.Container{{Name: "test"}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func buildDummyNodeResourceTopology() *nrtv1alpha2.NodeResourceTopology {
+	buildZone := func(name string, cpus, devices string) nrtv1alpha2.Zone {
+		return nrtv1alpha2.Zone{
+			Name: name,
+			Type: NUMAZoneType,
+			Resources: nrtv1alpha2.ResourceInfoList{
+				{
+					Name:        string(corev1.ResourceCPU),
+					Capacity:    resource.MustParse("32"),
+					Allocatable: resource.MustParse("31"),
+					Available:   resource.MustParse(cpus),
+				},
+				{
+					Name:        string(defaultSriovResource),
+					Capacity:    resource.MustParse("2"),
+					Allocatable: resource.MustParse("2"),
+					Available:   resource.MustParse(devices),
+				},
+			},
+		}
+	}
+
+	return &nrtv1alpha2.NodeResourceTopology{
+		ObjectMeta:       metav1.ObjectMeta{Name: defaultNodeName},
+		TopologyPolicies: []string{"SingleNUMANodeContainerLevel"},
+		Zones: nrtv1alpha2.ZoneList{
+			buildZone("node-0", "30", "1"),
+			buildZone("node-1", "24", "2"),
+			{Name: "socket-0", Type: "Socket"},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// validTaintEffects contains the effects accepted by the API server for node taints.
+var validTaintEffects = []corev1.TaintEffect{
+	corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute}
+
+// WithTaint adds the taint to the node definition. A taint with the same key and effect is replaced. The change is
+// applied to the cluster by calling Update.
+func (builder *Builder) WithTaint(taint corev1.Taint) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding taint %s to node %s", taint.ToString(), builder.Definition.Name)
+
+	if taint.Key == "" {
+		klog.V(100).Infof("Failed to apply taint with an empty key to node %s", builder.Definition.Name)
+
+		builder.errorMsg = "cannot apply taint with an empty key to node"
+
+		return builder
+	}
+
+	if !slices.Contains(validTaintEffects, taint.Effect) {
+		klog.V(100).Infof("Failed to apply taint with invalid effect %s to node %s", taint.Effect, builder.Definition.Name)
+
+		builder.errorMsg = fmt.Sprintf("cannot apply taint with invalid effect %q to node", taint.Effect)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Taints = slices.DeleteFunc(builder.Definition.Spec.Taints, func(existing corev1.Taint) bool {
+		return existing.MatchTaint(&taint)
+	})
+	builder.Definition.Spec.Taints = append(builder.Definition.Spec.Taints, taint)
+
+	return builder
+}
+
+// RemoveTaint removes the taints with the given key and effect from the node definition. If effect is empty, taints
+// with the key are removed regardless of their effect. The change is applied to the cluster by calling Update.
+func (builder *Builder) RemoveTaint(key string, effect corev1.TaintEffect) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Removing taint %s:%s from node %s", key, effect, builder.Definition.Name)
+
+	if key == "" {
+		klog.V(100).Infof("Failed to remove taint with an empty key from node %s", builder.Definition.Name)
+
+		builder.errorMsg = "cannot remove taint with an empty key from node"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Taints = slices.DeleteFunc(builder.Definition.Spec.Taints, func(existing corev1.Taint) bool {
+		return taintMatches(existing, key, effect)
+	})
+
+	return builder
+}
+
+// HasTaint returns true if the node object has a taint with the given key and effect. If effect is empty, any taint
+// with the key matches. It returns false if the node does not exist.
+func (builder *Builder) HasTaint(key string, effect corev1.TaintEffect) bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	klog.V(100).Infof("Checking if node %s has taint %s:%s", builder.Definition.Name, key, effect)
+
+	if !builder.Exists() {
+		return false
+	}
+
+	return hasTaint(builder.Object, key, effect)
+}
+
+// WaitUntilTainted waits for up to timeout until the node has a taint with the given key and effect. If effect is
+// empty, any taint with the key matches.
+func (builder *Builder) WaitUntilTainted(key string, effect corev1.TaintEffect, timeout time.Duration) error {
+	return builder.waitForTaint(key, effect, true, timeout)
+}
+
+// WaitUntilUntainted waits for up to timeout until the node has no taint with the given key and effect. If effect is
+// empty, no taint with the key may remain regardless of its effect.
+func (builder *Builder) WaitUntilUntainted(key string, effect corev1.TaintEffect, timeout time.Duration) error {
+	return builder.waitForTaint(key, effect, false, timeout)
+}
+
+// ListWithTaint returns the nodes that have a taint with the given key and effect. If effect is empty, any taint
+// with the key matches. Taints cannot be selected server side, so the nodes returned by List are filtered locally.
+func ListWithTaint(
+	apiClient *clients.Settings,
+	key string,
+	effect corev1.TaintEffect,
+	options ...metav1.ListOptions) ([]*Builder, error) {
+	klog.V(100).Infof("Listing nodes with taint %s:%s", key, effect)
+
+	if key == "" {
+		klog.V(100).Info("The taint key is empty")
+
+		return nil, fmt.Errorf("failed to list nodes by taint, 'key' parameter is empty")
+	}
+
+	nodeBuilders, err := List(apiClient, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var taintedNodes []*Builder
+
+	for _, nodeBuilder := range nodeBuilders {
+		if hasTaint(nodeBuilder.Object, key, effect) {
+			taintedNodes = append(taintedNodes, nodeBuilder)
+		}
+	}
+
+	return taintedNodes, nil
+}
+
+// waitForTaint polls the node until the presence of the taint matches tainted.
+func (builder *Builder) waitForTaint(key string, effect corev1.TaintEffect, tainted bool, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for up to %s until node %s has taint %s:%s set to %t",
+		timeout, builder.Definition.Name, key, effect, tainted)
+
+	if key == "" {
+		return fmt.Errorf("cannot wait for taint with an empty key on node %s", builder.Definition.Name)
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.CoreV1Interface.Nodes().Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("failed to get node %q, retrying: %v", builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			return hasTaint(builder.Object, key, effect) == tainted, nil
+		})
+}
+
+// hasTaint returns true if the node has a taint matching the key and effect.
+func hasTaint(node *corev1.Node, key string, effect corev1.TaintEffect) bool {
+	if node == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(node.Spec.Taints, func(taint corev1.Taint) bool {
+		return taintMatches(taint, key, effect)
+	})
+}
+
+// taintMatches returns true if the taint has the key and, when effect is not empty, the effect.
+func taintMatches(taint corev1.Taint, key string, effect corev1.TaintEffect) bool {
+	return taint.Key == key && (effect == "" || taint.Effect == effect)
+}
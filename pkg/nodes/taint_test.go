@@ -0,0 +1,224 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var testTaint = corev1.Taint{Key: "test-key", Value: "test-value", Effect: corev1.TaintEffectNoExecute}
+
+func TestNodeWithTaint(t *testing.T) {
+	testCases := []struct {
+		taint          corev1.Taint
+		existingTaints []corev1.Taint
+		expectedTaints []corev1.Taint
+		expectedError  string
+	}{
+		{
+			taint:          testTaint,
+			expectedTaints: []corev1.Taint{testTaint},
+		},
+		{
+			taint: testTaint,
+			existingTaints: []corev1.Taint{
+				{Key: "test-key", Value: "old-value", Effect: corev1.TaintEffectNoExecute},
+				{Key: "test-key", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expectedTaints: []corev1.Taint{{Key: "test-key", Effect: corev1.TaintEffectNoSchedule}, testTaint},
+		},
+		{
+			taint:         corev1.Taint{Effect: corev1.TaintEffectNoSchedule},
+			expectedError: "cannot apply taint with an empty key to node",
+		},
+		{
+			taint:         corev1.Taint{Key: "test-key", Effect: "Invalid"},
+			expectedError: "cannot apply taint with invalid effect \"Invalid\" to node",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNodeTestBuilder(buildTestClientWithDummyNode())
+		testBuilder.Definition.Spec.Taints = testCase.existingTaints
+
+		testBuilder = testBuilder.WithTaint(testCase.taint)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.expectedTaints, testBuilder.Definition.Spec.Taints)
+		}
+	}
+}
+
+func TestNodeRemoveTaint(t *testing.T) {
+	existingTaints := []corev1.Taint{
+		{Key: "test-key", Effect: corev1.TaintEffectNoExecute},
+		{Key: "test-key", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "other-key", Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	testCases := []struct {
+		key            string
+		effect         corev1.TaintEffect
+		expectedTaints []corev1.Taint
+		expectedError  string
+	}{
+		{
+			key:            "test-key",
+			effect:         corev1.TaintEffectNoExecute,
+			expectedTaints: existingTaints[1:],
+		},
+		{
+			key:            "test-key",
+			effect:         "",
+			expectedTaints: existingTaints[2:],
+		},
+		{
+			key:           "",
+			effect:        corev1.TaintEffectNoExecute,
+			expectedError: "cannot remove taint with an empty key from node",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNodeTestBuilder(buildTestClientWithDummyNode())
+		testBuilder.Definition.Spec.Taints = append([]corev1.Taint{}, existingTaints...)
+
+		testBuilder = testBuilder.RemoveTaint(testCase.key, testCase.effect)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.expectedTaints, testBuilder.Definition.Spec.Taints)
+		}
+	}
+}
+
+func TestNodeWaitUntilTainted(t *testing.T) {
+	testCases := []struct {
+		tainted       bool
+		waitTainted   bool
+		effect        corev1.TaintEffect
+		expectedError error
+	}{
+		{
+			tainted:       true,
+			waitTainted:   true,
+			effect:        corev1.TaintEffectNoExecute,
+			expectedError: nil,
+		},
+		{
+			tainted:       true,
+			waitTainted:   true,
+			effect:        "",
+			expectedError: nil,
+		},
+		{
+			tainted:       false,
+			waitTainted:   true,
+			effect:        corev1.TaintEffectNoExecute,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			tainted:       false,
+			waitTainted:   false,
+			effect:        corev1.TaintEffectNoExecute,
+			expectedError: nil,
+		},
+		{
+			tainted:       true,
+			waitTainted:   false,
+			effect:        corev1.TaintEffectNoSchedule,
+			expectedError: nil,
+		},
+		{
+			tainted:       true,
+			waitTainted:   false,
+			effect:        corev1.TaintEffectNoExecute,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNodeTestBuilder(buildTestClientWithTaintedNodes(testCase.tainted))
+
+		var err error
+		if testCase.waitTainted {
+			err = testBuilder.WaitUntilTainted(testTaint.Key, testCase.effect, time.Second)
+		} else {
+			err = testBuilder.WaitUntilUntainted(testTaint.Key, testCase.effect, time.Second)
+		}
+
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.tainted, testBuilder.HasTaint(testTaint.Key, testTaint.Effect))
+	}
+}
+
+func TestListWithTaint(t *testing.T) {
+	testCases := []struct {
+		key           string
+		effect        corev1.TaintEffect
+		client        bool
+		expectedNodes []string
+		expectedError error
+	}{
+		{
+			key:           testTaint.Key,
+			effect:        testTaint.Effect,
+			client:        true,
+			expectedNodes: []string{defaultNodeName},
+		},
+		{
+			key:           testTaint.Key,
+			effect:        corev1.TaintEffectNoSchedule,
+			client:        true,
+			expectedNodes: nil,
+		},
+		{
+			key:           "",
+			client:        true,
+			expectedError: fmt.Errorf("failed to list nodes by taint, 'key' parameter is empty"),
+		},
+		{
+			key:           testTaint.Key,
+			client:        false,
+			expectedError: fmt.Errorf("failed to list node objects, 'apiClient' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = buildTestClientWithTaintedNodes(true)
+		}
+
+		nodeBuilders, err := ListWithTaint(testSettings, testCase.key, testCase.effect)
+		assert.Equal(t, testCase.expectedError, err)
+
+		var nodeNames []string
+		for _, nodeBuilder := range nodeBuilders {
+			nodeNames = append(nodeNames, nodeBuilder.Definition.Name)
+		}
+
+		assert.Equal(t, testCase.expectedNodes, nodeNames)
+	}
+}
+
+// buildTestClientWithTaintedNodes returns a client with the default node, optionally tainted with testTaint, and an
+// untainted node.
+func buildTestClientWithTaintedNodes(tainted bool) *clients.Settings {
+	node := buildDummyNode(defaultNodeName)
+	if tainted {
+		node.Spec.Taints = []corev1.Taint{testTaint}
+	}
+
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{node, buildDummyNode("untainted-node")},
+	})
+}
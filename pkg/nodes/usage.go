@@ -0,0 +1,33 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	metricsv1beta1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/metrics/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetUsage returns the current CPU and memory usage of the node as reported by the metrics.k8s.io API. The memory
+// usage is the working set of the node.
+func (builder *Builder) GetUsage() (corev1.ResourceList, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting resource usage of node %s", builder.Definition.Name)
+
+	nodeMetrics := &metricsv1beta1.NodeMetrics{}
+
+	err := builder.apiClient.Client.Get(
+		logging.DiscardContext(), runtimeclient.ObjectKey{Name: builder.Definition.Name}, nodeMetrics)
+	if err != nil {
+		klog.V(100).Infof("Failed to get metrics of node %s: %v", builder.Definition.Name, err)
+
+		return nil, fmt.Errorf("failed to get metrics of node %s: %w", builder.Definition.Name, err)
+	}
+
+	return nodeMetrics.Usage, nil
+}
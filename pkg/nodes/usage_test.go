@@ -0,0 +1,59 @@
+package nodes
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	metricsv1beta1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/metrics/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNodeGetUsage(t *testing.T) {
+	testCases := []struct {
+		metricsExist  bool
+		expectedError string
+	}{
+		{
+			metricsExist:  true,
+			expectedError: "",
+		},
+		{
+			metricsExist:  false,
+			expectedError: "failed to get metrics of node test-node",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyNode(defaultNodeName)}
+
+		if testCase.metricsExist {
+			runtimeObjects = append(runtimeObjects, &metricsv1beta1.NodeMetrics{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultNodeName},
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1500m"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			})
+		}
+
+		testBuilder := buildValidNodeTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: runtimeObjects,
+		}))
+
+		usage, err := testBuilder.GetUsage()
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1500), usage.Cpu().MilliValue())
+		assert.Equal(t, int64(4<<30), usage.Memory().Value())
+	}
+}
@@ -432,6 +432,324 @@ func (builder *ContainerBuilder) WithReadinessProbe(readinessProbe *corev1.Probe
 	return builder
 }
 
+// WithLivenessProbe adds a livenessProbe to the container.
+func (builder *ContainerBuilder) WithLivenessProbe(livenessProbe *corev1.Probe) *ContainerBuilder {
+	klog.V(100).Infof("Adding livenessProbe to the %s container's definition", builder.definition.Name)
+
+	if err := validateProbe("livenessProbe", livenessProbe); err != nil {
+		klog.V(100).Infof("Container's livenessProbe is invalid: %v", err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.LivenessProbe = livenessProbe
+
+	return builder
+}
+
+// WithStartupProbe adds a startupProbe to the container.
+func (builder *ContainerBuilder) WithStartupProbe(startupProbe *corev1.Probe) *ContainerBuilder {
+	klog.V(100).Infof("Adding startupProbe to the %s container's definition", builder.definition.Name)
+
+	if err := validateProbe("startupProbe", startupProbe); err != nil {
+		klog.V(100).Infof("Container's startupProbe is invalid: %v", err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.StartupProbe = startupProbe
+
+	return builder
+}
+
+// WithArgs sets the arguments passed to the container's command.
+func (builder *ContainerBuilder) WithArgs(args []string) *ContainerBuilder {
+	klog.V(100).Infof("Applying args %v to the %s container's definition", args, builder.definition.Name)
+
+	if len(args) == 0 {
+		klog.V(100).Info("Container's args are empty")
+
+		builder.errorMsg = "container's args are empty"
+
+		return builder
+	}
+
+	builder.definition.Args = args
+
+	return builder
+}
+
+// WithWorkingDir sets the working directory of the container.
+func (builder *ContainerBuilder) WithWorkingDir(workingDir string) *ContainerBuilder {
+	klog.V(100).Infof("Applying workingDir %s to the %s container's definition", workingDir, builder.definition.Name)
+
+	if workingDir == "" {
+		klog.V(100).Info("Container's workingDir is empty")
+
+		builder.errorMsg = "container's workingDir is empty"
+
+		return builder
+	}
+
+	builder.definition.WorkingDir = workingDir
+
+	return builder
+}
+
+// WithEnvFromConfigMap exposes all keys of the ConfigMap as environment variables in the container.
+func (builder *ContainerBuilder) WithEnvFromConfigMap(configMapName string) *ContainerBuilder {
+	klog.V(100).Infof("Adding envFrom configMap %s to the %s container's definition",
+		configMapName, builder.definition.Name)
+
+	if configMapName == "" {
+		klog.V(100).Info("Container's envFrom configMap name is empty")
+
+		builder.errorMsg = "container's envFrom configMap name is empty"
+
+		return builder
+	}
+
+	builder.definition.EnvFrom = append(builder.definition.EnvFrom, corev1.EnvFromSource{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: configMapName}},
+	})
+
+	return builder
+}
+
+// WithEnvFromSecret exposes all keys of the Secret as environment variables in the container.
+func (builder *ContainerBuilder) WithEnvFromSecret(secretName string) *ContainerBuilder {
+	klog.V(100).Infof("Adding envFrom secret %s to the %s container's definition", secretName, builder.definition.Name)
+
+	if secretName == "" {
+		klog.V(100).Info("Container's envFrom secret name is empty")
+
+		builder.errorMsg = "container's envFrom secret name is empty"
+
+		return builder
+	}
+
+	builder.definition.EnvFrom = append(builder.definition.EnvFrom, corev1.EnvFromSource{
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}},
+	})
+
+	return builder
+}
+
+// WithEnvVarFromSource adds an environment variable whose value is taken from the provided source.
+func (builder *ContainerBuilder) WithEnvVarFromSource(name string, valueFrom *corev1.EnvVarSource) *ContainerBuilder {
+	klog.V(100).Infof("Applying environment variable %s from source %v to container", name, valueFrom)
+
+	if name == "" {
+		klog.V(100).Info("Container's environment var 'name' is empty")
+
+		builder.errorMsg = "container's environment var 'name' is empty"
+
+		return builder
+	}
+
+	if valueFrom == nil {
+		klog.V(100).Info("Container's environment var 'valueFrom' is empty")
+
+		builder.errorMsg = "container's environment var 'valueFrom' is empty"
+
+		return builder
+	}
+
+	builder.definition.Env = append(builder.definition.Env, corev1.EnvVar{Name: name, ValueFrom: valueFrom})
+
+	return builder
+}
+
+// WithEnvVarFromField adds an environment variable populated through the downward API from a pod field, for example
+// metadata.name, spec.nodeName or status.podIP.
+func (builder *ContainerBuilder) WithEnvVarFromField(name, fieldPath string) *ContainerBuilder {
+	if fieldPath == "" {
+		klog.V(100).Info("Container's environment var 'fieldPath' is empty")
+
+		builder.errorMsg = "container's environment var 'fieldPath' is empty"
+
+		return builder
+	}
+
+	return builder.WithEnvVarFromSource(name, &corev1.EnvVarSource{
+		FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath},
+	})
+}
+
+// WithEnvVarFromResource adds an environment variable populated through the downward API from a resource limit or
+// request of this container, for example limits.cpu or requests.memory.
+func (builder *ContainerBuilder) WithEnvVarFromResource(name, resource string) *ContainerBuilder {
+	if resource == "" {
+		klog.V(100).Info("Container's environment var 'resource' is empty")
+
+		builder.errorMsg = "container's environment var 'resource' is empty"
+
+		return builder
+	}
+
+	return builder.WithEnvVarFromSource(name, &corev1.EnvVarSource{
+		ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: builder.definition.Name, Resource: resource},
+	})
+}
+
+// WithEnvVarFromConfigMapKey adds an environment variable populated from a key of a ConfigMap.
+func (builder *ContainerBuilder) WithEnvVarFromConfigMapKey(name, configMapName, key string) *ContainerBuilder {
+	if configMapName == "" || key == "" {
+		klog.V(100).Info("Container's environment var configMap name or key is empty")
+
+		builder.errorMsg = "container's environment var configMap name and key cannot be empty"
+
+		return builder
+	}
+
+	return builder.WithEnvVarFromSource(name, &corev1.EnvVarSource{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			Key:                  key,
+		},
+	})
+}
+
+// WithEnvVarFromSecretKey adds an environment variable populated from a key of a Secret.
+func (builder *ContainerBuilder) WithEnvVarFromSecretKey(name, secretName, key string) *ContainerBuilder {
+	if secretName == "" || key == "" {
+		klog.V(100).Info("Container's environment var secret name or key is empty")
+
+		builder.errorMsg = "container's environment var secret name and key cannot be empty"
+
+		return builder
+	}
+
+	return builder.WithEnvVarFromSource(name, &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  key,
+		},
+	})
+}
+
+// WithPostStartHook sets the handler executed immediately after the container is created.
+func (builder *ContainerBuilder) WithPostStartHook(handler *corev1.LifecycleHandler) *ContainerBuilder {
+	klog.V(100).Infof("Adding postStart hook to the %s container's definition", builder.definition.Name)
+
+	if err := validateLifecycleHandler("postStart", handler); err != nil {
+		klog.V(100).Infof("Container's postStart hook is invalid: %v", err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	if builder.definition.Lifecycle == nil {
+		builder.definition.Lifecycle = &corev1.Lifecycle{}
+	}
+
+	builder.definition.Lifecycle.PostStart = handler
+
+	return builder
+}
+
+// WithPreStopHook sets the handler executed immediately before the container is terminated.
+func (builder *ContainerBuilder) WithPreStopHook(handler *corev1.LifecycleHandler) *ContainerBuilder {
+	klog.V(100).Infof("Adding preStop hook to the %s container's definition", builder.definition.Name)
+
+	if err := validateLifecycleHandler("preStop", handler); err != nil {
+		klog.V(100).Infof("Container's preStop hook is invalid: %v", err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	if builder.definition.Lifecycle == nil {
+		builder.definition.Lifecycle = &corev1.Lifecycle{}
+	}
+
+	builder.definition.Lifecycle.PreStop = handler
+
+	return builder
+}
+
+// WithResizePolicy sets how the container reacts to an in-place resize of the given resource. Only cpu and memory
+// can be resized. An existing policy for the same resource is replaced.
+func (builder *ContainerBuilder) WithResizePolicy(
+	resourceName corev1.ResourceName, restartPolicy corev1.ResourceResizeRestartPolicy) *ContainerBuilder {
+	klog.V(100).Infof("Applying resize policy %s for resource %s to the %s container's definition",
+		restartPolicy, resourceName, builder.definition.Name)
+
+	if resourceName != corev1.ResourceCPU && resourceName != corev1.ResourceMemory {
+		klog.V(100).Infof("Container's resize policy resource %s is not supported", resourceName)
+
+		builder.errorMsg = fmt.Sprintf("container's resize policy resource must be cpu or memory, got %q", resourceName)
+
+		return builder
+	}
+
+	if restartPolicy != corev1.NotRequired && restartPolicy != corev1.RestartContainer {
+		klog.V(100).Infof("Container's resize restart policy %s is not supported", restartPolicy)
+
+		builder.errorMsg = fmt.Sprintf(
+			"container's resize restart policy must be NotRequired or RestartContainer, got %q", restartPolicy)
+
+		return builder
+	}
+
+	for index, policy := range builder.definition.ResizePolicy {
+		if policy.ResourceName == resourceName {
+			builder.definition.ResizePolicy[index].RestartPolicy = restartPolicy
+
+			return builder
+		}
+	}
+
+	builder.definition.ResizePolicy = append(builder.definition.ResizePolicy,
+		corev1.ContainerResizePolicy{ResourceName: resourceName, RestartPolicy: restartPolicy})
+
+	return builder
+}
+
+// WithTerminationMessagePolicy sets how the termination message of the container is populated.
+func (builder *ContainerBuilder) WithTerminationMessagePolicy(
+	policy corev1.TerminationMessagePolicy) *ContainerBuilder {
+	klog.V(100).Infof("Applying termination message policy %s to the %s container's definition",
+		policy, builder.definition.Name)
+
+	if policy != corev1.TerminationMessageReadFile && policy != corev1.TerminationMessageFallbackToLogsOnError {
+		klog.V(100).Infof("Container's termination message policy %s is not supported", policy)
+
+		builder.errorMsg = fmt.Sprintf(
+			"container's termination message policy must be File or FallbackToLogsOnError, got %q", policy)
+
+		return builder
+	}
+
+	builder.definition.TerminationMessagePolicy = policy
+
+	return builder
+}
+
+// WithTerminationMessagePath sets the file from which the termination message of the container is read.
+func (builder *ContainerBuilder) WithTerminationMessagePath(path string) *ContainerBuilder {
+	klog.V(100).Infof("Applying termination message path %s to the %s container's definition",
+		path, builder.definition.Name)
+
+	if path == "" {
+		klog.V(100).Info("Container's termination message path is empty")
+
+		builder.errorMsg = "container's termination message path is empty"
+
+		return builder
+	}
+
+	builder.definition.TerminationMessagePath = path
+
+	return builder
+}
+
 // WithTTY applies TTY value on container.
 func (builder *ContainerBuilder) WithTTY(enableTTY bool) *ContainerBuilder {
 	klog.V(100).Infof("Applying TTY value to container: %v", enableTTY)
@@ -536,3 +854,47 @@ func capabilitiesDifference(aCaps, bCaps []corev1.Capability) []corev1.Capabilit
 
 	return resultCaps
 }
+
+// validateProbe checks that the probe is set and defines exactly one handler.
+func validateProbe(probeName string, probe *corev1.Probe) error {
+	if probe == nil {
+		return fmt.Errorf("container's %s is empty", probeName)
+	}
+
+	handlers := 0
+
+	for _, isSet := range []bool{
+		probe.Exec != nil, probe.HTTPGet != nil, probe.TCPSocket != nil, probe.GRPC != nil} {
+		if isSet {
+			handlers++
+		}
+	}
+
+	if handlers != 1 {
+		return fmt.Errorf("container's %s must define exactly one handler", probeName)
+	}
+
+	return nil
+}
+
+// validateLifecycleHandler checks that the lifecycle hook is set and defines exactly one handler.
+func validateLifecycleHandler(hookName string, handler *corev1.LifecycleHandler) error {
+	if handler == nil {
+		return fmt.Errorf("container's %s hook is empty", hookName)
+	}
+
+	handlers := 0
+
+	for _, isSet := range []bool{
+		handler.Exec != nil, handler.HTTPGet != nil, handler.TCPSocket != nil, handler.Sleep != nil} {
+		if isSet {
+			handlers++
+		}
+	}
+
+	if handlers != 1 {
+		return fmt.Errorf("container's %s hook must define exactly one handler", hookName)
+	}
+
+	return nil
+}
@@ -437,6 +437,312 @@ func TestPodContainerWithReadinessProbe(t *testing.T) {
 	}
 }
 
+func TestPodContainerWithLivenessAndStartupProbe(t *testing.T) {
+	execProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"echo", "alive"}}},
+	}
+
+	testCases := []struct {
+		probe         *corev1.Probe
+		expectedError string
+	}{
+		{
+			probe:         execProbe,
+			expectedError: "",
+		},
+		{
+			probe:         nil,
+			expectedError: "container's %s is empty",
+		},
+		{
+			probe:         &corev1.Probe{},
+			expectedError: "container's %s must define exactly one handler",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		container = container.WithLivenessProbe(testCase.probe)
+
+		startupContainer := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		startupContainer = startupContainer.WithStartupProbe(testCase.probe)
+
+		if testCase.expectedError != "" {
+			assert.Equal(t, fmt.Sprintf(testCase.expectedError, "livenessProbe"), container.errorMsg)
+			assert.Equal(t, fmt.Sprintf(testCase.expectedError, "startupProbe"), startupContainer.errorMsg)
+
+			continue
+		}
+
+		assert.Empty(t, container.errorMsg)
+		assert.Empty(t, startupContainer.errorMsg)
+		assert.Equal(t, testCase.probe, container.definition.LivenessProbe)
+		assert.Equal(t, testCase.probe, startupContainer.definition.StartupProbe)
+	}
+}
+
+func TestPodContainerWithArgsAndWorkingDir(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		workingDir    string
+		expectedError string
+	}{
+		{
+			args:          []string{"--verbose"},
+			workingDir:    "/tmp",
+			expectedError: "",
+		},
+		{
+			args:          nil,
+			workingDir:    "/tmp",
+			expectedError: "container's args are empty",
+		},
+		{
+			args:          []string{"--verbose"},
+			workingDir:    "",
+			expectedError: "container's workingDir is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		container = container.WithArgs(testCase.args).WithWorkingDir(testCase.workingDir)
+		assert.Equal(t, testCase.expectedError, container.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.args, container.definition.Args)
+			assert.Equal(t, testCase.workingDir, container.definition.WorkingDir)
+		}
+	}
+}
+
+func TestPodContainerWithEnvFrom(t *testing.T) {
+	testCases := []struct {
+		configMapName string
+		secretName    string
+		expectedError string
+	}{
+		{
+			configMapName: "test-configmap",
+			secretName:    "test-secret",
+			expectedError: "",
+		},
+		{
+			configMapName: "",
+			secretName:    "test-secret",
+			expectedError: "container's envFrom configMap name is empty",
+		},
+		{
+			configMapName: "test-configmap",
+			secretName:    "",
+			expectedError: "container's envFrom secret name is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		container = container.WithEnvFromConfigMap(testCase.configMapName).WithEnvFromSecret(testCase.secretName)
+		assert.Equal(t, testCase.expectedError, container.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Len(t, container.definition.EnvFrom, 2)
+			assert.Equal(t, testCase.configMapName, container.definition.EnvFrom[0].ConfigMapRef.Name)
+			assert.Equal(t, testCase.secretName, container.definition.EnvFrom[1].SecretRef.Name)
+		}
+	}
+}
+
+func TestPodContainerWithEnvVarFromSource(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *ContainerBuilder) *ContainerBuilder
+		expectedVar   corev1.EnvVar
+		expectedError string
+	}{
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromField("NODE_NAME", "spec.nodeName")
+			},
+			expectedVar: corev1.EnvVar{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromResource("CPU_LIMIT", "limits.cpu")
+			},
+			expectedVar: corev1.EnvVar{Name: "CPU_LIMIT", ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: "container", Resource: "limits.cpu"}}},
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromConfigMapKey("MODE", "test-configmap", "mode")
+			},
+			expectedVar: corev1.EnvVar{Name: "MODE", ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "test-configmap"}, Key: "mode"}}},
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromSecretKey("TOKEN", "test-secret", "token")
+			},
+			expectedVar: corev1.EnvVar{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"}, Key: "token"}}},
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromField("", "spec.nodeName")
+			},
+			expectedError: "container's environment var 'name' is empty",
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromField("NODE_NAME", "")
+			},
+			expectedError: "container's environment var 'fieldPath' is empty",
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromResource("CPU_LIMIT", "")
+			},
+			expectedError: "container's environment var 'resource' is empty",
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromConfigMapKey("MODE", "test-configmap", "")
+			},
+			expectedError: "container's environment var configMap name and key cannot be empty",
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromSecretKey("TOKEN", "", "token")
+			},
+			expectedError: "container's environment var secret name and key cannot be empty",
+		},
+		{
+			mutate: func(builder *ContainerBuilder) *ContainerBuilder {
+				return builder.WithEnvVarFromSource("TOKEN", nil)
+			},
+			expectedError: "container's environment var 'valueFrom' is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := testCase.mutate(NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"}))
+		assert.Equal(t, testCase.expectedError, container.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, []corev1.EnvVar{testCase.expectedVar}, container.definition.Env)
+		}
+	}
+}
+
+func TestPodContainerWithLifecycleHooks(t *testing.T) {
+	execHandler := &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"echo", "hook"}}}
+
+	testCases := []struct {
+		handler       *corev1.LifecycleHandler
+		expectedError string
+	}{
+		{
+			handler:       execHandler,
+			expectedError: "",
+		},
+		{
+			handler:       nil,
+			expectedError: "container's postStart hook is empty",
+		},
+		{
+			handler:       &corev1.LifecycleHandler{},
+			expectedError: "container's postStart hook must define exactly one handler",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		container = container.WithPostStartHook(testCase.handler)
+		assert.Equal(t, testCase.expectedError, container.errorMsg)
+
+		if testCase.expectedError == "" {
+			container = container.WithPreStopHook(testCase.handler)
+			assert.Empty(t, container.errorMsg)
+			assert.Equal(t, testCase.handler, container.definition.Lifecycle.PostStart)
+			assert.Equal(t, testCase.handler, container.definition.Lifecycle.PreStop)
+		}
+	}
+}
+
+func TestPodContainerWithResizePolicy(t *testing.T) {
+	testCases := []struct {
+		resourceName  corev1.ResourceName
+		restartPolicy corev1.ResourceResizeRestartPolicy
+		expectedError string
+	}{
+		{
+			resourceName:  corev1.ResourceMemory,
+			restartPolicy: corev1.RestartContainer,
+			expectedError: "",
+		},
+		{
+			resourceName:  corev1.ResourceStorage,
+			restartPolicy: corev1.NotRequired,
+			expectedError: "container's resize policy resource must be cpu or memory, got \"storage\"",
+		},
+		{
+			resourceName:  corev1.ResourceCPU,
+			restartPolicy: "Always",
+			expectedError: "container's resize restart policy must be NotRequired or RestartContainer, got \"Always\"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		container = container.WithResizePolicy(corev1.ResourceMemory, corev1.NotRequired).
+			WithResizePolicy(testCase.resourceName, testCase.restartPolicy)
+		assert.Equal(t, testCase.expectedError, container.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, []corev1.ContainerResizePolicy{{
+				ResourceName: testCase.resourceName, RestartPolicy: testCase.restartPolicy,
+			}}, container.definition.ResizePolicy)
+		}
+	}
+}
+
+func TestPodContainerWithTerminationMessage(t *testing.T) {
+	testCases := []struct {
+		policy        corev1.TerminationMessagePolicy
+		path          string
+		expectedError string
+	}{
+		{
+			policy:        corev1.TerminationMessageFallbackToLogsOnError,
+			path:          "/tmp/termination-log",
+			expectedError: "",
+		},
+		{
+			policy:        "invalid",
+			path:          "/tmp/termination-log",
+			expectedError: "container's termination message policy must be File or FallbackToLogsOnError, got \"invalid\"",
+		},
+		{
+			policy:        corev1.TerminationMessageReadFile,
+			path:          "",
+			expectedError: "container's termination message path is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		container := NewContainerBuilder("container", "test", []string{"/bin/bash", "-c", "sleep"})
+		container = container.WithTerminationMessagePolicy(testCase.policy).WithTerminationMessagePath(testCase.path)
+		assert.Equal(t, testCase.expectedError, container.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.policy, container.definition.TerminationMessagePolicy)
+			assert.Equal(t, testCase.path, container.definition.TerminationMessagePath)
+		}
+	}
+}
+
 func TestPodContainerWithTTY(t *testing.T) {
 	testCases := []struct {
 		enableTty     bool
@@ -0,0 +1,177 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+)
+
+// AddEphemeralContainer injects an ephemeral debug container into the running pod using the ephemeralcontainers
+// subresource and waits for the duration of the defined timeout or until the container is running. This allows
+// commands to be executed in pods whose containers have no shell, such as distroless operator pods.
+//
+// If targetContainer is not empty, the ephemeral container shares the process namespace of that container. Once this
+// method returns, the name of the ephemeral container can be passed to ExecCommand, ExecCommandWithTimeout, GetLog,
+// and GetFullLog like any other container name.
+func (builder *Builder) AddEphemeralContainer(
+	name, image, targetContainer string, command []string, timeout time.Duration) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Adding ephemeral container %s with image %s to pod %s in namespace %s",
+		name, image, builder.Definition.Name, builder.Definition.Namespace)
+
+	if name == "" {
+		klog.V(100).Info("The ephemeral container name is empty")
+
+		return builder, fmt.Errorf("ephemeral container 'name' cannot be empty")
+	}
+
+	if image == "" {
+		klog.V(100).Info("The ephemeral container image is empty")
+
+		return builder, fmt.Errorf("ephemeral container 'image' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		klog.V(100).Infof("Cannot add ephemeral container to pod %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return builder, fmt.Errorf("pod object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	if hasContainerNamed(builder.Object, name) {
+		klog.V(100).Infof("Container %s already exists in pod %s in namespace %s",
+			name, builder.Definition.Name, builder.Definition.Namespace)
+
+		return builder, fmt.Errorf("container %s already exists in pod %s in namespace %s",
+			name, builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	if targetContainer != "" && !hasRegularContainerNamed(builder.Object, targetContainer) {
+		klog.V(100).Infof("Target container %s does not exist in pod %s in namespace %s",
+			targetContainer, builder.Definition.Name, builder.Definition.Namespace)
+
+		return builder, fmt.Errorf("target container %s does not exist in pod %s in namespace %s",
+			targetContainer, builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	updatedPod := builder.Object.DeepCopy()
+	updatedPod.Spec.EphemeralContainers = append(updatedPod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			Command:                  command,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			Stdin:                    true,
+			TTY:                      true,
+		},
+		TargetContainerName: targetContainer,
+	})
+
+	podObject, err := builder.apiClient.Pods(builder.Definition.Namespace).UpdateEphemeralContainers(
+		logging.DiscardContext(), builder.Definition.Name, updatedPod, metav1.UpdateOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to add ephemeral container %s to pod %s in namespace %s: %v",
+			name, builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, fmt.Errorf("failed to add ephemeral container %s: %w", name, err)
+	}
+
+	builder.Object = podObject
+
+	err = builder.WaitUntilEphemeralContainerRunning(name, timeout)
+	if err != nil {
+		return builder, err
+	}
+
+	return builder, nil
+}
+
+// WaitUntilEphemeralContainerRunning waits for the duration of the defined timeout or until the ephemeral container
+// with the provided name is running.
+func (builder *Builder) WaitUntilEphemeralContainerRunning(name string, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until ephemeral container %s in pod %s in namespace %s is running",
+		name, builder.Definition.Name, builder.Definition.Namespace)
+
+	if name == "" {
+		klog.V(100).Info("The ephemeral container name is empty")
+
+		return fmt.Errorf("ephemeral container 'name' cannot be empty")
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			updatePod, err := builder.apiClient.Pods(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("Failed to get pod %s in namespace %s: %v",
+					builder.Definition.Name, builder.Definition.Namespace, err)
+
+				return false, nil
+			}
+
+			builder.Object = updatePod
+
+			for _, status := range updatePod.Status.EphemeralContainerStatuses {
+				if status.Name != name {
+					continue
+				}
+
+				if status.State.Terminated != nil {
+					return false, fmt.Errorf("ephemeral container %s terminated with exit code %d: %s",
+						name, status.State.Terminated.ExitCode, status.State.Terminated.Reason)
+				}
+
+				return status.State.Running != nil, nil
+			}
+
+			return false, nil
+		})
+}
+
+// hasContainerNamed returns true if the pod has a regular, init, or ephemeral container with the provided name.
+func hasContainerNamed(pod *corev1.Pod, name string) bool {
+	if hasRegularContainerNamed(pod, name) {
+		return true
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasRegularContainerNamed returns true if the pod has a regular container with the provided name.
+func hasRegularContainerNamed(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,204 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultEphemeralName = "debugger"
+
+func TestPodAddEphemeralContainer(t *testing.T) {
+	testCases := []struct {
+		name            string
+		image           string
+		targetContainer string
+		exists          bool
+		running         bool
+		valid           bool
+		expectedError   error
+	}{
+		{
+			name:            defaultEphemeralName,
+			image:           defaultPodImage,
+			targetContainer: "test",
+			exists:          true,
+			running:         true,
+			valid:           true,
+			expectedError:   nil,
+		},
+		{
+			name:            defaultEphemeralName,
+			image:           defaultPodImage,
+			targetContainer: "",
+			exists:          true,
+			running:         true,
+			valid:           true,
+			expectedError:   nil,
+		},
+		{
+			name:            "",
+			image:           defaultPodImage,
+			targetContainer: "test",
+			exists:          true,
+			running:         true,
+			valid:           true,
+			expectedError:   fmt.Errorf("ephemeral container 'name' cannot be empty"),
+		},
+		{
+			name:            defaultEphemeralName,
+			image:           "",
+			targetContainer: "test",
+			exists:          true,
+			running:         true,
+			valid:           true,
+			expectedError:   fmt.Errorf("ephemeral container 'image' cannot be empty"),
+		},
+		{
+			name:            "test",
+			image:           defaultPodImage,
+			targetContainer: "test",
+			exists:          true,
+			running:         true,
+			valid:           true,
+			expectedError: fmt.Errorf(
+				"container test already exists in pod %s in namespace %s", defaultPodName, defaultPodNsName),
+		},
+		{
+			name:            defaultEphemeralName,
+			image:           defaultPodImage,
+			targetContainer: "missing",
+			exists:          true,
+			running:         true,
+			valid:           true,
+			expectedError: fmt.Errorf(
+				"target container missing does not exist in pod %s in namespace %s", defaultPodName, defaultPodNsName),
+		},
+		{
+			name:            defaultEphemeralName,
+			image:           defaultPodImage,
+			targetContainer: "test",
+			exists:          false,
+			running:         true,
+			valid:           true,
+			expectedError: fmt.Errorf(
+				"pod object %s does not exist in namespace %s", defaultPodName, defaultPodNsName),
+		},
+		{
+			name:            defaultEphemeralName,
+			image:           defaultPodImage,
+			targetContainer: "test",
+			exists:          true,
+			running:         false,
+			valid:           true,
+			expectedError:   context.DeadlineExceeded,
+		},
+		{
+			name:            defaultEphemeralName,
+			image:           defaultPodImage,
+			targetContainer: "test",
+			exists:          true,
+			running:         true,
+			valid:           false,
+			expectedError:   fmt.Errorf("pod 'namespace' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyPodWithEphemeralStatus(testCase.running))
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		var testBuilder *Builder
+
+		if testCase.valid {
+			testBuilder = buildValidPodTestBuilder(testSettings)
+		} else {
+			testBuilder = buildInvalidPodTestBuilder(testSettings)
+		}
+
+		testBuilder, err := testBuilder.AddEphemeralContainer(
+			testCase.name, testCase.image, testCase.targetContainer, []string{"sleep", "INF"}, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, testBuilder.Object.Spec.EphemeralContainers, 1)
+			assert.Equal(t, testCase.name, testBuilder.Object.Spec.EphemeralContainers[0].Name)
+			assert.Equal(t, testCase.targetContainer, testBuilder.Object.Spec.EphemeralContainers[0].TargetContainerName)
+		}
+	}
+}
+
+func TestPodWaitUntilEphemeralContainerRunning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		running       bool
+		terminated    bool
+		expectedError error
+	}{
+		{
+			name:          defaultEphemeralName,
+			running:       true,
+			expectedError: nil,
+		},
+		{
+			name:          defaultEphemeralName,
+			running:       false,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			name:          defaultEphemeralName,
+			terminated:    true,
+			expectedError: fmt.Errorf("ephemeral container %s terminated with exit code 1: Error", defaultEphemeralName),
+		},
+		{
+			name:          "",
+			running:       true,
+			expectedError: fmt.Errorf("ephemeral container 'name' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testPod := buildDummyPodWithEphemeralStatus(testCase.running)
+
+		if testCase.terminated {
+			testPod.Status.EphemeralContainerStatuses[0].State = corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+			}
+		}
+
+		testBuilder := buildValidPodTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{testPod},
+		}))
+
+		err := testBuilder.WaitUntilEphemeralContainerRunning(testCase.name, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+// buildDummyPodWithEphemeralStatus returns a dummy Pod that reports the default ephemeral container status as either
+// running or waiting.
+func buildDummyPodWithEphemeralStatus(running bool) *corev1.Pod {
+	pod := buildDummyPod(defaultPodName, defaultPodNsName, defaultPodImage)
+	status := corev1.ContainerStatus{Name: defaultEphemeralName}
+
+	if running {
+		status.State.Running = &corev1.ContainerStateRunning{}
+	} else {
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}
+	}
+
+	pod.Status.EphemeralContainerStatuses = append(pod.Status.EphemeralContainerStatuses, status)
+
+	return pod
+}
@@ -784,51 +784,6 @@ func (builder *Builder) WithTolerationToControlPlane() *Builder {
 	return builder
 }
 
-// WithToleration adds a toleration configuration inside the pod.
-func (builder *Builder) WithToleration(toleration corev1.Toleration) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Updating pod %s with toleration %v", builder.Definition.Name, toleration)
-
-	builder.isMutationAllowed("custom toleration")
-
-	if builder.errorMsg != "" {
-		return builder
-	}
-
-	builder.Definition.Spec.Tolerations = append(builder.Definition.Spec.Tolerations, toleration)
-
-	return builder
-}
-
-// WithNodeSelector adds a nodeSelector configuration inside the pod.
-func (builder *Builder) WithNodeSelector(nodeSelector map[string]string) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Redefining pod %s in namespace %s with nodeSelector %v",
-		builder.Definition.Name, builder.Definition.Namespace, nodeSelector)
-
-	builder.isMutationAllowed("nodeSelector")
-
-	if len(nodeSelector) == 0 {
-		klog.V(100).Infof(
-			"Failed to set nodeSelector on pod %s in namespace %s. nodeSelector can not be empty",
-			builder.Definition.Name, builder.Definition.Namespace)
-
-		builder.errorMsg = "can not define pod with empty nodeSelector"
-
-		return builder
-	}
-
-	builder.Definition.Spec.NodeSelector = nodeSelector
-
-	return builder
-}
-
 // WithPrivilegedFlag sets privileged flag on all containers.
 func (builder *Builder) WithPrivilegedFlag() *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -852,28 +807,6 @@ func (builder *Builder) WithPrivilegedFlag() *Builder {
 	return builder
 }
 
-// WithVolume attaches given volume to a pod.
-func (builder *Builder) WithVolume(volume corev1.Volume) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if volume.Name == "" {
-		klog.V(100).Info("The volume's Name cannot be empty")
-
-		builder.errorMsg = "the volume's name cannot be empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding volume %s to pod %s in namespace %s",
-		volume.Name, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Volumes = append(builder.Definition.Spec.Volumes, volume)
-
-	return builder
-}
-
 // WithLocalVolume attaches given volume to all pod's containers.
 func (builder *Builder) WithLocalVolume(volumeName, mountPath string) *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -1057,69 +990,6 @@ func (builder *Builder) RedefineDefaultContainer(container corev1.Container) *Bu
 	return builder
 }
 
-// WithHugePages sets hugePages on all containers inside the pod.
-func (builder *Builder) WithHugePages() *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying hugePages configuration to all containers in pod: %s", builder.Definition.Name)
-
-	builder.isMutationAllowed("hugepages")
-
-	if builder.Definition.Spec.Volumes != nil {
-		builder.Definition.Spec.Volumes = append(builder.Definition.Spec.Volumes, corev1.Volume{
-			Name: "hugepages", VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: "HugePages"}}})
-	} else {
-		builder.Definition.Spec.Volumes = []corev1.Volume{
-			{Name: "hugepages", VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: "HugePages"}},
-			},
-		}
-	}
-
-	for idx := range builder.Definition.Spec.Containers {
-		if builder.Definition.Spec.Containers[idx].VolumeMounts != nil {
-			builder.Definition.Spec.Containers[idx].VolumeMounts = append(
-				builder.Definition.Spec.Containers[idx].VolumeMounts,
-				corev1.VolumeMount{Name: "hugepages", MountPath: "/mnt/huge"})
-		} else {
-			builder.Definition.Spec.Containers[idx].VolumeMounts = []corev1.VolumeMount{{
-				Name:      "hugepages",
-				MountPath: "/mnt/huge",
-			},
-			}
-		}
-	}
-
-	return builder
-}
-
-// WithSecurityContext sets SecurityContext on pod definition.
-func (builder *Builder) WithSecurityContext(securityContext *corev1.PodSecurityContext) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying SecurityContext configuration on pod %s in namespace %s",
-		builder.Definition.Name, builder.Definition.Namespace)
-
-	if securityContext == nil {
-		klog.V(100).Info("The 'securityContext' of the pod is empty")
-
-		builder.errorMsg = "'securityContext' parameter is empty"
-
-		return builder
-	}
-
-	builder.isMutationAllowed("SecurityContext")
-
-	builder.Definition.Spec.SecurityContext = securityContext
-
-	return builder
-}
-
 // PullImage pulls image for given pod's container and removes it.
 func (builder *Builder) PullImage(timeout time.Duration, testCmd []string) error {
 	if valid, err := builder.validate(); !valid {
@@ -1238,26 +1108,6 @@ func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
 	return builder
 }
 
-// WithTerminationGracePeriodSeconds configures TerminationGracePeriodSeconds on the pod.
-func (builder *Builder) WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds int64) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying terminationGracePeriodSeconds flag to the configuration of pod: %s in namespace: %s",
-		builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.isMutationAllowed("terminationGracePeriodSeconds")
-
-	if builder.errorMsg != "" {
-		return builder
-	}
-
-	builder.Definition.Spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
-
-	return builder
-}
-
 // GetLog connects to a pod and fetches log.
 func (builder *Builder) GetLog(logStartTime time.Duration, containerName string) (string, error) {
 	// GetLogsWithOptions already handles validation, so no need to duplicate it here.
@@ -0,0 +1,142 @@
+package pod
+
+import (
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/podtemplate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// WithNodeSelector applies a nodeSelector to the pod.
+func (builder *Builder) WithNodeSelector(nodeSelector map[string]string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if len(nodeSelector) == 0 {
+		klog.V(100).Info("The nodeSelector is empty")
+
+		builder.errorMsg = "can not define pod with empty nodeSelector"
+
+		return builder
+	}
+
+	return builder.withPodTemplateMutation("nodeSelector", podtemplate.WithNodeSelector(nodeSelector))
+}
+
+// WithToleration appends a toleration to the pod.
+func (builder *Builder) WithToleration(toleration corev1.Toleration) *Builder {
+	return builder.withPodTemplateMutation("toleration", podtemplate.WithToleration(toleration))
+}
+
+// WithVolume attaches given volume to the pod. Unlike the other pod template mutations, it is not rejected for a
+// running pod.
+func (builder *Builder) WithVolume(volume corev1.Volume) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Adding volume %s to pod %s in namespace %s",
+		volume.Name, builder.Definition.Name, builder.Definition.Namespace)
+
+	template := &corev1.PodTemplateSpec{Spec: builder.Definition.Spec}
+
+	if err := podtemplate.WithVolume(volume)(template); err != nil {
+		klog.V(100).Infof("Failed to add volume to pod %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec = template.Spec
+
+	return builder
+}
+
+// WithHugePages adds a hugepages volume to the pod and mounts it in all containers.
+func (builder *Builder) WithHugePages() *Builder {
+	return builder.withPodTemplateMutation("hugepages", podtemplate.WithHugePages())
+}
+
+// WithAffinity applies affinity to the pod.
+func (builder *Builder) WithAffinity(affinity *corev1.Affinity) *Builder {
+	return builder.withPodTemplateMutation("affinity", podtemplate.WithAffinity(affinity))
+}
+
+// WithTopologySpreadConstraint appends a topology spread constraint to the pod.
+func (builder *Builder) WithTopologySpreadConstraint(constraint corev1.TopologySpreadConstraint) *Builder {
+	return builder.withPodTemplateMutation(
+		"topology spread constraint", podtemplate.WithTopologySpreadConstraint(constraint))
+}
+
+// WithPriorityClassName sets the priority class of the pod.
+func (builder *Builder) WithPriorityClassName(priorityClassName string) *Builder {
+	return builder.withPodTemplateMutation("priorityClassName", podtemplate.WithPriorityClassName(priorityClassName))
+}
+
+// WithRuntimeClassName sets the runtime class of the pod.
+func (builder *Builder) WithRuntimeClassName(runtimeClassName string) *Builder {
+	return builder.withPodTemplateMutation("runtimeClassName", podtemplate.WithRuntimeClassName(runtimeClassName))
+}
+
+// WithDNSConfig sets the DNS policy and DNS config of the pod. The dnsConfig must define at
+// least one nameserver when the dnsPolicy is None and may be nil otherwise.
+func (builder *Builder) WithDNSConfig(dnsPolicy corev1.DNSPolicy, dnsConfig *corev1.PodDNSConfig) *Builder {
+	return builder.withPodTemplateMutation("dnsConfig", podtemplate.WithDNSConfig(dnsPolicy, dnsConfig))
+}
+
+// WithSecurityContext sets the pod security context of the pod.
+func (builder *Builder) WithSecurityContext(securityContext *corev1.PodSecurityContext) *Builder {
+	return builder.withPodTemplateMutation("securityContext", podtemplate.WithSecurityContext(securityContext))
+}
+
+// WithServiceAccountName sets the service account the pod runs as.
+func (builder *Builder) WithServiceAccountName(serviceAccountName string) *Builder {
+	return builder.withPodTemplateMutation(
+		"serviceAccountName", podtemplate.WithServiceAccountName(serviceAccountName))
+}
+
+// WithSchedulerName configures a scheduler to process the scheduling of the pod.
+func (builder *Builder) WithSchedulerName(schedulerName string) *Builder {
+	return builder.withPodTemplateMutation("schedulerName", podtemplate.WithSchedulerName(schedulerName))
+}
+
+// WithTerminationGracePeriodSeconds configures terminationGracePeriodSeconds on the pod.
+func (builder *Builder) WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds int64) *Builder {
+	return builder.withPodTemplateMutation(
+		"terminationGracePeriodSeconds", podtemplate.WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds))
+}
+
+// withPodTemplateMutation validates the builder and applies the mutator to the pod spec and metadata, saving any
+// error on the builder. Mutations are rejected once the pod is running.
+func (builder *Builder) withPodTemplateMutation(field string, mutator podtemplate.Mutator) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	klog.V(100).Infof("Applying %s to pod %s in namespace %s",
+		field, builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.isMutationAllowed(field)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	template := &corev1.PodTemplateSpec{ObjectMeta: builder.Definition.ObjectMeta, Spec: builder.Definition.Spec}
+
+	if err := mutator(template); err != nil {
+		klog.V(100).Infof("Failed to apply %s to pod %s in namespace %s: %v",
+			field, builder.Definition.Name, builder.Definition.Namespace, err)
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.ObjectMeta = template.ObjectMeta
+	builder.Definition.Spec = template.Spec
+
+	return builder
+}
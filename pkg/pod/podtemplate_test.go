@@ -0,0 +1,118 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodTemplateMutators(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *Builder) *Builder
+		verify        func(t *testing.T, spec corev1.PodSpec)
+		expectedError string
+	}{
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithToleration(corev1.Toleration{Key: "test-key", Operator: corev1.TolerationOpExists})
+			},
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Contains(t, spec.Tolerations, corev1.Toleration{Key: "test-key", Operator: corev1.TolerationOpExists})
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithToleration(corev1.Toleration{}) },
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Contains(t, spec.Tolerations, corev1.Toleration{})
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithHugePages() },
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.StorageMediumHugePages, spec.Volumes[len(spec.Volumes)-1].EmptyDir.Medium)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithAffinity(&corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}})
+			},
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.NotNil(t, spec.Affinity.PodAntiAffinity)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithTopologySpreadConstraint(corev1.TopologySpreadConstraint{
+					MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway})
+			},
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Len(t, spec.TopologySpreadConstraints, 1)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithTopologySpreadConstraint(corev1.TopologySpreadConstraint{})
+			},
+			expectedError: "topology spread constraint topologyKey cannot be empty",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithPriorityClassName("high-priority") },
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Equal(t, "high-priority", spec.PriorityClassName)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithRuntimeClassName("performance") },
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To("performance"), spec.RuntimeClassName)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithDNSConfig(corev1.DNSNone, &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}})
+			},
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Equal(t, corev1.DNSNone, spec.DNSPolicy)
+				assert.Equal(t, []string{"10.0.0.10"}, spec.DNSConfig.Nameservers)
+			},
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithDNSConfig(corev1.DNSNone, nil) },
+			expectedError: "dnsConfig must define at least one nameserver when dnsPolicy is None",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithSchedulerName("test-scheduler") },
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Equal(t, "test-scheduler", spec.SchedulerName)
+			},
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithTerminationGracePeriodSeconds(10) },
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				t.Helper()
+				assert.Equal(t, ptr.To[int64](10), spec.TerminationGracePeriodSeconds)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(buildValidPodTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			testCase.verify(t, testBuilder.Definition.Spec)
+		}
+	}
+}
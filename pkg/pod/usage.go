@@ -0,0 +1,67 @@
+package pod
+
+import (
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	metricsv1beta1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/metrics/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetUsage returns the current CPU and memory usage of each container in the pod as reported by the metrics.k8s.io
+// API, keyed by container name. The memory usage is the working set of the container.
+func (builder *Builder) GetUsage() (map[string]corev1.ResourceList, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting resource usage of pod %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	podMetrics := &metricsv1beta1.PodMetrics{}
+
+	err := builder.apiClient.Client.Get(logging.DiscardContext(), runtimeclient.ObjectKey{
+		Name: builder.Definition.Name, Namespace: builder.Definition.Namespace}, podMetrics)
+	if err != nil {
+		klog.V(100).Infof("Failed to get metrics of pod %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return nil, fmt.Errorf("failed to get metrics of pod %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	usage := make(map[string]corev1.ResourceList, len(podMetrics.Containers))
+	for _, container := range podMetrics.Containers {
+		usage[container.Name] = container.Usage
+	}
+
+	return usage, nil
+}
+
+// GetTotalUsage returns the current resource usage of the pod summed across all of its containers.
+func (builder *Builder) GetTotalUsage() (corev1.ResourceList, error) {
+	containerUsage, err := builder.GetUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	totalUsage := corev1.ResourceList{}
+
+	for _, usage := range containerUsage {
+		for resourceName, quantity := range usage {
+			total, found := totalUsage[resourceName]
+			if !found {
+				totalUsage[resourceName] = quantity.DeepCopy()
+
+				continue
+			}
+
+			total.Add(quantity)
+			totalUsage[resourceName] = total
+		}
+	}
+
+	return totalUsage, nil
+}
@@ -0,0 +1,85 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	metricsv1beta1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/metrics/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPodGetUsage(t *testing.T) {
+	testCases := []struct {
+		metricsExist  bool
+		expectedError string
+	}{
+		{
+			metricsExist:  true,
+			expectedError: "",
+		},
+		{
+			metricsExist:  false,
+			expectedError: "failed to get metrics of pod test-pod in namespace test-ns",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.metricsExist {
+			runtimeObjects = append(runtimeObjects, buildDummyPodMetrics())
+		}
+
+		testBuilder := buildValidPodTestBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: runtimeObjects,
+		}))
+
+		usage, err := testBuilder.GetUsage()
+		totalUsage, totalErr := testBuilder.GetTotalUsage()
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError)
+			assert.ErrorContains(t, totalErr, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Nil(t, totalErr)
+		assert.Len(t, usage, 2)
+		firstUsage := usage["first"]
+		assert.Equal(t, int64(100), firstUsage.Cpu().MilliValue())
+
+		assert.Equal(t, int64(350), totalUsage.Cpu().MilliValue())
+		assert.Equal(t, int64(3<<20), totalUsage.Memory().Value())
+	}
+}
+
+func buildDummyPodMetrics() *metricsv1beta1.PodMetrics {
+	return &metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultPodName,
+			Namespace: defaultPodNsName,
+		},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{
+				Name: "first",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("1Mi"),
+				},
+			},
+			{
+				Name: "second",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("2Mi"),
+				},
+			},
+		},
+	}
+}
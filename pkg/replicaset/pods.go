@@ -0,0 +1,71 @@
+package replicaset
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/workloadpods"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// ListPods returns the pods managed by the replicaset. Pods must match the replicaset's selector and be
+// controlled by the replicaset. At most one ListOptions may be provided to narrow the result further; its label
+// selector is combined with the replicaset's selector.
+func (builder *Builder) ListPods(options ...metav1.ListOptions) ([]*pod.Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Listing pods of replicaset %s in namespace %s with the options %v",
+		builder.Definition.Name, builder.Definition.Namespace, options)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot list pods of replicaset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	ownerUIDs := []types.UID{builder.Object.UID}
+
+	return workloadpods.List(
+		builder.apiClient, builder.Definition.Namespace, builder.Object.Spec.Selector, ownerUIDs, options...)
+}
+
+// GetReadyPod returns one of the pods managed by the replicaset that is running and ready.
+func (builder *Builder) GetReadyPod() (*pod.Builder, error) {
+	pods, err := builder.ListPods()
+	if err != nil {
+		return nil, err
+	}
+
+	return workloadpods.FirstReady(pods, "replicaset", builder.Definition.Name, builder.Definition.Namespace)
+}
+
+// ExecCommandInReadyPod runs the command in one of the ready pods managed by the replicaset and returns the buffer
+// output.
+func (builder *Builder) ExecCommandInReadyPod(command []string, containerName ...string) (bytes.Buffer, error) {
+	readyPod, err := builder.GetReadyPod()
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	klog.V(100).Infof("Executing command %v in pod %s of replicaset %s in namespace %s",
+		command, readyPod.Definition.Name, builder.Definition.Name, builder.Definition.Namespace)
+
+	return readyPod.ExecCommand(command, containerName...)
+}
+
+// GetReadyPodLog returns the full log of the container in one of the ready pods managed by the replicaset.
+func (builder *Builder) GetReadyPodLog(containerName string) (string, error) {
+	readyPod, err := builder.GetReadyPod()
+	if err != nil {
+		return "", err
+	}
+
+	klog.V(100).Infof("Getting log of container %s in pod %s of replicaset %s in namespace %s",
+		containerName, readyPod.Definition.Name, builder.Definition.Name, builder.Definition.Namespace)
+
+	return readyPod.GetFullLog(containerName)
+}
@@ -0,0 +1,158 @@
+package replicaset
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const defaultReplicaSetUID = types.UID("test-replicaset-uid")
+
+func TestReplicaSetListPods(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		options       []metav1.ListOptions
+		expectedPods  []string
+		expectedError error
+	}{
+		{
+			exists:       true,
+			expectedPods: []string{"owned-ready", "owned-pending"},
+		},
+		{
+			exists:       true,
+			options:      []metav1.ListOptions{{LabelSelector: "ready=true"}},
+			expectedPods: []string{"owned-ready"},
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot list pods of replicaset test-name in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = buildDummyPodsTestObjects()
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		pods, err := buildValidReplicaSetBuilder(testSettings).ListPods(testCase.options...)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			var podNames []string
+			for _, podBuilder := range pods {
+				podNames = append(podNames, podBuilder.Definition.Name)
+			}
+
+			assert.ElementsMatch(t, testCase.expectedPods, podNames)
+		}
+	}
+}
+
+func TestReplicaSetGetReadyPodLog(t *testing.T) {
+	testCases := []struct {
+		ready         bool
+		expectedError error
+	}{
+		{
+			ready:         true,
+			expectedError: nil,
+		},
+		{
+			ready:         false,
+			expectedError: fmt.Errorf("no ready pod found for replicaset test-name in namespace test-namespace"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := buildDummyPodsTestObjects()
+
+		if !testCase.ready {
+			runtimeObjects = runtimeObjects[:len(runtimeObjects)-1]
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		log, err := buildValidReplicaSetBuilder(testSettings).GetReadyPodLog("test")
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, "fake logs", log)
+		}
+	}
+}
+
+// buildDummyPodsTestObjects returns the replicaset along with pods that are pending, owned by another controller or
+// not matching its selector. The last object is the only ready pod managed by the replicaset.
+func buildDummyPodsTestObjects() []runtime.Object {
+	labels := defaultReplicaSetLabel
+
+	return []runtime.Object{
+		buildDummyReplicaSetWithSelector(),
+		buildDummyWorkloadPod("owned-pending", labels, defaultReplicaSetUID, false),
+		buildDummyWorkloadPod("other-owner", labels, "other-uid", true),
+		buildDummyWorkloadPod("other-labels", map[string]string{"other": "label"}, defaultReplicaSetUID, true),
+		buildDummyWorkloadPod("owned-ready", labels, defaultReplicaSetUID, true),
+	}
+}
+
+func buildDummyWorkloadPod(name string, labels map[string]string, controllerUID types.UID, ready bool) *corev1.Pod {
+	podLabels := map[string]string{"ready": strconv.FormatBool(ready)}
+	for key, value := range labels {
+		podLabels[key] = value
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+			Labels:    podLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "OWNERreplicaset",
+				Name:       "owner",
+				UID:        controllerUID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	if ready {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+
+	return pod
+}
+
+func buildDummyReplicaSetWithSelector() *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultReplicaSetName,
+			Namespace: defaultReplicaSetNamespace,
+			UID:       defaultReplicaSetUID,
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: defaultReplicaSetLabel},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: defaultReplicaSetLabel},
+				Spec:       corev1.PodSpec{Containers: defaultReplicaSetContainer},
+			},
+		},
+	}
+}
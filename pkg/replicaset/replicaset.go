@@ -172,62 +172,6 @@ func (builder *Builder) WithLabel(labels map[string]string) *Builder {
 	return builder
 }
 
-// WithNodeSelector applies nodeSelector to the replicaset definition.
-func (builder *Builder) WithNodeSelector(nodeSelector map[string]string) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	klog.V(100).Infof("Applying nodeSelector %s to replicaset %s in namespace %s",
-		nodeSelector, builder.Definition.Name, builder.Definition.Namespace)
-
-	if len(nodeSelector) == 0 {
-		klog.V(100).Info("The 'nodeSelector' of the replicaset is empty")
-
-		builder.errorMsg = "can not apply empty nodeSelector"
-
-		return builder
-	}
-
-	for key := range nodeSelector {
-		if key == "" {
-			klog.V(100).Info("The 'nodeSelector' key value cannot be empty")
-
-			builder.errorMsg = "can not apply a nodeSelector with an empty key value"
-
-			return builder
-		}
-	}
-
-	builder.Definition.Spec.Template.Spec.NodeSelector = nodeSelector
-
-	return builder
-}
-
-// WithVolume defines Volume of replicaset under ContainerTemplateSpec.
-func (builder *Builder) WithVolume(rsVolume corev1.Volume) *Builder {
-	if valid, _ := builder.validate(); !valid {
-		return builder
-	}
-
-	if rsVolume.Name == "" {
-		klog.V(100).Info("The Volume name parameter is empty")
-
-		builder.errorMsg = "volume name parameter is empty"
-
-		return builder
-	}
-
-	klog.V(100).Infof("Adding volume %s for replicaset %s container template in namespace %s",
-		rsVolume.Name, builder.Definition.Name, builder.Definition.Namespace)
-
-	builder.Definition.Spec.Template.Spec.Volumes = append(
-		builder.Definition.Spec.Template.Spec.Volumes,
-		rsVolume)
-
-	return builder
-}
-
 // WithAdditionalContainerSpecs appends a list of container specs to the replicaset definition.
 func (builder *Builder) WithAdditionalContainerSpecs(specs []corev1.Container) *Builder {
 	if valid, _ := builder.validate(); !valid {
@@ -0,0 +1,140 @@
+package replicaset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestReplicaSetScale(t *testing.T) {
+	testCases := []struct {
+		replicas      int32
+		exists        bool
+		expectedError error
+	}{
+		{
+			replicas:      3,
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			replicas:      0,
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			replicas:      -1,
+			exists:        true,
+			expectedError: fmt.Errorf("replicaset 'replicas' cannot be negative"),
+		},
+		{
+			replicas: 3,
+			exists:   false,
+			expectedError: fmt.Errorf(
+				"cannot scale replicaset test-name in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = buildDummyReplicaSet()
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		addScaleReactors(testSettings)
+
+		testBuilder, err := buildValidReplicaSetBuilder(testSettings).Scale(testCase.replicas)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.replicas, *testBuilder.Object.Spec.Replicas)
+			assert.Equal(t, testCase.replicas, *testBuilder.Definition.Spec.Replicas)
+		}
+	}
+}
+
+func TestReplicaSetWaitForReplicas(t *testing.T) {
+	testCases := []struct {
+		replicas      int32
+		expectedError error
+	}{
+		{
+			replicas:      0,
+			expectedError: nil,
+		},
+		{
+			replicas:      1,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			replicas:      -1,
+			expectedError: fmt.Errorf("replicaset 'replicas' cannot be negative"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidReplicaSetBuilder(buildReplicaSetClientWithDummyObject())
+
+		err := testBuilder.WaitForReplicas(testCase.replicas, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+// addScaleReactors makes the fake client in apiClient emulate the replicaset scale subresource, which the fake
+// clientset does not support on its own.
+func addScaleReactors(apiClient *clients.Settings) {
+	fakeClient, _ := apiClient.K8sClient.(*k8sfake.Clientset)
+	replicaSetGVR := appsv1.SchemeGroupVersion.WithResource("replicasets")
+
+	fakeClient.PrependReactor("get", "replicasets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+
+		getAction, _ := action.(k8stesting.GetAction)
+
+		object, err := fakeClient.Tracker().Get(replicaSetGVR, getAction.GetNamespace(), getAction.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+
+		replicaSet, _ := object.(*appsv1.ReplicaSet)
+
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: replicaSet.Name, Namespace: replicaSet.Namespace},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: ptr.Deref(replicaSet.Spec.Replicas, 1)},
+		}, nil
+	})
+
+	fakeClient.PrependReactor("update", "replicasets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+
+		updateAction, _ := action.(k8stesting.UpdateAction)
+		scale, _ := updateAction.GetObject().(*autoscalingv1.Scale)
+
+		object, err := fakeClient.Tracker().Get(replicaSetGVR, updateAction.GetNamespace(), scale.Name)
+		if err != nil {
+			return true, nil, err
+		}
+
+		replicaSet, _ := object.(*appsv1.ReplicaSet)
+		replicaSet.Spec.Replicas = ptr.To(scale.Spec.Replicas)
+
+		return true, scale, fakeClient.Tracker().Update(replicaSetGVR, replicaSet, replicaSet.Namespace)
+	})
+}
@@ -0,0 +1,225 @@
+// Package resourceusage records the resource usage of pods, nodes or any other source at a fixed interval over a
+// test window and summarizes the minimum, maximum and average usage of every resource.
+package resourceusage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/inf.v0"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+)
+
+// UsageGetter returns the current usage of a resource consumer. The GetUsage method of nodes.Builder and the
+// GetTotalUsage method of pod.Builder satisfy this signature.
+type UsageGetter func() (corev1.ResourceList, error)
+
+// Stats summarizes the samples recorded for a single resource.
+type Stats struct {
+	Min     resource.Quantity
+	Max     resource.Quantity
+	Average resource.Quantity
+}
+
+// Report summarizes the usage recorded by a Sampler between its start and stop.
+type Report struct {
+	// Start and End delimit the window during which samples were recorded.
+	Start time.Time
+	End   time.Time
+	// Samples is the number of successful usage reads.
+	Samples int
+	// Failures is the number of usage reads that returned an error and were skipped.
+	Failures int
+	// Resources contains the statistics of every resource present in at least one sample.
+	Resources map[corev1.ResourceName]Stats
+}
+
+// Sampler periodically records usage from a UsageGetter in the background.
+type Sampler struct {
+	getter   UsageGetter
+	interval time.Duration
+
+	mutex     sync.Mutex
+	samples   []corev1.ResourceList
+	failures  int
+	lastError error
+	start     time.Time
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewSampler creates a Sampler that reads usage from the getter every interval once started.
+func NewSampler(getter UsageGetter, interval time.Duration) (*Sampler, error) {
+	klog.V(100).Infof("Initializing new resource usage sampler with interval %s", interval)
+
+	if getter == nil {
+		klog.V(100).Info("The usage getter of the sampler is nil")
+
+		return nil, fmt.Errorf("sampler 'getter' cannot be nil")
+	}
+
+	if interval <= 0 {
+		klog.V(100).Info("The interval of the sampler is not positive")
+
+		return nil, fmt.Errorf("sampler 'interval' must be greater than zero")
+	}
+
+	return &Sampler{getter: getter, interval: interval}, nil
+}
+
+// Start begins recording samples in the background. The first sample is recorded immediately.
+func (sampler *Sampler) Start() error {
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	if sampler.done != nil {
+		return fmt.Errorf("sampler has already been started")
+	}
+
+	klog.V(100).Infof("Starting resource usage sampler with interval %s", sampler.interval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sampler.cancel = cancel
+	sampler.done = make(chan struct{})
+	sampler.start = time.Now()
+
+	go sampler.run(ctx)
+
+	return nil
+}
+
+// Stop stops recording samples and returns the report of the recorded window. An error is returned if the sampler
+// was not started or if no sample could be recorded.
+func (sampler *Sampler) Stop() (*Report, error) {
+	sampler.mutex.Lock()
+	cancel, done := sampler.cancel, sampler.done
+	sampler.mutex.Unlock()
+
+	if done == nil {
+		return nil, fmt.Errorf("sampler has not been started")
+	}
+
+	klog.V(100).Info("Stopping resource usage sampler")
+
+	cancel()
+	<-done
+
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	if len(sampler.samples) == 0 {
+		return nil, fmt.Errorf("no usage samples were recorded: %w", sampler.lastError)
+	}
+
+	return &Report{
+		Start:     sampler.start,
+		End:       time.Now(),
+		Samples:   len(sampler.samples),
+		Failures:  sampler.failures,
+		Resources: summarize(sampler.samples),
+	}, nil
+}
+
+// Sample records usage from the getter every interval for the duration of the window and returns the report. It
+// blocks until the window has elapsed.
+func Sample(getter UsageGetter, interval, window time.Duration) (*Report, error) {
+	sampler, err := NewSampler(getter, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sampler.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	time.Sleep(window)
+
+	return sampler.Stop()
+}
+
+// run records samples until the context is cancelled.
+func (sampler *Sampler) run(ctx context.Context) {
+	defer close(sampler.done)
+
+	ticker := time.NewTicker(sampler.interval)
+	defer ticker.Stop()
+
+	for {
+		sampler.record()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// record reads the usage once and saves either the sample or the failure.
+func (sampler *Sampler) record() {
+	usage, err := sampler.getter()
+
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+
+	if err != nil {
+		klog.V(100).Infof("Failed to record resource usage sample: %v", err)
+
+		sampler.failures++
+		sampler.lastError = err
+
+		return
+	}
+
+	sampler.samples = append(sampler.samples, usage.DeepCopy())
+}
+
+// summarize computes the statistics of every resource present in the samples. The average is calculated with milli
+// precision and uses the format of the first sample of the resource. Quantities are summed with arbitrary precision so
+// large quantities do not overflow.
+func summarize(samples []corev1.ResourceList) map[corev1.ResourceName]Stats {
+	stats := make(map[corev1.ResourceName]Stats)
+	sums := make(map[corev1.ResourceName]*resource.Quantity)
+	counts := make(map[corev1.ResourceName]int64)
+
+	for _, sample := range samples {
+		for resourceName, quantity := range sample {
+			resourceStats, found := stats[resourceName]
+			if !found {
+				resourceStats = Stats{Min: quantity.DeepCopy(), Max: quantity.DeepCopy()}
+			}
+
+			if quantity.Cmp(resourceStats.Min) < 0 {
+				resourceStats.Min = quantity.DeepCopy()
+			}
+
+			if quantity.Cmp(resourceStats.Max) > 0 {
+				resourceStats.Max = quantity.DeepCopy()
+			}
+
+			stats[resourceName] = resourceStats
+			counts[resourceName]++
+
+			if sum, found := sums[resourceName]; found {
+				sum.Add(quantity)
+			} else {
+				sum := quantity.DeepCopy()
+				sums[resourceName] = &sum
+			}
+		}
+	}
+
+	for resourceName, resourceStats := range stats {
+		average := new(inf.Dec).QuoRound(
+			sums[resourceName].AsDec(), inf.NewDec(counts[resourceName], 0), 3, inf.RoundDown)
+		resourceStats.Average = *resource.NewDecimalQuantity(*average, resourceStats.Min.Format)
+		stats[resourceName] = resourceStats
+	}
+
+	return stats
+}
@@ -0,0 +1,133 @@
+package resourceusage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNewSampler(t *testing.T) {
+	testCases := []struct {
+		getter        UsageGetter
+		interval      time.Duration
+		expectedError error
+	}{
+		{
+			getter:        func() (corev1.ResourceList, error) { return nil, nil },
+			interval:      time.Second,
+			expectedError: nil,
+		},
+		{
+			getter:        nil,
+			interval:      time.Second,
+			expectedError: fmt.Errorf("sampler 'getter' cannot be nil"),
+		},
+		{
+			getter:        func() (corev1.ResourceList, error) { return nil, nil },
+			interval:      0,
+			expectedError: fmt.Errorf("sampler 'interval' must be greater than zero"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		sampler, err := NewSampler(testCase.getter, testCase.interval)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.NotNil(t, sampler)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	getter := newSequenceGetter([]corev1.ResourceList{
+		{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		{corev1.ResourceCPU: resource.MustParse("300m"), corev1.ResourceMemory: resource.MustParse("3Gi")},
+	}, nil)
+
+	report, err := Sample(getter, 5*time.Millisecond, 50*time.Millisecond)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, report.Samples, 2)
+	assert.Equal(t, 0, report.Failures)
+	assert.True(t, report.End.After(report.Start))
+
+	cpuStats := report.Resources[corev1.ResourceCPU]
+	assert.Equal(t, int64(100), cpuStats.Min.MilliValue())
+	assert.Equal(t, int64(300), cpuStats.Max.MilliValue())
+	assert.True(t, cpuStats.Average.Cmp(cpuStats.Min) > 0)
+	assert.True(t, cpuStats.Average.Cmp(cpuStats.Max) <= 0)
+
+	memoryStats := report.Resources[corev1.ResourceMemory]
+	assert.Equal(t, int64(1<<30), memoryStats.Min.Value())
+	assert.Equal(t, int64(3<<30), memoryStats.Max.Value())
+}
+
+func TestSamplerStartStop(t *testing.T) {
+	sampler, err := NewSampler(newSequenceGetter(nil, fmt.Errorf("metrics unavailable")), time.Millisecond)
+	assert.Nil(t, err)
+
+	_, err = sampler.Stop()
+	assert.Equal(t, fmt.Errorf("sampler has not been started"), err)
+
+	assert.Nil(t, sampler.Start())
+	assert.Equal(t, fmt.Errorf("sampler has already been started"), sampler.Start())
+
+	time.Sleep(5 * time.Millisecond)
+
+	report, err := sampler.Stop()
+	assert.Nil(t, report)
+	assert.EqualError(t, err, "no usage samples were recorded: metrics unavailable")
+}
+
+func TestSummarize(t *testing.T) {
+	stats := summarize([]corev1.ResourceList{
+		{corev1.ResourceCPU: resource.MustParse("100m")},
+		{corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("2Mi")},
+		{corev1.ResourceCPU: resource.MustParse("600m")},
+	})
+
+	cpuStats := stats[corev1.ResourceCPU]
+	assert.Equal(t, int64(100), cpuStats.Min.MilliValue())
+	assert.Equal(t, int64(600), cpuStats.Max.MilliValue())
+	assert.Equal(t, int64(300), cpuStats.Average.MilliValue())
+
+	memoryStats := stats[corev1.ResourceMemory]
+	assert.Equal(t, int64(2<<20), memoryStats.Average.Value())
+
+	// The sum of these quantities, in bytes and even more so in millibytes, does not fit in an int64.
+	stats = summarize([]corev1.ResourceList{
+		{corev1.ResourceMemory: resource.MustParse("4Ei")},
+		{corev1.ResourceMemory: resource.MustParse("6Ei")},
+	})
+
+	memoryStats = stats[corev1.ResourceMemory]
+	assert.Equal(t, 0, memoryStats.Average.Cmp(resource.MustParse("5Ei")))
+}
+
+// newSequenceGetter returns a UsageGetter that cycles through the provided samples, or always returns err when no
+// samples are provided.
+func newSequenceGetter(samples []corev1.ResourceList, err error) UsageGetter {
+	var (
+		mutex sync.Mutex
+		index int
+	)
+
+	return func() (corev1.ResourceList, error) {
+		if len(samples) == 0 {
+			return nil, err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		sample := samples[index%len(samples)]
+		index++
+
+		return sample, nil
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeResourceTopology describes node resources and their topology.
+// +kubebuilder:resource:scope=Cluster,shortName=node-res-topo
+type NodeResourceTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// DEPRECATED (to be removed in v1beta1): use top level attributes if needed
+	// +optional
+	TopologyPolicies []string `json:"topologyPolicies,omitempty"`
+
+	Zones ZoneList `json:"zones"`
+	// +optional
+	Attributes AttributeList `json:"attributes,omitempty"`
+}
+
+// Zone represents a resource topology zone, e.g. socket, node, die or core.
+type Zone struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// +optional
+	Parent string `json:"parent,omitempty"`
+	// +optional
+	Costs CostList `json:"costs,omitempty"`
+	// +optional
+	Attributes AttributeList `json:"attributes,omitempty"`
+	// +optional
+	Resources ResourceInfoList `json:"resources,omitempty"`
+}
+
+// ZoneList contains an array of Zone objects.
+type ZoneList []Zone
+
+// ResourceInfo contains information about one resource type.
+type ResourceInfo struct {
+	// Name of the resource.
+	Name string `json:"name"`
+	// Capacity of the resource, corresponding to capacity in node status, i.e.
+	// total amount of this resource that the node has.
+	Capacity resource.Quantity `json:"capacity"`
+	// Allocatable quantity of the resource, corresponding to allocatable in
+	// node status, i.e. total amount of this resource available to be used by
+	// pods.
+	Allocatable resource.Quantity `json:"allocatable"`
+	// Available is the amount of this resource currently available for new (to
+	// be scheduled) pods, i.e. Allocatable minus the resources reserved by
+	// currently running pods.
+	Available resource.Quantity `json:"available"`
+}
+
+// ResourceInfoList contains an array of ResourceInfo objects.
+type ResourceInfoList []ResourceInfo
+
+// CostInfo describes the cost (or distance) between two Zones.
+type CostInfo struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+// CostList contains an array of CostInfo objects.
+type CostList []CostInfo
+
+// AttributeInfo contains one attribute of a Zone.
+type AttributeInfo struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AttributeList contains an array of AttributeInfo objects.
+type AttributeList []AttributeInfo
+
+// NodeResourceTopologyList is a list of NodeResourceTopology resources.
+type NodeResourceTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodeResourceTopology `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeResourceTopology{}, &NodeResourceTopologyList{})
+}
@@ -0,0 +1,90 @@
+package sriov
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+)
+
+// BMCNICCorrelation holds an interface reported by the SR-IOV operator and the BMC network adapter and port with the
+// same MAC address.
+type BMCNICCorrelation struct {
+	// Interface is the interface reported by the SR-IOV operator.
+	Interface srIovV1.InterfaceExt
+	// Adapter is the BMC network adapter of the interface, or nil if no BMC port has its MAC address.
+	Adapter *bmc.NetworkAdapter
+	// Port is the BMC network port of the interface, or nil if no BMC port has its MAC address.
+	Port *bmc.NetworkPort
+	// Mismatches describes the differences between the SR-IOV operator and the BMC views of the interface, such as
+	// different vendor IDs.
+	Mismatches []string
+}
+
+// CorrelateBMCNICs matches the interfaces returned by NetworkNodeStateBuilder.GetNICs with the ports of the network
+// adapters returned by bmc.BMC.NetworkAdapters by MAC address. The returned correlations are in the order of
+// interfaces. Vendor and device IDs, and the number of virtual functions when both sides report it, are compared for
+// matched interfaces.
+func CorrelateBMCNICs(adapters []bmc.NetworkAdapter, interfaces srIovV1.InterfaceExts) []BMCNICCorrelation {
+	var correlations []BMCNICCorrelation
+
+	for _, sriovInterface := range interfaces {
+		correlation := BMCNICCorrelation{Interface: sriovInterface}
+		mac := strings.ToLower(sriovInterface.Mac)
+
+		for adapterIndex := range adapters {
+			portIndex := slices.IndexFunc(adapters[adapterIndex].Ports, func(port bmc.NetworkPort) bool {
+				return mac != "" && slices.Contains(port.MACAddresses, mac)
+			})
+
+			if portIndex >= 0 {
+				correlation.Adapter = &adapters[adapterIndex]
+				correlation.Port = &adapters[adapterIndex].Ports[portIndex]
+
+				break
+			}
+		}
+
+		if correlation.Adapter != nil {
+			correlation.Mismatches = bmcNICMismatches(sriovInterface, correlation.Adapter, correlation.Port)
+		}
+
+		correlations = append(correlations, correlation)
+	}
+
+	return correlations
+}
+
+// bmcNICMismatches compares the SR-IOV operator view of an interface with the BMC adapter and port it was matched to.
+func bmcNICMismatches(sriovInterface srIovV1.InterfaceExt, adapter *bmc.NetworkAdapter, port *bmc.NetworkPort) []string {
+	var mismatches []string
+
+	if adapter.VendorID != "" && !pciIDsEqual(sriovInterface.Vendor, adapter.VendorID) {
+		mismatches = append(mismatches,
+			fmt.Sprintf("vendor ID %s does not match BMC vendor ID %s", sriovInterface.Vendor, adapter.VendorID))
+	}
+
+	if adapter.DeviceID != "" && !pciIDsEqual(sriovInterface.DeviceID, adapter.DeviceID) {
+		mismatches = append(mismatches,
+			fmt.Sprintf("device ID %s does not match BMC device ID %s", sriovInterface.DeviceID, adapter.DeviceID))
+	}
+
+	if sriovInterface.TotalVfs > 0 && port.MaxVirtualFunctions > 0 && sriovInterface.TotalVfs != port.MaxVirtualFunctions {
+		mismatches = append(mismatches, fmt.Sprintf(
+			"total VFs %d does not match BMC max virtual functions %d", sriovInterface.TotalVfs, port.MaxVirtualFunctions))
+	}
+
+	return mismatches
+}
+
+// pciIDsEqual compares PCI IDs ignoring case and a 0x prefix, since the SR-IOV operator reports IDs such as 8086
+// while Redfish reports them as 0x8086.
+func pciIDsEqual(first, second string) bool {
+	normalize := func(pciID string) string {
+		return strings.TrimPrefix(strings.ToLower(pciID), "0x")
+	}
+
+	return normalize(first) == normalize(second)
+}
@@ -0,0 +1,44 @@
+package sriov
+
+import (
+	"testing"
+
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelateBMCNICs(t *testing.T) {
+	adapters := []bmc.NetworkAdapter{{
+		ID:       "NIC.Slot.1",
+		VendorID: "0x8086",
+		DeviceID: "0x159B",
+		Ports: []bmc.NetworkPort{
+			{ID: "NIC.Slot.1-1", MACAddresses: []string{"b4:96:91:aa:bb:01"}},
+			{ID: "NIC.Slot.1-2", MACAddresses: []string{"b4:96:91:aa:bb:02"}, MaxVirtualFunctions: 128},
+		},
+	}}
+
+	correlations := CorrelateBMCNICs(adapters, srIovV1.InterfaceExts{
+		{Name: "ens1f0", Mac: "B4:96:91:AA:BB:01", Vendor: "8086", DeviceID: "159b", TotalVfs: 128},
+		{Name: "ens1f1", Mac: "b4:96:91:aa:bb:02", Vendor: "8086", DeviceID: "1593", TotalVfs: 64},
+		{Name: "ens2f0", Mac: "0c:42:a1:00:00:01", Vendor: "15b3", DeviceID: "101d"},
+	})
+
+	assert.Len(t, correlations, 3)
+
+	assert.Equal(t, "ens1f0", correlations[0].Interface.Name)
+	assert.Equal(t, &adapters[0], correlations[0].Adapter)
+	assert.Equal(t, &adapters[0].Ports[0], correlations[0].Port)
+	assert.Empty(t, correlations[0].Mismatches)
+
+	assert.Equal(t, &adapters[0].Ports[1], correlations[1].Port)
+	assert.Equal(t, []string{
+		"device ID 1593 does not match BMC device ID 0x159B",
+		"total VFs 64 does not match BMC max virtual functions 128",
+	}, correlations[1].Mismatches)
+
+	assert.Nil(t, correlations[2].Adapter)
+	assert.Nil(t, correlations[2].Port)
+	assert.Empty(t, correlations[2].Mismatches)
+}
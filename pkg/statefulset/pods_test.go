@@ -0,0 +1,133 @@
+package statefulset
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestStatefulSetListPods(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		options       []metav1.ListOptions
+		expectedPods  []string
+		expectedError error
+	}{
+		{
+			exists:       true,
+			expectedPods: []string{"owned-ready", "owned-pending"},
+		},
+		{
+			exists:       true,
+			options:      []metav1.ListOptions{{LabelSelector: "ready=true"}},
+			expectedPods: []string{"owned-ready"},
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot list pods of statefulset test-statefulset in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = buildDummyPodsTestObjects()
+		}
+
+		pods, err := buildTestBuilderWithFakeObjects(runtimeObjects).ListPods(testCase.options...)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			var podNames []string
+			for _, podBuilder := range pods {
+				podNames = append(podNames, podBuilder.Definition.Name)
+			}
+
+			assert.ElementsMatch(t, testCase.expectedPods, podNames)
+		}
+	}
+}
+
+func TestStatefulSetGetReadyPodLog(t *testing.T) {
+	testCases := []struct {
+		ready         bool
+		expectedError error
+	}{
+		{
+			ready:         true,
+			expectedError: nil,
+		},
+		{
+			ready:         false,
+			expectedError: fmt.Errorf("no ready pod found for statefulset test-statefulset in namespace test-namespace"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := buildDummyPodsTestObjects()
+
+		if !testCase.ready {
+			runtimeObjects = runtimeObjects[:len(runtimeObjects)-1]
+		}
+
+		log, err := buildTestBuilderWithFakeObjects(runtimeObjects).GetReadyPodLog("test")
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, "fake logs", log)
+		}
+	}
+}
+
+// buildDummyPodsTestObjects returns the statefulset along with pods that are pending, owned by another controller or
+// not matching its selector. The last object is the only ready pod managed by the statefulset.
+func buildDummyPodsTestObjects() []runtime.Object {
+	labels := map[string]string{"demo": "test"}
+
+	return []runtime.Object{
+		buildDummyRolloutStatefulSet(true),
+		buildDummyWorkloadPod("owned-pending", labels, defaultStatefulSetUID, false),
+		buildDummyWorkloadPod("other-owner", labels, "other-uid", true),
+		buildDummyWorkloadPod("other-labels", map[string]string{"other": "label"}, defaultStatefulSetUID, true),
+		buildDummyWorkloadPod("owned-ready", labels, defaultStatefulSetUID, true),
+	}
+}
+
+func buildDummyWorkloadPod(name string, labels map[string]string, controllerUID types.UID, ready bool) *corev1.Pod {
+	podLabels := map[string]string{"ready": strconv.FormatBool(ready)}
+	for key, value := range labels {
+		podLabels[key] = value
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+			Labels:    podLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "OWNERstatefulset",
+				Name:       "owner",
+				UID:        controllerUID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	if ready {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+
+	return pod
+}
@@ -0,0 +1,244 @@
+package statefulset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/rollout"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// RolloutStatus provides a summary of the rollout progress of a statefulset.
+type RolloutStatus = rollout.Status
+
+// RolloutRevision describes a single revision in the rollout history of a statefulset.
+type RolloutRevision = rollout.Revision
+
+// RolloutRestart triggers a rolling restart of the statefulset by updating the restartedAt annotation on its pod
+// template, the same way kubectl rollout restart does.
+func (builder *Builder) RolloutRestart() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Restarting rollout of statefulset %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot restart statefulset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	patch, restartedAt, err := rollout.RestartPatch()
+	if err != nil {
+		return builder, err
+	}
+
+	builder.Object, err = builder.apiClient.StatefulSets(builder.Definition.Namespace).Patch(
+		logging.DiscardContext(), builder.Definition.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to restart statefulset %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, err
+	}
+
+	if builder.Definition.Spec.Template.Annotations == nil {
+		builder.Definition.Spec.Template.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Spec.Template.Annotations[rollout.RestartedAtAnnotation] = restartedAt
+
+	return builder, nil
+}
+
+// RolloutStatus returns the current rollout status of the statefulset, including the number of replicas owned by
+// the current and update revisions.
+func (builder *Builder) RolloutStatus() (*RolloutStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rollout status of statefulset %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get rollout status of statefulset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	revisions, err := builder.listOwnedControllerRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	revisionNumbers := make(map[string]int64)
+	for _, revision := range revisions {
+		revisionNumbers[revision.Name] = revision.Revision
+	}
+
+	status := getRolloutStatus(builder.Object)
+
+	if revision, ok := revisionNumbers[builder.Object.Status.CurrentRevision]; ok &&
+		builder.Object.Status.CurrentReplicas > 0 {
+		status.RevisionReplicas[revision] = builder.Object.Status.CurrentReplicas
+	}
+
+	if revision, ok := revisionNumbers[builder.Object.Status.UpdateRevision]; ok &&
+		builder.Object.Status.UpdateRevision != builder.Object.Status.CurrentRevision &&
+		builder.Object.Status.UpdatedReplicas > 0 {
+		status.RevisionReplicas[revision] = builder.Object.Status.UpdatedReplicas
+	}
+
+	return status, nil
+}
+
+// WaitForRolloutComplete waits for the duration of the defined timeout or until the latest revision of the
+// statefulset has been fully rolled out.
+func (builder *Builder) WaitForRolloutComplete(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until rollout of statefulset %s in namespace %s is complete",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return fmt.Errorf("cannot wait for rollout of statefulset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.StatefulSets(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(100).Infof("Failed to get statefulset %s in namespace %s: %v",
+					builder.Definition.Name, builder.Definition.Namespace, err)
+
+				return false, nil
+			}
+
+			return getRolloutStatus(builder.Object).Complete, nil
+		})
+}
+
+// RolloutHistory returns the revisions of the statefulset, ordered from oldest to newest, based on the
+// ControllerRevisions owned by the statefulset.
+func (builder *Builder) RolloutHistory() ([]RolloutRevision, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Getting rollout history of statefulset %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("cannot get rollout history of statefulset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	revisions, err := builder.listOwnedControllerRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	return rollout.ControllerRevisionHistory(revisions)
+}
+
+// RolloutUndo rolls the statefulset back to the pod template of the provided revision. If toRevision is 0, the
+// statefulset is rolled back to the revision immediately preceding the current one.
+func (builder *Builder) RolloutUndo(toRevision int64) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Rolling back statefulset %s in namespace %s to revision %d",
+		builder.Definition.Name, builder.Definition.Namespace, toRevision)
+
+	if toRevision < 0 {
+		return builder, fmt.Errorf("statefulset revision cannot be negative")
+	}
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot roll back statefulset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	revisions, err := builder.listOwnedControllerRevisions()
+	if err != nil {
+		return builder, err
+	}
+
+	target, err := rollout.FindUndoControllerRevision(revisions, toRevision)
+	if err != nil {
+		return builder, fmt.Errorf("cannot roll back statefulset %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	// ControllerRevision data for statefulsets is a strategic merge patch that replaces the pod template, so it can
+	// be applied directly.
+	builder.Object, err = builder.apiClient.StatefulSets(builder.Definition.Namespace).Patch(
+		logging.DiscardContext(), builder.Definition.Name, types.StrategicMergePatchType, target.Data.Raw,
+		metav1.PatchOptions{})
+	if err != nil {
+		klog.V(100).Infof("Failed to roll back statefulset %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, err
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// listOwnedControllerRevisions returns the ControllerRevisions controlled by the statefulset, ordered from oldest to
+// newest. It assumes the builder is valid and builder.Object is set.
+func (builder *Builder) listOwnedControllerRevisions() ([]*appsv1.ControllerRevision, error) {
+	return rollout.ListControllerRevisions(builder.apiClient, "statefulset", builder.Object, builder.Object.Spec.Selector)
+}
+
+// getRolloutStatus computes the rollout status of the statefulset from its spec and status, using the same
+// completeness checks as kubectl rollout status. RevisionReplicas is initialized but left empty.
+func getRolloutStatus(statefulSet *appsv1.StatefulSet) *RolloutStatus {
+	desiredReplicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		desiredReplicas = *statefulSet.Spec.Replicas
+	}
+
+	status := &RolloutStatus{
+		Generation:         statefulSet.Generation,
+		ObservedGeneration: statefulSet.Status.ObservedGeneration,
+		DesiredReplicas:    desiredReplicas,
+		UpdatedReplicas:    statefulSet.Status.UpdatedReplicas,
+		ReadyReplicas:      statefulSet.Status.ReadyReplicas,
+		AvailableReplicas:  statefulSet.Status.AvailableReplicas,
+		RevisionReplicas:   make(map[int64]int32),
+	}
+
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+		statefulSet.Status.ReadyReplicas < desiredReplicas {
+		return status
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		rollingUpdate != nil && rollingUpdate.Partition != nil {
+		status.Complete = statefulSet.Status.UpdatedReplicas >= desiredReplicas-*rollingUpdate.Partition
+
+		return status
+	}
+
+	status.Complete = statefulSet.Status.UpdateRevision == statefulSet.Status.CurrentRevision
+
+	return status
+}
@@ -0,0 +1,267 @@
+package statefulset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/rollout"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const defaultStatefulSetUID = types.UID("test-statefulset-uid")
+
+func TestStatefulSetRolloutRestart(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			expectedError: nil,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf(
+				"cannot restart statefulset test-statefulset in namespace test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutStatefulSet(true))
+		}
+
+		testBuilder, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutRestart()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.NotEmpty(t, testBuilder.Object.Spec.Template.Annotations[rollout.RestartedAtAnnotation])
+			assert.Equal(t, testBuilder.Object.Spec.Template.Annotations[rollout.RestartedAtAnnotation],
+				testBuilder.Definition.Spec.Template.Annotations[rollout.RestartedAtAnnotation])
+		}
+	}
+}
+
+func TestStatefulSetRolloutStatus(t *testing.T) {
+	testCases := []struct {
+		complete         bool
+		partition        *int32
+		expectedComplete bool
+		expectedReplicas map[int64]int32
+	}{
+		{
+			complete:         true,
+			expectedComplete: true,
+			expectedReplicas: map[int64]int32{2: 2},
+		},
+		{
+			complete:         false,
+			expectedComplete: false,
+			expectedReplicas: map[int64]int32{1: 1, 2: 1},
+		},
+		{
+			complete:         false,
+			partition:        ptr.To[int32](1),
+			expectedComplete: true,
+			expectedReplicas: map[int64]int32{1: 1, 2: 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		statefulSet := buildDummyRolloutStatefulSet(testCase.complete)
+
+		if testCase.partition != nil {
+			statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: testCase.partition},
+			}
+		}
+
+		runtimeObjects := []runtime.Object{statefulSet}
+		runtimeObjects = append(runtimeObjects, buildDummyRolloutControllerRevisions()...)
+
+		status, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutStatus()
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedComplete, status.Complete)
+		assert.Equal(t, int32(2), status.DesiredReplicas)
+		assert.Equal(t, testCase.expectedReplicas, status.RevisionReplicas)
+	}
+}
+
+func TestStatefulSetWaitForRolloutComplete(t *testing.T) {
+	testCases := []struct {
+		exists        bool
+		complete      bool
+		expectedError error
+	}{
+		{
+			exists:        true,
+			complete:      true,
+			expectedError: nil,
+		},
+		{
+			exists:        true,
+			complete:      false,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			exists: false,
+			expectedError: fmt.Errorf("cannot wait for rollout of statefulset test-statefulset in namespace " +
+				"test-namespace because it does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.exists {
+			runtimeObjects = append(runtimeObjects, buildDummyRolloutStatefulSet(testCase.complete))
+		}
+
+		err := buildTestBuilderWithFakeObjects(runtimeObjects).WaitForRolloutComplete(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestStatefulSetRolloutHistory(t *testing.T) {
+	runtimeObjects := []runtime.Object{buildDummyRolloutStatefulSet(false)}
+	runtimeObjects = append(runtimeObjects, buildDummyRolloutControllerRevisions()...)
+
+	history, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutHistory()
+	assert.Nil(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, "first", history[0].ChangeCause)
+	assert.Equal(t, "image-1", history[0].Template.Spec.Containers[0].Image)
+	assert.Equal(t, int64(2), history[1].Revision)
+}
+
+func TestStatefulSetRolloutUndo(t *testing.T) {
+	testCases := []struct {
+		toRevision    int64
+		expectedImage string
+		expectedError string
+	}{
+		{
+			toRevision:    0,
+			expectedImage: "image-1",
+			expectedError: "",
+		},
+		{
+			toRevision:    1,
+			expectedImage: "image-1",
+			expectedError: "",
+		},
+		{
+			toRevision: 5,
+			expectedError: "cannot roll back statefulset test-statefulset in namespace test-namespace: " +
+				"revision 5 not found",
+		},
+		{
+			toRevision:    -1,
+			expectedError: "statefulset revision cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyRolloutStatefulSet(false)}
+		runtimeObjects = append(runtimeObjects, buildDummyRolloutControllerRevisions()...)
+
+		testBuilder, err := buildTestBuilderWithFakeObjects(runtimeObjects).RolloutUndo(testCase.toRevision)
+		if testCase.expectedError == "" {
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedImage, testBuilder.Object.Spec.Template.Spec.Containers[0].Image)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError)
+		}
+	}
+}
+
+// buildDummyRolloutStatefulSet returns a statefulset with two desired replicas whose status is either fully rolled
+// out or has one replica on each of the two revisions.
+func buildDummyRolloutStatefulSet(complete bool) *appsv1.StatefulSet {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-statefulset",
+			Namespace:  "test-namespace",
+			UID:        defaultStatefulSetUID,
+			Generation: 2,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To[int32](2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"demo": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"demo": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "image-2"}},
+				},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2,
+			Replicas:           2,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+			CurrentReplicas:    2,
+			UpdatedReplicas:    2,
+			CurrentRevision:    "test-statefulset-2",
+			UpdateRevision:     "test-statefulset-2",
+		},
+	}
+
+	if !complete {
+		statefulSet.Status.CurrentReplicas = 1
+		statefulSet.Status.UpdatedReplicas = 1
+		statefulSet.Status.CurrentRevision = "test-statefulset-1"
+	}
+
+	return statefulSet
+}
+
+// buildDummyRolloutControllerRevisions returns ControllerRevisions for revisions 1 and 2 owned by the dummy rollout
+// statefulset, along with a ControllerRevision that matches the selector but is not owned by it.
+func buildDummyRolloutControllerRevisions() []runtime.Object {
+	return []runtime.Object{
+		buildDummyRolloutControllerRevision("test-statefulset-2", 2, "second", "image-2", true),
+		buildDummyRolloutControllerRevision("test-statefulset-1", 1, "first", "image-1", true),
+		buildDummyRolloutControllerRevision("other", 3, "", "image-3", false),
+	}
+}
+
+func buildDummyRolloutControllerRevision(
+	name string, revision int64, changeCause, image string, owned bool) *appsv1.ControllerRevision {
+	controllerRevision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "test-namespace",
+			Labels:      map[string]string{"demo": "test"},
+			Annotations: map[string]string{rollout.ChangeCauseAnnotation: changeCause},
+		},
+		Revision: revision,
+		Data: runtime.RawExtension{Raw: fmt.Appendf(nil,
+			`{"spec":{"template":{"$patch":"replace","metadata":{"labels":{"demo":"test"}},`+
+				`"spec":{"containers":[{"name":"test","image":"%s"}]}}}}`, image)},
+	}
+
+	if owned {
+		controllerRevision.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Name:       "test-statefulset",
+			UID:        defaultStatefulSetUID,
+			Controller: ptr.To(true),
+		}}
+	}
+
+	return controllerRevision
+}
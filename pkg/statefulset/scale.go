@@ -0,0 +1,78 @@
+package statefulset
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/logging"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/internal/scale"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// Scale sets the number of replicas of the statefulset through the scale subresource. Only the replica count is changed
+// on the cluster, so concurrent changes to the rest of the spec are preserved.
+func (builder *Builder) Scale(replicas int32) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	klog.V(100).Infof("Scaling statefulset %s in namespace %s to %d replicas",
+		builder.Definition.Name, builder.Definition.Namespace, replicas)
+
+	if err := scale.ValidateReplicas("statefulset", replicas); err != nil {
+		return builder, err
+	}
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("cannot scale statefulset %s in namespace %s because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	client := builder.apiClient.StatefulSets(builder.Definition.Namespace)
+
+	err := scale.Update(
+		"statefulset", builder.Definition.Name, builder.Definition.Namespace, replicas, client.GetScale, client.UpdateScale)
+	if err != nil {
+		return builder, err
+	}
+
+	builder.Definition.Spec.Replicas = &replicas
+
+	builder.Object, err = client.Get(logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+
+	return builder, err
+}
+
+// WaitForReplicas waits for the duration of the defined timeout or until the statefulset has observed its latest
+// generation and has exactly the provided number of replicas, all of which are ready.
+func (builder *Builder) WaitForReplicas(replicas int32, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	klog.V(100).Infof("Waiting for the defined period until statefulset %s in namespace %s has %d ready replicas",
+		builder.Definition.Name, builder.Definition.Namespace, replicas)
+
+	if err := scale.ValidateReplicas("statefulset", replicas); err != nil {
+		return err
+	}
+
+	return scale.WaitForReplicas("statefulset", builder.Definition.Name, builder.Definition.Namespace, replicas, timeout,
+		func() (scale.ReplicaStatus, error) {
+			var err error
+
+			builder.Object, err = builder.apiClient.StatefulSets(builder.Definition.Namespace).Get(
+				logging.DiscardContext(), builder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				return scale.ReplicaStatus{}, err
+			}
+
+			return scale.ReplicaStatus{
+				Generation:         builder.Object.Generation,
+				ObservedGeneration: builder.Object.Status.ObservedGeneration,
+				Replicas:           builder.Object.Status.Replicas,
+				ReadyReplicas:      builder.Object.Status.ReadyReplicas,
+			}, nil
+		})
+}